@@ -0,0 +1,285 @@
+package logger
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Archive — файл, закрытый при ротации (архив, если включено сжатие).
+type Archive struct {
+	// Name — имя файла без каталога.
+	Name string
+	// Date — начало периода, за который записан файл.
+	Date time.Time
+	Size int64
+	Body io.Reader
+}
+
+// ArchiveUploader загружает файлы после ротации во внешнее хранилище.
+type ArchiveUploader interface {
+	Upload(ctx context.Context, archive Archive) error
+}
+
+// UploadArchives загружает каждый файл после ротации и сжатия через
+// uploader; при removeLocal успешно загруженный файл удаляется. Ошибки
+// загрузки попадают в Diagnostics, файл при этом остаётся на диске. При
+// removeLocal оставшиеся файлы загружаются повторно при запуске и после
+// следующей ротации; без removeLocal неудачная загрузка не повторяется.
+// Загрузка идёт без блокировки каталога, не дольше десяти минут на файл.
+func UploadArchives(uploader ArchiveUploader, removeLocal bool) Option {
+	return func(l *Logger) {
+		l.uploader = uploader
+		l.removeUploaded = removeLocal
+	}
+}
+
+// uploadTimeout ограничивает загрузку одного файла, чтобы зависшее
+// хранилище не задерживало Close.
+const uploadTimeout = 10 * time.Minute
+
+// uploadPending загружает закрытый при ротации файл src (пустой — без
+// него), а при removeUploaded и прошлые файлы, оставшиеся на диске после
+// неудачных загрузок. Загрузки одного ротатора идут по очереди.
+func (r *fileRotator) uploadPending(src string) {
+	r.uploadMu.Lock()
+	defer r.uploadMu.Unlock()
+
+	var paths []string
+	if src != "" {
+		paths = append(paths, r.uploadPath(src))
+	}
+
+	if r.removeUploaded {
+		backups, _ := r.retentionBackups()
+		for _, backup := range backups {
+			// Несжатый файл ещё ждёт сжатия и будет загружен после него;
+			// файлы других процессов загружают они сами.
+			if !backup.own || r.compress && !backup.archive {
+				continue
+			}
+
+			if len(paths) == 0 || backup.path != paths[0] {
+				paths = append(paths, backup.path)
+			}
+		}
+	}
+
+	for _, path := range paths {
+		r.uploadFile(path)
+	}
+}
+
+// uploadPath возвращает архив файла src, если он есть, иначе сам src.
+func (r *fileRotator) uploadPath(src string) string {
+	if _, err := r.fs().Stat(src + archiveExt); err == nil {
+		return src + archiveExt
+	}
+
+	return src
+}
+
+// uploadFile загружает файл path. Файл, уже удалённый другой загрузкой
+// или очисткой, пропускается.
+func (r *fileRotator) uploadFile(path string) {
+	name := filepath.Base(path)
+	date, _, _ := r.filenamePattern().parseSegment(strings.TrimSuffix(name, archiveExt))
+
+	file, err := r.fs().OpenFile(path, os.O_RDONLY, 0)
+	if errors.Is(err, fs.ErrNotExist) {
+		return
+	}
+	if err != nil {
+		r.diagnostics().Warn("upload failed", zap.String("file", path), zap.Error(err))
+		return
+	}
+
+	ctx := r.uploads
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, cancel := context.WithTimeout(ctx, uploadTimeout)
+	defer cancel()
+
+	info, err := file.Stat()
+	if err == nil {
+		err = r.uploader.Upload(ctx, Archive{Name: name, Date: date, Size: info.Size(), Body: file})
+	}
+	_ = file.Close()
+
+	if err != nil {
+		r.diagnostics().Warn("upload failed", zap.String("file", path), zap.Error(err))
+		return
+	}
+
+	r.diagnostics().Info("uploaded", zap.String("file", path))
+
+	if r.removeUploaded {
+		r.removeArtifact(path)
+	}
+}
+
+// S3Config — настройки загрузки в S3-совместимое хранилище (AWS S3, MinIO).
+type S3Config struct {
+	// Endpoint — адрес хранилища, например "https://s3.eu-west-1.amazonaws.com"
+	// или "http://minio:9000".
+	Endpoint string
+	Region   string
+	Bucket   string
+	// Prefix — шаблон префикса ключа; {date}, {year}, {month} и {day}
+	// заменяются датой файла, например "logs/{year}/{month}/".
+	Prefix    string
+	AccessKey string
+	SecretKey string
+	// PathStyle адресует бакет путём (endpoint/bucket/key), как принято в
+	// MinIO, а не поддоменом.
+	PathStyle bool
+}
+
+type s3Uploader struct {
+	cfg      S3Config
+	endpoint *url.URL
+	client   *http.Client
+	now      func() time.Time
+}
+
+// NewS3Uploader создаёт ArchiveUploader, загружающий файлы запросом PUT
+// с подписью AWS Signature Version 4.
+func NewS3Uploader(cfg S3Config) (ArchiveUploader, error) {
+	endpoint, err := url.Parse(cfg.Endpoint)
+	if err != nil || (endpoint.Scheme != "http" && endpoint.Scheme != "https") || endpoint.Host == "" {
+		return nil, fmt.Errorf("logger: invalid S3 endpoint %q", cfg.Endpoint)
+	}
+
+	if cfg.Bucket == "" || cfg.Region == "" || cfg.AccessKey == "" || cfg.SecretKey == "" {
+		return nil, fmt.Errorf("logger: S3 bucket, region and credentials are required")
+	}
+
+	return &s3Uploader{cfg: cfg, endpoint: endpoint, client: &http.Client{Timeout: uploadTimeout}, now: time.Now}, nil
+}
+
+// key возвращает ключ объекта для архива.
+func (u *s3Uploader) key(archive Archive) string {
+	prefix := strings.NewReplacer(
+		"{date}", archive.Date.Format("2006-01-02"),
+		"{year}", archive.Date.Format("2006"),
+		"{month}", archive.Date.Format("01"),
+		"{day}", archive.Date.Format("02"),
+	).Replace(u.cfg.Prefix)
+
+	return strings.TrimPrefix(prefix+archive.Name, "/")
+}
+
+func (u *s3Uploader) Upload(ctx context.Context, archive Archive) error {
+	target := *u.endpoint
+	if u.cfg.PathStyle {
+		target.Path = strings.TrimSuffix(target.Path, "/") + "/" + u.cfg.Bucket + "/" + u.key(archive)
+	} else {
+		target.Host = u.cfg.Bucket + "." + target.Host
+		target.Path = strings.TrimSuffix(target.Path, "/") + "/" + u.key(archive)
+	}
+	target.RawPath = awsURIEncode(target.Path)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, target.String(), archive.Body)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = archive.Size
+
+	u.sign(req, u.now().UTC())
+
+	resp, err := u.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("logger: S3 upload: %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	return nil
+}
+
+// sign добавляет заголовки подписи SigV4 без хеширования тела.
+func (u *s3Uploader) sign(req *http.Request, now time.Time) {
+	const payload = "UNSIGNED-PAYLOAD"
+
+	amzDate := now.Format("20060102T150405Z")
+	day := now.Format("20060102")
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payload)
+
+	canonical := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		"",
+		"host:" + req.URL.Host,
+		"x-amz-content-sha256:" + payload,
+		"x-amz-date:" + amzDate,
+		"",
+		"host;x-amz-content-sha256;x-amz-date",
+		payload,
+	}, "\n")
+
+	scope := day + "/" + u.cfg.Region + "/s3/aws4_request"
+	hash := sha256.Sum256([]byte(canonical))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(hash[:])
+
+	signature := hex.EncodeToString(hmacSHA256(awsSigningKey(u.cfg.SecretKey, day, u.cfg.Region, "s3"), stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=host;x-amz-content-sha256;x-amz-date, Signature=%s",
+		u.cfg.AccessKey, scope, signature,
+	))
+}
+
+func awsSigningKey(secret, day, region, service string) []byte {
+	key := hmacSHA256([]byte("AWS4"+secret), day)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, service)
+
+	return hmacSHA256(key, "aws4_request")
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+
+	return mac.Sum(nil)
+}
+
+// awsURIEncode кодирует путь по правилам SigV4: без изменений остаются
+// только буквы, цифры, "-", ".", "_", "~" и разделители "/".
+func awsURIEncode(path string) string {
+	var b strings.Builder
+
+	for i := 0; i < len(path); i++ {
+		c := path[i]
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9',
+			c == '-', c == '.', c == '_', c == '~', c == '/':
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+
+	return b.String()
+}
@@ -0,0 +1,195 @@
+package logger
+
+import (
+	"context"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingUploader struct {
+	mu       sync.Mutex
+	archives []Archive
+	bodies   []string
+}
+
+func (u *recordingUploader) Upload(_ context.Context, archive Archive) error {
+	body, err := io.ReadAll(archive.Body)
+	if err != nil {
+		return err
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	u.archives = append(u.archives, archive)
+	u.bodies = append(u.bodies, string(body))
+
+	return nil
+}
+
+func (u *recordingUploader) count() int {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	return len(u.archives)
+}
+
+// TestUploadArchives проверяет загрузку и удаление файла после ротации.
+func TestUploadArchives(t *testing.T) {
+	tmpDir := t.TempDir()
+	uploader := &recordingUploader{}
+
+	rotator, err := NewRotator(Path(tmpDir), FilenamePattern("app-{date}.log"), MaxSize(10),
+		Compress(false), UploadArchives(uploader, true))
+	require.NoError(t, err)
+
+	_, err = rotator.Write([]byte("first line\n"))
+	require.NoError(t, err)
+	_, err = rotator.Write([]byte("second line\n"))
+	require.NoError(t, err)
+
+	assert.Eventually(t, func() bool { return uploader.count() == 1 }, time.Second, 10*time.Millisecond)
+	require.NoError(t, rotator.Close())
+
+	today := time.Now().Format(dateLayout)
+
+	uploader.mu.Lock()
+	defer uploader.mu.Unlock()
+
+	assert.Equal(t, "app-"+today+".log", uploader.archives[0].Name)
+	assert.Equal(t, today, uploader.archives[0].Date.Format(dateLayout))
+	assert.EqualValues(t, 11, uploader.archives[0].Size)
+	assert.Equal(t, "first line\n", uploader.bodies[0])
+
+	assert.Eventually(t, func() bool {
+		return assert.ObjectsAreEqual([]string{"app-" + today + ".1.log"}, dirNames(t, tmpDir))
+	}, time.Second, 10*time.Millisecond)
+}
+
+// blockingUploader ждёт release перед загрузкой или отмены ctx.
+type blockingUploader struct {
+	started chan struct{}
+	release chan struct{}
+	once    sync.Once
+}
+
+func (u *blockingUploader) Upload(ctx context.Context, _ Archive) error {
+	u.once.Do(func() { close(u.started) })
+
+	select {
+	case <-u.release:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// TestUploadArchivesWithoutLock проверяет, что медленная загрузка не
+// блокирует запись, а Close с истёкшим ctx прерывает её.
+func TestUploadArchivesWithoutLock(t *testing.T) {
+	uploader := &blockingUploader{started: make(chan struct{}), release: make(chan struct{})}
+
+	rotator, err := NewRotator(Path(t.TempDir()), MaxSize(10), Compress(false), FileLock(true),
+		UploadArchives(uploader, false))
+	require.NoError(t, err)
+
+	_, err = rotator.Write([]byte("first line\n"))
+	require.NoError(t, err)
+	_, err = rotator.Write([]byte("second line\n"))
+	require.NoError(t, err)
+
+	<-uploader.started
+
+	written := make(chan error, 1)
+	go func() {
+		_, err := rotator.Write([]byte("third\n"))
+		written <- err
+	}()
+
+	select {
+	case err := <-written:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("write blocked by upload")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	assert.Error(t, rotator.rotator.closeContext(ctx))
+}
+
+// TestUploadArchivesRetry проверяет загрузку файлов, оставшихся после
+// неудачной загрузки, при запуске.
+func TestUploadArchivesRetry(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeLogFiles(t, tmpDir, "2024_05_27.log", "2024_05_28.log")
+
+	uploader := &recordingUploader{}
+
+	rotator, err := NewRotator(Path(tmpDir), Compress(false), UploadArchives(uploader, true))
+	require.NoError(t, err)
+	require.NoError(t, rotator.Close())
+
+	require.Equal(t, 1, uploader.count())
+	assert.Equal(t, "2024_05_27.log", uploader.archives[0].Name)
+	assert.Equal(t, []string{"2024_05_28.log"}, dirNames(t, tmpDir))
+}
+
+// TestAWSSigningKey проверяет вывод ключа подписи на примере из
+// документации AWS.
+func TestAWSSigningKey(t *testing.T) {
+	key := awsSigningKey("wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY", "20120215", "us-east-1", "iam")
+	assert.Equal(t, "f4780e2d9f65fa895f9c67b32ce1baf0b0d8a43505a000a1a9e090d414db404d", hex.EncodeToString(key))
+}
+
+// TestS3Uploader проверяет запрос PUT с ключом по шаблону префикса.
+func TestS3Uploader(t *testing.T) {
+	var (
+		path, auth, content string
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.EscapedPath()
+		auth = r.Header.Get("Authorization")
+		body, _ := io.ReadAll(r.Body)
+		content = string(body)
+	}))
+	defer server.Close()
+
+	uploader, err := NewS3Uploader(S3Config{
+		Endpoint:  server.URL,
+		Region:    "us-east-1",
+		Bucket:    "logs",
+		Prefix:    "app/{year}/{month}/{day}/",
+		AccessKey: "AKID",
+		SecretKey: "secret",
+		PathStyle: true,
+	})
+	require.NoError(t, err)
+	uploader.(*s3Uploader).now = func() time.Time { return time.Date(2026, 5, 28, 12, 0, 0, 0, time.UTC) }
+
+	err = uploader.Upload(context.Background(), Archive{
+		Name: "app 2026_05_27.log.zip",
+		Date: time.Date(2026, 5, 27, 0, 0, 0, 0, time.Local),
+		Size: 4,
+		Body: strings.NewReader("data"),
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "/logs/app/2026/05/27/app%202026_05_27.log.zip", path)
+	assert.Equal(t, "data", content)
+	assert.True(t, strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=AKID/20260528/us-east-1/s3/aws4_request, "+
+		"SignedHeaders=host;x-amz-content-sha256;x-amz-date, Signature="), auth)
+
+	_, err = NewS3Uploader(S3Config{Endpoint: "minio:9000"})
+	assert.ErrorContains(t, err, "invalid S3 endpoint")
+}
@@ -0,0 +1,209 @@
+package logger
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// DropPolicy определяет поведение асинхронной записи при заполненной
+// очереди.
+type DropPolicy int
+
+const (
+	// DropNewest отбрасывает новую запись.
+	DropNewest DropPolicy = iota
+	// DropOldest вытесняет самую старую запись очереди.
+	DropOldest
+	// DropNone ждёт освобождения места: записи не теряются, но вызов
+	// логгера блокируется.
+	DropNone
+)
+
+// Async выносит запись в файл из вызова логгера: закодированные записи
+// ставятся в очередь на bufferSize записей и пишутся фоновой горутиной.
+// При заполненной очереди действует dropPolicy; число отброшенных записей
+// возвращает Dropped. Sync и Close дожидаются записи очереди.
+func Async(bufferSize int, dropPolicy DropPolicy) Option {
+	return func(l *Logger) {
+		if bufferSize <= 0 {
+			l.optionErrs = append(l.optionErrs, fmt.Errorf("logger: invalid async buffer size %d", bufferSize))
+			return
+		}
+
+		l.asyncSize = bufferSize
+		l.asyncPolicy = dropPolicy
+	}
+}
+
+// Dropped возвращает число записей, отброшенных асинхронной записью из-за
+// заполненной очереди.
+func (l *Logger) Dropped() uint64 {
+	var dropped uint64
+	for _, w := range l.asyncWriters {
+		dropped += w.dropped.Load()
+	}
+
+	return dropped
+}
+
+// asyncWriter — кольцевая очередь записей перед WriteSyncer с фоновой
+// записью.
+type asyncWriter struct {
+	out    zapcore.WriteSyncer
+	policy DropPolicy
+
+	mu      sync.Mutex
+	cond    *sync.Cond
+	items   [][]byte
+	head    int
+	count   int
+	writing bool
+	closed  bool
+
+	dropped atomic.Uint64
+	done    chan struct{}
+	metrics Metrics
+}
+
+func newAsyncWriter(out zapcore.WriteSyncer, size int, policy DropPolicy) *asyncWriter {
+	w := &asyncWriter{
+		out:    out,
+		policy: policy,
+		items:  make([][]byte, size),
+		done:   make(chan struct{}),
+	}
+	w.cond = sync.NewCond(&w.mu)
+
+	goLabeled("async-write", w.run)
+
+	return w
+}
+
+// Write копирует p в очередь. Ошибки записи в out не возвращаются
+// вызывающему: запись уже произошла асинхронно.
+func (w *asyncWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return w.out.Write(p)
+	}
+
+	for w.count == len(w.items) {
+		switch w.policy {
+		case DropOldest:
+			w.items[w.head] = nil
+			w.head = (w.head + 1) % len(w.items)
+			w.count--
+			w.dropped.Add(1)
+		case DropNone:
+			w.cond.Wait()
+			if w.closed {
+				return w.out.Write(p)
+			}
+			continue
+		default:
+			w.dropped.Add(1)
+			return len(p), nil
+		}
+	}
+
+	w.items[(w.head+w.count)%len(w.items)] = append([]byte(nil), p...)
+	w.count++
+	w.observeDepth()
+	w.cond.Broadcast()
+
+	return len(p), nil
+}
+
+func (w *asyncWriter) run() {
+	defer close(w.done)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for {
+		for w.count == 0 && !w.closed {
+			w.cond.Wait()
+		}
+		if w.count == 0 {
+			return
+		}
+
+		p := w.items[w.head]
+		w.items[w.head] = nil
+		w.head = (w.head + 1) % len(w.items)
+		w.count--
+		w.observeDepth()
+		w.writing = true
+		w.cond.Broadcast()
+
+		w.mu.Unlock()
+		_, _ = w.out.Write(p)
+		w.mu.Lock()
+
+		w.writing = false
+		w.cond.Broadcast()
+	}
+}
+
+// observeDepth сообщает в метрики число записей в очереди. Вызывается
+// под mu.
+func (w *asyncWriter) observeDepth() {
+	if w.metrics != nil {
+		w.metrics.ObserveQueueDepth(w.count)
+	}
+}
+
+// drain ждёт, пока очередь не будет записана.
+func (w *asyncWriter) drain() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for w.count > 0 || w.writing {
+		w.cond.Wait()
+	}
+}
+
+// Sync дожидается записи очереди и сбрасывает out.
+func (w *asyncWriter) Sync() error {
+	w.drain()
+
+	return w.out.Sync()
+}
+
+// Close дописывает очередь и останавливает горутину; последующие записи
+// идут в out синхронно.
+func (w *asyncWriter) Close() error {
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
+		return nil
+	}
+	w.closed = true
+	w.cond.Broadcast()
+	w.mu.Unlock()
+
+	<-w.done
+
+	return w.out.Sync()
+}
+
+// withAsync оборачивает writer асинхронной очередью, если она включена.
+// Очередь закрывается раньше файлов, чтобы её содержимое было дописано.
+func (l *Logger) withAsync(writer zapcore.WriteSyncer) zapcore.WriteSyncer {
+	if l.asyncSize <= 0 {
+		return writer
+	}
+
+	w := newAsyncWriter(writer, l.asyncSize, l.asyncPolicy)
+	w.metrics = l.metrics
+	l.asyncWriters = append(l.asyncWriters, w)
+	l.closers = append([]io.Closer{w}, l.closers...)
+
+	return w
+}
@@ -0,0 +1,123 @@
+package logger
+
+import (
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// blockingSyncer пишет строки после закрытия unblock.
+type blockingSyncer struct {
+	unblock chan struct{}
+	mu      sync.Mutex
+	lines   []string
+}
+
+func (s *blockingSyncer) Write(p []byte) (int, error) {
+	<-s.unblock
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.lines = append(s.lines, string(p))
+
+	return len(p), nil
+}
+
+func (s *blockingSyncer) Sync() error {
+	return nil
+}
+
+func (s *blockingSyncer) written() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]string(nil), s.lines...)
+}
+
+func TestAsyncWriterDropPolicy(t *testing.T) {
+	tests := []struct {
+		policy  DropPolicy
+		written []string
+	}{
+		{DropNewest, []string{"1", "2", "3"}},
+		{DropOldest, []string{"1", "4", "5"}},
+	}
+
+	for _, tt := range tests {
+		out := &blockingSyncer{unblock: make(chan struct{})}
+		w := newAsyncWriter(out, 2, tt.policy)
+
+		// Первая запись забирается горутиной и блокирует её, следующие
+		// заполняют очередь.
+		_, _ = w.Write([]byte("1"))
+		require.Eventually(t, func() bool {
+			w.mu.Lock()
+			defer w.mu.Unlock()
+			return w.writing
+		}, time.Second, time.Millisecond)
+
+		for _, line := range []string{"2", "3", "4", "5"} {
+			n, err := w.Write([]byte(line))
+			require.NoError(t, err)
+			assert.Equal(t, 1, n)
+		}
+
+		assert.EqualValues(t, 2, w.dropped.Load())
+
+		close(out.unblock)
+		require.NoError(t, w.Sync())
+		assert.Equal(t, tt.written, out.written())
+		require.NoError(t, w.Close())
+	}
+}
+
+func TestAsyncWriterBlock(t *testing.T) {
+	out := &blockingSyncer{unblock: make(chan struct{})}
+	w := newAsyncWriter(out, 1, DropNone)
+
+	written := make(chan struct{})
+	go func() {
+		for _, line := range []string{"1", "2", "3"} {
+			_, _ = w.Write([]byte(line))
+		}
+		close(written)
+	}()
+
+	select {
+	case <-written:
+		t.Fatal("write did not block on full queue")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(out.unblock)
+	<-written
+	require.NoError(t, w.Close())
+
+	assert.Equal(t, []string{"1", "2", "3"}, out.written())
+	assert.Zero(t, w.dropped.Load())
+}
+
+func TestAsyncOption(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	logger := NewLogger(Path(tmpDir), Structured(true), Async(128, DropNone))
+	require.NoError(t, logger.Init(false))
+	require.Len(t, logger.asyncWriters, 1)
+
+	for i := 0; i < 100; i++ {
+		logger.Info("message")
+	}
+	require.NoError(t, logger.Close())
+
+	lines := readJSONLines(t, filepath.Join(tmpDir, time.Now().Format(dateLayout)+".log"))
+	assert.Len(t, lines, 100)
+	assert.Zero(t, logger.Dropped())
+
+	_, err := Build(Path(t.TempDir()), Async(0, DropNewest))
+	assert.ErrorContains(t, err, "invalid async buffer size")
+}
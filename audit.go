@@ -0,0 +1,72 @@
+package logger
+
+import (
+	"path/filepath"
+	"sort"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// defaultAuditDir — подкаталог журнала аудита в каталоге логов.
+const defaultAuditDir = "audit"
+
+// AuditPath задаёт каталог журнала аудита. По умолчанию — подкаталог
+// "audit" в каталоге логов.
+func AuditPath(path string) Option {
+	return func(l *Logger) {
+		l.auditPath = path
+	}
+}
+
+// Audit пишет событие аудита в отдельный журнал. Запись не зависит от
+// уровня логгера, сэмплирования и переопределений уровней, а файлы
+// аудита не удаляются по MaxAge, MaxBackups и MaxTotalSize.
+func (l *Logger) Audit(msg string, fields map[string]interface{}) {
+	if l.audit == nil {
+		return
+	}
+
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	zapFields := make([]zap.Field, 0, len(keys))
+	for _, key := range keys {
+		zapFields = append(zapFields, zap.Any(key, fields[key]))
+	}
+
+	if ce := l.audit.Check(zapcore.InfoLevel, msg); ce != nil {
+		ce.Write(zapFields...)
+	}
+}
+
+// newAuditLogger создаёт логгер аудита с собственным ротатором. Без
+// каталога логов (SocketOutput) аудит требует явного AuditPath.
+func (l *Logger) newAuditLogger(encoder zapcore.Encoder) *zap.Logger {
+	path := l.auditPath
+	if path == "" {
+		if l.rotator == nil {
+			return nil
+		}
+		path = filepath.Join(l.path, defaultAuditDir)
+	}
+
+	settings := *l
+	settings.maxAge, settings.maxBackups, settings.maxTotalSize = 0, 0, 0
+
+	rotator := settings.newFileRotatorFor(path, l.filename, "")
+	l.closers = append(l.closers, rotator)
+
+	// Аудит занимает место в квоте MaxTotalSize, но не удаляется ею.
+	if l.rotator != nil {
+		l.rotator.addQuotaPeer(rotator, true)
+	}
+
+	enabled := zap.LevelEnablerFunc(func(zapcore.Level) bool { return true })
+	core := zapcore.NewCore(encoder, zapcore.AddSync(rotator), enabled)
+
+	return zap.New(l.withEntryStats(l.wrapCore(core, true)), l.zapOptions()...)
+}
@@ -0,0 +1,49 @@
+package logger
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAudit(t *testing.T) {
+	tmpDir := t.TempDir()
+	today := time.Now().Format(dateLayout)
+
+	logger := NewLogger(Path(tmpDir), Level("error"), Structured(true), Compress(false))
+	require.NoError(t, logger.Init(false))
+
+	logger.Audit("user deleted", map[string]interface{}{"user": "alice", "by": "admin"})
+	logger.Error("regular error")
+	require.NoError(t, logger.Close())
+
+	lines := readJSONLines(t, filepath.Join(tmpDir, defaultAuditDir, today+".log"))
+	require.Len(t, lines, 1)
+	assert.Equal(t, "user deleted", lines[0]["message"])
+	assert.Equal(t, "info", lines[0]["level"])
+	assert.Equal(t, "alice", lines[0]["user"])
+	assert.Equal(t, "admin", lines[0]["by"])
+
+	lines = readJSONLines(t, filepath.Join(tmpDir, today+".log"))
+	require.Len(t, lines, 1)
+	assert.Equal(t, "regular error", lines[0]["message"])
+}
+
+func TestAuditPath(t *testing.T) {
+	tmpDir := t.TempDir()
+	auditDir := t.TempDir()
+
+	logger := NewLogger(Path(tmpDir), AuditPath(auditDir), Structured(true), Compress(false))
+	require.NoError(t, logger.Init(false))
+
+	logger.Named("billing").Audit("refund", nil)
+	require.NoError(t, logger.Close())
+
+	lines := readJSONLines(t, filepath.Join(auditDir, time.Now().Format(dateLayout)+".log"))
+	require.Len(t, lines, 1)
+	assert.Equal(t, "refund", lines[0]["message"])
+	assert.NoDirExists(t, filepath.Join(tmpDir, defaultAuditDir))
+}
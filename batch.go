@@ -0,0 +1,82 @@
+package logger
+
+import (
+	"bytes"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Batch накапливает записи и пишет их в файл логов одним вызовом Write,
+// поэтому в файле они идут подряд, без записей других горутин. Записи
+// пакета попадают только в файл (или сокет SocketOutput): консоль и
+// Outputs их не получают. Batch не рассчитан на одновременное
+// использование из нескольких горутин.
+type Batch struct {
+	writer      zapcore.WriteSyncer
+	buf         bytes.Buffer
+	sugarLogger *zap.SugaredLogger
+}
+
+// Batch создаёт пустой пакет записей с именем логгера (Named). Если
+// логгер создан без файлового вывода (BaseLogger), записи пишутся сразу,
+// как обычные.
+func (l *Logger) Batch() *Batch {
+	b := &Batch{writer: l.fileWriter}
+
+	if l.fileWriter == nil {
+		b.sugarLogger = l.sugarLogger
+		return b
+	}
+
+	core := zapcore.NewCore(l.fileEncoder.Clone(), zapcore.AddSync(&b.buf), l.fileLevel)
+	core = l.withEntryStats(l.wrapCore(core, true)).With(l.fields)
+
+	b.sugarLogger = zap.New(core, l.zapOptions()...).Named(l.baseLogger.Name()).Sugar()
+
+	return b
+}
+
+// Flush пишет накопленные записи одним вызовом и очищает пакет.
+func (b *Batch) Flush() error {
+	if b.buf.Len() == 0 {
+		return nil
+	}
+
+	_, err := b.writer.Write(b.buf.Bytes())
+	b.buf.Reset()
+
+	return err
+}
+
+func (b *Batch) Debug(args ...interface{}) {
+	b.sugarLogger.Debug(args...)
+}
+
+func (b *Batch) Debugf(template string, args ...interface{}) {
+	b.sugarLogger.Debugf(template, args...)
+}
+
+func (b *Batch) Info(args ...interface{}) {
+	b.sugarLogger.Info(args...)
+}
+
+func (b *Batch) Infof(template string, args ...interface{}) {
+	b.sugarLogger.Infof(template, args...)
+}
+
+func (b *Batch) Warn(args ...interface{}) {
+	b.sugarLogger.Warn(args...)
+}
+
+func (b *Batch) Warnf(template string, args ...interface{}) {
+	b.sugarLogger.Warnf(template, args...)
+}
+
+func (b *Batch) Error(args ...interface{}) {
+	b.sugarLogger.Error(args...)
+}
+
+func (b *Batch) Errorf(template string, args ...interface{}) {
+	b.sugarLogger.Errorf(template, args...)
+}
@@ -0,0 +1,85 @@
+package logger
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestBatch проверяет, что записи пакета идут в файле подряд.
+func TestBatch(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "logger_test")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	logger := NewLogger(Path(tmpDir), Structured(true))
+	require.NoError(t, logger.Init(false))
+
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+				logger.Info("noise")
+			}
+		}
+	}()
+
+	batch := logger.WithFields(map[string]interface{}{"tx": 42}).Batch()
+	for i := 0; i < 100; i++ {
+		batch.Infof("line %d", i)
+	}
+
+	require.NoError(t, batch.Flush())
+
+	close(stop)
+	wg.Wait()
+	require.NoError(t, logger.Close())
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, time.Now().Format(dateLayout)+".log"))
+	require.NoError(t, err)
+
+	lines := strings.Split(string(content), "\n")
+	first := -1
+	for i, line := range lines {
+		if strings.Contains(line, `"line 0"`) {
+			first = i
+			break
+		}
+	}
+	require.NotEqual(t, -1, first)
+
+	for i := 0; i < 100; i++ {
+		assert.Contains(t, lines[first+i], `"tx":42`)
+		assert.NotContains(t, lines[first+i], "noise")
+	}
+}
+
+// TestBatchNamed проверяет имя логгера у записей пакета.
+func TestBatchNamed(t *testing.T) {
+	tmpDir := t.TempDir()
+	logger := NewLogger(Path(tmpDir), Structured(true))
+	require.NoError(t, logger.Init(false))
+
+	batch := logger.Named("billing").Batch()
+	batch.Info("batched")
+	require.NoError(t, batch.Flush())
+	require.NoError(t, logger.Close())
+
+	lines := readJSONLines(t, filepath.Join(tmpDir, time.Now().Format(dateLayout)+".log"))
+	if assert.Len(t, lines, 1) {
+		assert.Equal(t, "billing", lines[0]["logger"])
+	}
+}
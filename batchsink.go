@@ -0,0 +1,230 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	batchFlushInterval = time.Second
+	// batchSize — число записей, при котором отправка не ждёт
+	// интервала.
+	batchSize = 1000
+	// batchMaxPending — предел неотправленных записей; при переполнении
+	// отбрасываются самые старые.
+	batchMaxPending = 10 * batchSize
+	batchRetries    = 5
+	batchRetryDelay = 500 * time.Millisecond
+)
+
+// batchEntry — запись в очереди на отправку.
+type batchEntry struct {
+	ts   time.Time
+	line string
+}
+
+// batchSender отправляет пачку записей, прерываясь по ctx; retry
+// сообщает, что ошибка временная и запрос стоит повторить.
+type batchSender func(ctx context.Context, batch []batchEntry) (retry bool, err error)
+
+// batchSink копит записи и отправляет их пачками фоновой горутиной,
+// повторяя отправку с удвоением паузы при временных ошибках. Пачка,
+// не отправленная после всех повторов, отбрасывается. Sync и Close
+// отправляют остаток одной попыткой, чтобы недоступный сервер не
+// задерживал остановку логгера.
+type batchSink struct {
+	name     string
+	send     batchSender
+	size     int
+	interval time.Duration
+	retries  int
+	delay    time.Duration
+	diag     *zap.Logger
+
+	mu      sync.Mutex
+	pending []batchEntry
+	dropped int
+
+	pushMu sync.Mutex
+	wake   chan struct{}
+	done   chan struct{}
+	closed chan struct{}
+	once   sync.Once
+}
+
+func newBatchSink(name string, size int, interval time.Duration, send batchSender) *batchSink {
+	if size <= 0 {
+		size = batchSize
+	}
+	if interval <= 0 {
+		interval = batchFlushInterval
+	}
+
+	s := &batchSink{
+		name:     name,
+		send:     send,
+		size:     size,
+		interval: interval,
+		retries:  batchRetries,
+		delay:    batchRetryDelay,
+		wake:     make(chan struct{}, 1),
+		done:     make(chan struct{}),
+		closed:   make(chan struct{}),
+	}
+
+	goLabeled(name, s.loop)
+
+	return s
+}
+
+func (s *batchSink) setDiagnostics(diag *zap.Logger) {
+	s.diag = diag
+}
+
+func (s *batchSink) Write(p []byte) (int, error) {
+	line := strings.TrimSuffix(string(p), "\n")
+	maxPending := max(batchMaxPending, 10*s.size)
+
+	s.mu.Lock()
+	s.pending = append(s.pending, batchEntry{ts: time.Now(), line: line})
+	if len(s.pending) > maxPending {
+		s.dropped += len(s.pending) - maxPending
+		s.pending = s.pending[len(s.pending)-maxPending:]
+	}
+	full := len(s.pending) >= s.size
+	s.mu.Unlock()
+
+	if full {
+		select {
+		case s.wake <- struct{}{}:
+		default:
+		}
+	}
+
+	return len(p), nil
+}
+
+func (s *batchSink) loop() {
+	defer close(s.closed)
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-s.wake:
+		case <-s.done:
+			return
+		}
+
+		_ = s.push(context.Background(), true)
+	}
+}
+
+// push отправляет накопленные записи пачками по size; retry разрешает
+// повторы при временных ошибках.
+func (s *batchSink) push(ctx context.Context, retry bool) error {
+	s.pushMu.Lock()
+	defer s.pushMu.Unlock()
+
+	for {
+		s.mu.Lock()
+		n := min(len(s.pending), s.size)
+		batch := s.pending[:n:n]
+		s.pending = s.pending[n:]
+		dropped := s.dropped
+		s.dropped = 0
+		s.mu.Unlock()
+
+		if dropped > 0 {
+			diagLogger(s.diag).Warn("sink queue overflow", zap.String("sink", s.name), zap.Int("dropped", dropped))
+		}
+
+		if len(batch) == 0 {
+			return nil
+		}
+
+		if err := s.sendRetry(ctx, batch, retry); err != nil {
+			diagLogger(s.diag).Warn("sink push failed", zap.String("sink", s.name), zap.Int("entries", len(batch)), zap.Error(err))
+			return err
+		}
+	}
+}
+
+func (s *batchSink) sendRetry(ctx context.Context, batch []batchEntry, allowRetry bool) error {
+	delay := s.delay
+
+	for attempt := 0; ; attempt++ {
+		retry, err := s.send(ctx, batch)
+		if err == nil || !retry || !allowRetry || attempt >= s.retries {
+			return err
+		}
+
+		select {
+		case <-time.After(delay):
+		case <-s.done:
+			return err
+		case <-ctx.Done():
+			return err
+		}
+
+		delay *= 2
+	}
+}
+
+func (s *batchSink) Sync() error {
+	return s.push(context.Background(), false)
+}
+
+func (s *batchSink) Close() error {
+	return s.closeContext(context.Background())
+}
+
+// closeContext останавливает фоновую отправку и отправляет остаток, ожидая
+// не дольше ctx.
+func (s *batchSink) closeContext(ctx context.Context) error {
+	s.once.Do(func() {
+		close(s.done)
+	})
+
+	select {
+	case <-s.closed:
+	case <-ctx.Done():
+		return fmt.Errorf("%s: %w", s.name, ctx.Err())
+	}
+
+	return s.push(ctx, false)
+}
+
+// postBatch выполняет POST-запрос и сообщает, стоит ли его повторить:
+// повторяются ошибки сети и ответы 429 и 5xx. Тело успешного ответа
+// возвращается вызывающему.
+func postBatch(client *http.Client, req *http.Request) ([]byte, bool, error) {
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, true, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, true, err
+	}
+
+	if resp.StatusCode/100 == 2 {
+		return body, false, nil
+	}
+
+	retry := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+
+	return nil, retry, fmt.Errorf("%s: %s", resp.Status, bytes.TrimSpace(body))
+}
@@ -0,0 +1,109 @@
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+)
+
+// Build создаёт логгер как NewLogger, но не подменяет неверные значения
+// опций, а проверяет их и возвращает все найденные ошибки вместе.
+// Доступность каталога логов проверяет Init.
+func Build(options ...Option) (*Logger, error) {
+	l := NewLogger(options...)
+
+	if err := l.validate(); err != nil {
+		return nil, err
+	}
+
+	return l, nil
+}
+
+// validate проверяет значения и сочетания опций.
+func (l *Logger) validate() error {
+	errs := append([]error(nil), l.optionErrs...)
+
+	if l.path == "" && l.socketPath == "" && len(l.outputs) == 0 {
+		errs = append(errs, errors.New("logger: path must not be empty when the file is the only output"))
+	}
+
+	if l.structured && l.format != "" && l.format != "json" {
+		errs = append(errs, fmt.Errorf("logger: Structured conflicts with format %q", l.format))
+	}
+
+	if _, err := lookupEncoder(l.fileFormat()); err != nil {
+		errs = append(errs, err)
+	}
+
+	for _, output := range l.outputs {
+		if err := validateSinkURL(output); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	for _, fallback := range l.fallbacks {
+		if err := validateSinkURL(fallback); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if pattern, err := newFilenamePattern(l.filename, l.appName); err != nil {
+		errs = append(errs, err)
+	} else if err := pattern.validateInterval(l.interval); err != nil {
+		errs = append(errs, err)
+	}
+
+	if _, err := parseRotateAt(l.rotateAt); err != nil {
+		errs = append(errs, err)
+	}
+
+	if err := validateInterval(l.interval); err != nil {
+		errs = append(errs, err)
+	}
+
+	if l.maxSize < 0 {
+		errs = append(errs, fmt.Errorf("logger: invalid max size %d", l.maxSize))
+	}
+
+	if l.maxSegments < 0 {
+		errs = append(errs, fmt.Errorf("logger: invalid max segments per day %d", l.maxSegments))
+	}
+
+	if l.maxSegments > 0 && l.maxSize == 0 {
+		errs = append(errs, errors.New("logger: MaxSegmentsPerDay requires MaxSize"))
+	}
+
+	if l.fileMode&^fs.ModePerm != 0 || l.dirMode&^fs.ModePerm != 0 {
+		errs = append(errs, fmt.Errorf("logger: invalid permissions: file %s, directory %s", l.fileMode, l.dirMode))
+	}
+
+	if l.parallelQueue < 0 {
+		errs = append(errs, fmt.Errorf("logger: invalid parallel outputs queue size %d", l.parallelQueue))
+	}
+
+	if l.maxAge < 0 || l.maxBackups < 0 {
+		errs = append(errs, fmt.Errorf("logger: invalid retention: max age %s, max backups %d", l.maxAge, l.maxBackups))
+	}
+
+	if l.maxTotalSize < 0 {
+		errs = append(errs, fmt.Errorf("logger: invalid max total size %d", l.maxTotalSize))
+	}
+
+	if l.retry.attempts < 1 || l.retry.delay < 0 {
+		errs = append(errs, fmt.Errorf("logger: invalid retry policy: %d attempts, delay %s", l.retry.attempts, l.retry.delay))
+	}
+
+	for level, rate := range l.sampling {
+		if rate.first < 0 || rate.thereafter < 0 {
+			errs = append(errs, fmt.Errorf("logger: invalid sampling rate for level %s", level))
+		}
+	}
+
+	if l.syslogFields {
+		if err := l.syslogFacility.validate(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
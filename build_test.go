@@ -0,0 +1,56 @@
+package logger
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestBuild проверяет создание логгера с корректными опциями.
+func TestBuild(t *testing.T) {
+	logger, err := Build(Path(t.TempDir()), Level("debug"), Structured(true), MaxSize(1024), MaxSegmentsPerDay(3, SegmentLimitAppend))
+	require.NoError(t, err)
+	assert.Equal(t, "debug", logger.level)
+}
+
+// TestBuildErrors проверяет, что все ошибки опций возвращаются вместе.
+func TestBuildErrors(t *testing.T) {
+	logger, err := Build(
+		Level("verbose"),
+		Structured(true),
+		Format("console"),
+		RotateAt("25:00"),
+		MaxSegmentsPerDay(3, SegmentLimitAppend),
+		SharingViolationRetry(0, time.Second),
+		ConsoleBuffer(0, time.Second),
+		Sampling("trace", 1, 1),
+	)
+	require.Error(t, err)
+	assert.Nil(t, logger)
+
+	for _, want := range []string{
+		`unknown level "verbose"`,
+		"path must not be empty",
+		`Structured conflicts with format "console"`,
+		"25:00",
+		"MaxSegmentsPerDay requires MaxSize",
+		"invalid retry policy",
+		"invalid console buffer",
+		`unknown sampling level "trace"`,
+	} {
+		assert.Contains(t, err.Error(), want)
+	}
+}
+
+// TestConsoleBufferDefaultInterval проверяет период сброса по умолчанию и
+// ошибку неверного размера при Init.
+func TestConsoleBufferDefaultInterval(t *testing.T) {
+	logger := NewLogger(ConsoleBuffer(1024, 0))
+	assert.Equal(t, 1024, logger.consoleBufferSize)
+	assert.Equal(t, time.Second, logger.consoleFlushInterval)
+
+	err := NewLogger(Path(t.TempDir()), ConsoleBuffer(0, time.Second)).Init(true)
+	assert.ErrorContains(t, err, "invalid console buffer")
+}
@@ -0,0 +1,82 @@
+package logger
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// CallerSkip пропускает ещё n кадров стека при определении caller, чтобы
+// обёртки над логгером указывали на место своего вызова.
+func CallerSkip(n int) Option {
+	return func(l *Logger) {
+		if n < 0 {
+			l.optionErrs = append(l.optionErrs, fmt.Errorf("logger: invalid caller skip %d", n))
+			return
+		}
+
+		l.callerSkip = n
+	}
+}
+
+// DisableCaller отключает запись caller: записи не содержат файла и строки
+// вызова, а логгер не тратит время на их определение.
+func DisableCaller() Option {
+	return func(l *Logger) {
+		l.noCaller = true
+	}
+}
+
+// TrimCallerPath записывает caller как путь файла без первого совпавшего
+// префикса, например TrimCallerPath("/home/ci/src/") даёт
+// "internal/db/query.go:42" вместо последних двух элементов пути.
+// Файлы вне префиксов записываются как обычно.
+func TrimCallerPath(prefixes ...string) Option {
+	return func(l *Logger) {
+		l.callerPrefixes = append(l.callerPrefixes, prefixes...)
+	}
+}
+
+// callerOptions возвращает опции zap для записи caller.
+func (l *Logger) callerOptions() []zap.Option {
+	if l.noCaller {
+		return nil
+	}
+
+	return []zap.Option{zap.AddCaller(), zap.AddCallerSkip(1 + l.callerSkip)}
+}
+
+// trimCallerEncoder записывает путь файла без префикса из prefixes.
+func trimCallerEncoder(prefixes []string) zapcore.CallerEncoder {
+	return func(caller zapcore.EntryCaller, enc zapcore.PrimitiveArrayEncoder) {
+		if !caller.Defined {
+			enc.AppendString("undefined")
+			return
+		}
+
+		for _, prefix := range prefixes {
+			if path, ok := strings.CutPrefix(caller.File, prefix); ok {
+				enc.AppendString(strings.TrimLeft(path, "/") + ":" + strconv.Itoa(caller.Line))
+				return
+			}
+		}
+
+		enc.AppendString(caller.TrimmedPath())
+	}
+}
+
+// WithCallerSkip возвращает копию логгера, пропускающую ещё skip кадров
+// стека при определении caller. Нужна адаптерам, вызывающим логгер из
+// своих методов.
+func (l *Logger) WithCallerSkip(skip int) *Logger {
+	base := l.baseLogger.WithOptions(zap.AddCallerSkip(skip))
+
+	child := *l
+	child.baseLogger = base
+	child.sugarLogger = base.Sugar()
+
+	return &child
+}
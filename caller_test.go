@@ -0,0 +1,80 @@
+package logger
+
+import (
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// logThroughWrapper имитирует обёртку над логгером.
+func logThroughWrapper(l *Logger, msg string) {
+	l.Info(msg)
+}
+
+// TestCallerOptions проверяет пропуск кадров, отключение и обрезку caller.
+func TestCallerOptions(t *testing.T) {
+	_, file, _, _ := runtime.Caller(0)
+
+	tests := []struct {
+		name    string
+		options []Option
+		log     func(l *Logger) int
+		want    string
+	}{
+		{
+			name:    "skip",
+			options: []Option{CallerSkip(1)},
+			log: func(l *Logger) int {
+				_, _, line, _ := runtime.Caller(0)
+				logThroughWrapper(l, "wrapped")
+				return line + 1
+			},
+			want: "module/caller_test.go",
+		},
+		{
+			name:    "trim",
+			options: []Option{TrimCallerPath("/nonexistent/", filepath.Dir(file))},
+			log: func(l *Logger) int {
+				_, _, line, _ := runtime.Caller(0)
+				l.Info("trimmed")
+				return line + 1
+			},
+			want: "caller_test.go",
+		},
+		{
+			name:    "disabled",
+			options: []Option{DisableCaller()},
+			log: func(l *Logger) int {
+				l.Info("no caller")
+				return 0
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			logger := NewLogger(append([]Option{Path(tmpDir), Structured(true)}, tt.options...)...)
+			require.NoError(t, logger.Init(false))
+
+			line := tt.log(logger)
+			require.NoError(t, logger.Close())
+
+			lines := readJSONLines(t, filepath.Join(tmpDir, time.Now().Format(dateLayout)+".log"))
+			require.Len(t, lines, 1)
+
+			if tt.want == "" {
+				assert.NotContains(t, lines[0], "caller")
+				return
+			}
+			assert.Equal(t, tt.want+":"+strconv.Itoa(line), lines[0]["caller"])
+		})
+	}
+
+	assert.Error(t, NewLogger(Path(t.TempDir()), CallerSkip(-1)).Init(false))
+}
@@ -0,0 +1,234 @@
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/zapcore"
+)
+
+// leefTimeLayout — формат devTime в LEEF, объявляемый в devTimeFormat.
+const leefTimeLayout = "Jan 02 2006 15:04:05.000"
+
+var siemPool = buffer.NewPool()
+
+// siemConfig — заголовок и шкала важности форматов CEF и LEEF.
+type siemConfig struct {
+	vendor   string
+	product  string
+	version  string
+	severity map[zapcore.Level]int
+}
+
+// defaultSIEMSeverity сопоставляет уровни zap шкале важности CEF и LEEF
+// от 0 до 10.
+var defaultSIEMSeverity = map[zapcore.Level]int{
+	zapcore.DebugLevel:  1,
+	zapcore.InfoLevel:   3,
+	zapcore.WarnLevel:   6,
+	zapcore.ErrorLevel:  8,
+	zapcore.DPanicLevel: 9,
+	zapcore.PanicLevel:  10,
+	zapcore.FatalLevel:  10,
+}
+
+func defaultSIEMConfig() *siemConfig {
+	return &siemConfig{vendor: "restfront", product: "logger", version: "1.0", severity: defaultSIEMSeverity}
+}
+
+// SIEMHeader задаёт поля Device Vendor, Device Product и Device Version
+// заголовка форматов "cef" и "leef". По умолчанию "restfront", "logger",
+// "1.0".
+func SIEMHeader(vendor, product, version string) Option {
+	return func(l *Logger) {
+		if vendor == "" || product == "" {
+			l.optionErrs = append(l.optionErrs, fmt.Errorf("logger: SIEM vendor and product must not be empty"))
+			return
+		}
+
+		cfg := l.siemConfig()
+		cfg.vendor, cfg.product, cfg.version = vendor, product, version
+		l.siem = cfg
+	}
+}
+
+// SIEMSeverity переопределяет важность (0–10) уровней в форматах "cef" и
+// "leef". Уровни, которых нет в levels, сохраняют значения по умолчанию:
+// debug — 1, info — 3, warn — 6, error — 8, dpanic — 9, panic и fatal — 10.
+func SIEMSeverity(levels map[zapcore.Level]int) Option {
+	return func(l *Logger) {
+		cfg := l.siemConfig()
+
+		severity := make(map[zapcore.Level]int, len(cfg.severity))
+		for level, value := range cfg.severity {
+			severity[level] = value
+		}
+
+		for level, value := range levels {
+			if value < 0 || value > 10 {
+				l.optionErrs = append(l.optionErrs, fmt.Errorf("logger: SIEM severity %d for level %s is out of range 0-10", value, level))
+				return
+			}
+			severity[level] = value
+		}
+
+		cfg.severity = severity
+		l.siem = cfg
+	}
+}
+
+// siemConfig возвращает копию настроек CEF и LEEF логгера.
+func (l *Logger) siemConfig() *siemConfig {
+	cfg := defaultSIEMConfig()
+	if l.siem != nil {
+		*cfg = *l.siem
+	}
+
+	return cfg
+}
+
+// siemEncoder кодирует записи в CEF или LEEF. Имя события — сообщение
+// записи, идентификатор события — поле "event_id", а без него — имя
+// уровня. Поля записи становятся расширениями key=value.
+type siemEncoder struct {
+	*zapcore.MapObjectEncoder
+	cfg  *siemConfig
+	leef bool
+}
+
+func newCEFEncoder(zapcore.EncoderConfig) zapcore.Encoder {
+	return &siemEncoder{MapObjectEncoder: zapcore.NewMapObjectEncoder(), cfg: defaultSIEMConfig()}
+}
+
+func newLEEFEncoder(zapcore.EncoderConfig) zapcore.Encoder {
+	return &siemEncoder{MapObjectEncoder: zapcore.NewMapObjectEncoder(), cfg: defaultSIEMConfig(), leef: true}
+}
+
+func (e *siemEncoder) Clone() zapcore.Encoder {
+	clone := zapcore.NewMapObjectEncoder()
+	for k, v := range e.Fields {
+		clone.Fields[k] = v
+	}
+
+	return &siemEncoder{MapObjectEncoder: clone, cfg: e.cfg, leef: e.leef}
+}
+
+func (e *siemEncoder) EncodeEntry(entry zapcore.Entry, fields []zapcore.Field) (*buffer.Buffer, error) {
+	enc := e.Clone().(*siemEncoder)
+	for _, field := range fields {
+		field.AddTo(enc)
+	}
+
+	eventID := entry.Level.String()
+	if id, ok := enc.Fields["event_id"]; ok {
+		eventID = fmt.Sprint(id)
+		delete(enc.Fields, "event_id")
+	}
+
+	severity := e.cfg.severity[entry.Level]
+
+	buf := siemPool.Get()
+
+	if e.leef {
+		e.appendLEEF(buf, entry, enc.Fields, eventID, severity)
+	} else {
+		e.appendCEF(buf, entry, enc.Fields, eventID, severity)
+	}
+
+	buf.AppendByte('\n')
+
+	return buf, nil
+}
+
+// appendCEF записывает CEF:0|vendor|product|version|id|name|severity|ext.
+func (e *siemEncoder) appendCEF(buf *buffer.Buffer, entry zapcore.Entry, fields map[string]interface{}, eventID string, severity int) {
+	buf.AppendString("CEF:0")
+	for _, value := range []string{e.cfg.vendor, e.cfg.product, e.cfg.version, eventID, entry.Message} {
+		buf.AppendByte('|')
+		buf.AppendString(cefHeaderEscaper.Replace(value))
+	}
+	buf.AppendByte('|')
+	buf.AppendInt(int64(severity))
+	buf.AppendByte('|')
+
+	ext := []string{"rt=" + strconv.FormatInt(entry.Time.UnixMilli(), 10)}
+	if entry.LoggerName != "" {
+		ext = append(ext, "cat="+cefValueEscaper.Replace(entry.LoggerName))
+	}
+	for _, key := range sortedKeys(fields) {
+		ext = append(ext, siemKey(key)+"="+cefValueEscaper.Replace(siemValue(fields[key])))
+	}
+
+	buf.AppendString(strings.Join(ext, " "))
+}
+
+// appendLEEF записывает LEEF:1.0|vendor|product|version|id| и атрибуты,
+// разделённые табуляцией.
+func (e *siemEncoder) appendLEEF(buf *buffer.Buffer, entry zapcore.Entry, fields map[string]interface{}, eventID string, severity int) {
+	buf.AppendString("LEEF:1.0")
+	for _, value := range []string{e.cfg.vendor, e.cfg.product, e.cfg.version, eventID} {
+		buf.AppendByte('|')
+		buf.AppendString(cefHeaderEscaper.Replace(value))
+	}
+	buf.AppendByte('|')
+
+	attrs := []string{
+		"devTime=" + entry.Time.Format(leefTimeLayout),
+		"devTimeFormat=MMM dd yyyy HH:mm:ss.SSS",
+		"sev=" + strconv.Itoa(severity),
+		"msg=" + leefValueEscaper.Replace(entry.Message),
+	}
+	if entry.LoggerName != "" {
+		attrs = append(attrs, "cat="+leefValueEscaper.Replace(entry.LoggerName))
+	}
+	for _, key := range sortedKeys(fields) {
+		attrs = append(attrs, siemKey(key)+"="+leefValueEscaper.Replace(siemValue(fields[key])))
+	}
+
+	buf.AppendString(strings.Join(attrs, "\t"))
+}
+
+var (
+	cefHeaderEscaper = strings.NewReplacer(`\`, `\\`, `|`, `\|`, "\n", " ", "\r", " ")
+	cefValueEscaper  = strings.NewReplacer(`\`, `\\`, `=`, `\=`, "\n", `\n`, "\r", `\r`)
+	leefValueEscaper = strings.NewReplacer("\t", " ", "\n", " ", "\r", " ")
+)
+
+func sortedKeys(fields map[string]interface{}) []string {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// siemKey заменяет символы, недопустимые в ключах расширений, на "_".
+func siemKey(key string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '_' || r == '.' || r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' {
+			return r
+		}
+		return '_'
+	}, key)
+}
+
+func siemValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case map[string]interface{}, []interface{}:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(data)
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,80 @@
+package logger
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// TestCEFEncoder проверяет заголовок и расширения CEF.
+func TestCEFEncoder(t *testing.T) {
+	enc := newCEFEncoder(zapcore.EncoderConfig{})
+	zap.String("src", "10.0.0.1").AddTo(enc)
+
+	entry := zapcore.Entry{
+		Level:      zapcore.WarnLevel,
+		Time:       time.UnixMilli(1700000000123),
+		LoggerName: "auth",
+		Message:    "login failed | retry",
+	}
+
+	buf, err := enc.EncodeEntry(entry, []zapcore.Field{
+		zap.String("event_id", "AUTH-401"),
+		zap.String("query", `a=b\c`),
+		zap.Int("attempts", 3),
+		zap.String("user name", "bob"),
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		`CEF:0|restfront|logger|1.0|AUTH-401|login failed \| retry|6|`+
+			`rt=1700000000123 cat=auth attempts=3 query=a\=b\\c src=10.0.0.1 user_name=bob`+"\n",
+		buf.String())
+}
+
+// TestLEEFEncoder проверяет заголовок и атрибуты LEEF.
+func TestLEEFEncoder(t *testing.T) {
+	enc := newLEEFEncoder(zapcore.EncoderConfig{})
+
+	ts := time.Date(2024, 3, 1, 12, 30, 0, 5e8, time.Local)
+	buf, err := enc.EncodeEntry(zapcore.Entry{Level: zapcore.ErrorLevel, Time: ts, Message: "disk\tfull"},
+		[]zapcore.Field{zap.Int("free", 0)})
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		"LEEF:1.0|restfront|logger|1.0|error|devTime=Mar 01 2024 12:30:00.500\t"+
+			"devTimeFormat=MMM dd yyyy HH:mm:ss.SSS\tsev=8\tmsg=disk full\tfree=0\n",
+		buf.String())
+}
+
+// TestSIEMOptions проверяет заголовок и шкалу важности из опций.
+func TestSIEMOptions(t *testing.T) {
+	tmpDir := t.TempDir()
+	logger := NewLogger(Path(tmpDir), Format("cef"),
+		SIEMHeader("Acme", "Billing", "2.3"),
+		SIEMSeverity(map[zapcore.Level]int{zapcore.InfoLevel: 2}))
+	require.NoError(t, logger.Init(false))
+
+	logger.Info("payment accepted")
+	require.NoError(t, logger.Close())
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, time.Now().Format(dateLayout)+".log"))
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(content), "CEF:0|Acme|Billing|2.3|info|payment accepted|2|rt="), string(content))
+}
+
+// TestSIEMOptionsValidation проверяет отклонение неверных настроек.
+func TestSIEMOptionsValidation(t *testing.T) {
+	err := NewLogger(Path(t.TempDir()), Format("leef"), SIEMHeader("", "app", "1")).Init(false)
+	assert.ErrorContains(t, err, "vendor and product")
+
+	err = NewLogger(Path(t.TempDir()), SIEMSeverity(map[zapcore.Level]int{zapcore.ErrorLevel: 11})).Init(false)
+	assert.ErrorContains(t, err, "out of range")
+}
@@ -0,0 +1,161 @@
+package logger
+
+import (
+	"sync/atomic"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap/zapcore"
+)
+
+// loggerStats — счётчики работы логгера для Collector. Методы допускают
+// nil-получателя, чтобы ротаторы вне логгера обходились без счётчиков.
+type loggerStats struct {
+	entries             [zapcore.FatalLevel - zapcore.DebugLevel + 1]atomic.Uint64
+	bytesWritten        atomic.Uint64
+	rotations           atomic.Uint64
+	compressionFailures atomic.Uint64
+	writeErrors         atomic.Uint64
+}
+
+func (s *loggerStats) entry(entry zapcore.Entry) error {
+	if s != nil && entry.Level >= zapcore.DebugLevel && entry.Level <= zapcore.FatalLevel {
+		s.entries[entry.Level-zapcore.DebugLevel].Add(1)
+	}
+
+	return nil
+}
+
+func (s *loggerStats) rotated() {
+	if s != nil {
+		s.rotations.Add(1)
+	}
+}
+
+func (s *loggerStats) compressFailed() {
+	if s != nil {
+		s.compressionFailures.Add(1)
+	}
+}
+
+// withEntryStats считает записи, которые выбрало ядро core.
+func (l *Logger) withEntryStats(core zapcore.Core) zapcore.Core {
+	if l.stats == nil {
+		return core
+	}
+
+	return &statsCore{Core: core, stats: l.stats}
+}
+
+// statsCore считает записи по уровням. Запись считается, если её выбрало
+// вложенное ядро. В отличие от zap.Hooks, Write передаёт запись дальше:
+// так через ядро проходят записи, выбранные выше, например из буфера
+// WithDebugRing.
+type statsCore struct {
+	zapcore.Core
+	stats *loggerStats
+}
+
+func (c *statsCore) With(fields []zapcore.Field) zapcore.Core {
+	return &statsCore{Core: c.Core.With(fields), stats: c.stats}
+}
+
+func (c *statsCore) Check(entry zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if downstream := c.Core.Check(entry, ce); downstream != nil {
+		return downstream.AddCore(entry, statsHook{c})
+	}
+
+	return ce
+}
+
+func (c *statsCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	_ = c.stats.entry(entry)
+
+	return c.Core.Write(entry, fields)
+}
+
+// statsHook считает запись, которую пишут ядра, выбранные в Check.
+type statsHook struct {
+	*statsCore
+}
+
+func (h statsHook) Write(entry zapcore.Entry, _ []zapcore.Field) error {
+	return h.stats.entry(entry)
+}
+
+// withStats считает байты и ошибки записи в файл.
+func (l *Logger) withStats(writer zapcore.WriteSyncer) zapcore.WriteSyncer {
+	if l.stats == nil {
+		return writer
+	}
+
+	return &statsWriter{WriteSyncer: writer, stats: l.stats}
+}
+
+type statsWriter struct {
+	zapcore.WriteSyncer
+	stats *loggerStats
+}
+
+func (w *statsWriter) Write(p []byte) (int, error) {
+	n, err := w.WriteSyncer.Write(p)
+
+	w.stats.bytesWritten.Add(uint64(n))
+	if err != nil {
+		w.stats.writeErrors.Add(1)
+	}
+
+	return n, err
+}
+
+var (
+	entriesDesc = prometheus.NewDesc("logger_entries_total",
+		"Number of log entries written, by level.", []string{"level"}, nil)
+	bytesWrittenDesc = prometheus.NewDesc("logger_written_bytes_total",
+		"Number of bytes written to log files.", nil, nil)
+	rotationsDesc = prometheus.NewDesc("logger_rotations_total",
+		"Number of log file rotations.", nil, nil)
+	compressionFailuresDesc = prometheus.NewDesc("logger_compression_failures_total",
+		"Number of failed compressions of rotated files.", nil, nil)
+	droppedDesc = prometheus.NewDesc("logger_dropped_entries_total",
+		"Number of entries dropped by the asynchronous write queue.", nil, nil)
+	writeErrorsDesc = prometheus.NewDesc("logger_write_errors_total",
+		"Number of failed writes to log files.", nil, nil)
+)
+
+// Collector возвращает сборщик метрик Prometheus о работе логгера: число
+// записей по уровням, записанные байты, ротации, ошибки сжатия, записи,
+// отброшенные Async, и ошибки записи в файл. Сборщик регистрируется
+// вызывающим:
+//
+//	prometheus.MustRegister(logger.Collector())
+func (l *Logger) Collector() prometheus.Collector {
+	return &loggerCollector{logger: l}
+}
+
+type loggerCollector struct {
+	logger *Logger
+}
+
+func (c *loggerCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- entriesDesc
+	ch <- bytesWrittenDesc
+	ch <- rotationsDesc
+	ch <- compressionFailuresDesc
+	ch <- droppedDesc
+	ch <- writeErrorsDesc
+}
+
+func (c *loggerCollector) Collect(ch chan<- prometheus.Metric) {
+	stats := c.logger.stats
+
+	for i := range stats.entries {
+		level := zapcore.DebugLevel + zapcore.Level(i)
+		ch <- prometheus.MustNewConstMetric(entriesDesc, prometheus.CounterValue, float64(stats.entries[i].Load()), level.String())
+	}
+
+	ch <- prometheus.MustNewConstMetric(bytesWrittenDesc, prometheus.CounterValue, float64(stats.bytesWritten.Load()))
+	ch <- prometheus.MustNewConstMetric(rotationsDesc, prometheus.CounterValue, float64(stats.rotations.Load()))
+	ch <- prometheus.MustNewConstMetric(compressionFailuresDesc, prometheus.CounterValue, float64(stats.compressionFailures.Load()))
+	ch <- prometheus.MustNewConstMetric(droppedDesc, prometheus.CounterValue, float64(c.logger.Dropped()))
+	ch <- prometheus.MustNewConstMetric(writeErrorsDesc, prometheus.CounterValue, float64(stats.writeErrors.Load()))
+}
@@ -0,0 +1,47 @@
+package logger
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollector(t *testing.T) {
+	logger := NewLogger(Path(t.TempDir()), Compress(false))
+	require.NoError(t, logger.Init(false))
+
+	logger.Info("first")
+	logger.Error("second")
+	logger.Debug("filtered")
+	require.NoError(t, logger.Rotate())
+	require.NoError(t, logger.Close())
+
+	registry := prometheus.NewRegistry()
+	require.NoError(t, registry.Register(logger.Collector()))
+
+	expected := `
+# HELP logger_entries_total Number of log entries written, by level.
+# TYPE logger_entries_total counter
+logger_entries_total{level="debug"} 0
+logger_entries_total{level="dpanic"} 0
+logger_entries_total{level="error"} 1
+logger_entries_total{level="fatal"} 0
+logger_entries_total{level="info"} 1
+logger_entries_total{level="panic"} 0
+logger_entries_total{level="warn"} 0
+# HELP logger_rotations_total Number of log file rotations.
+# TYPE logger_rotations_total counter
+logger_rotations_total 1
+# HELP logger_write_errors_total Number of failed writes to log files.
+# TYPE logger_write_errors_total counter
+logger_write_errors_total 0
+`
+	assert.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(expected),
+		"logger_entries_total", "logger_rotations_total", "logger_write_errors_total"))
+
+	assert.NotZero(t, logger.stats.bytesWritten.Load())
+}
@@ -0,0 +1,129 @@
+package logger
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+)
+
+const (
+	archiveExt = ".zip"
+	tempExt    = ".tmp"
+)
+
+// compressFile сжимает src во временный архив, проверяет его и только после
+// этого переименовывает архив и удаляет исходный файл. Прерванное на любом
+// шаге сжатие оставляет исходный файл нетронутым.
+func compressFile(fsys FS, src string, perm fs.FileMode, retry retryPolicy) error {
+	dst := src + archiveExt
+	tmp := dst + tempExt
+
+	size, err := writeArchive(fsys, src, tmp, perm)
+	if err != nil {
+		_ = fsys.Remove(tmp)
+		return err
+	}
+
+	if err := verifyArchive(fsys, tmp, size); err != nil {
+		_ = fsys.Remove(tmp)
+		return err
+	}
+
+	if err := retry.do(func() error { return fsys.Rename(tmp, dst) }); err != nil {
+		_ = fsys.Remove(tmp)
+		return err
+	}
+
+	return retry.do(func() error { return fsys.Remove(src) })
+}
+
+func writeArchive(fsys FS, src, dst string, perm fs.FileMode) (int64, error) {
+	file, err := fsys.OpenFile(src, os.O_RDONLY, 0)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return 0, err
+	}
+
+	zipFile, err := fsys.OpenFile(dst, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, perm)
+	if err != nil {
+		return 0, err
+	}
+	defer zipFile.Close()
+
+	header, err := zip.FileInfoHeader(info)
+	if err != nil {
+		return 0, err
+	}
+
+	header.Method = zip.Deflate
+
+	zipWriter := zip.NewWriter(zipFile)
+
+	writer, err := zipWriter.CreateHeader(header)
+	if err != nil {
+		return 0, err
+	}
+
+	size, err := io.Copy(writer, file)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := zipWriter.Close(); err != nil {
+		return 0, err
+	}
+
+	if err := zipFile.Sync(); err != nil {
+		return 0, err
+	}
+
+	return size, zipFile.Close()
+}
+
+// verifyArchive читает архив целиком, чтобы zip.Reader проверил контрольную
+// сумму, и сверяет размер распакованных данных с исходным файлом.
+func verifyArchive(fsys FS, path string, size int64) error {
+	archive, err := fsys.OpenFile(path, os.O_RDONLY, 0)
+	if err != nil {
+		return err
+	}
+	defer archive.Close()
+
+	info, err := archive.Stat()
+	if err != nil {
+		return err
+	}
+
+	reader, err := zip.NewReader(archive, info.Size())
+	if err != nil {
+		return err
+	}
+
+	if len(reader.File) != 1 {
+		return fmt.Errorf("archive %s: expected 1 file, got %d", path, len(reader.File))
+	}
+
+	file, err := reader.File[0].Open()
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	n, err := io.Copy(io.Discard, file)
+	if err != nil {
+		return err
+	}
+
+	if n != size {
+		return fmt.Errorf("archive %s: size mismatch: expected %d, got %d", path, size, n)
+	}
+
+	return nil
+}
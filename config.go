@@ -0,0 +1,182 @@
+package logger
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config — декларативная настройка логгера для NewFromConfig. Нулевые
+// значения оставляют настройки по умолчанию.
+type Config struct {
+	// Path — каталог файлов логов.
+	Path string `json:"path" yaml:"path"`
+	// AppName — имя приложения для {app} в шаблоне имени файла.
+	AppName string `json:"app_name" yaml:"app_name"`
+	// Level — минимальный уровень записей: debug, info, warn, error.
+	Level string `json:"level" yaml:"level"`
+	// Structured включает JSON в файле.
+	Structured bool `json:"structured" yaml:"structured"`
+	// Format — формат файла по имени, как в опции Format.
+	Format string `json:"format" yaml:"format"`
+	// Rotation — ротация и хранение файлов.
+	Rotation RotationConfig `json:"rotation" yaml:"rotation"`
+	// Outputs — дополнительные назначения в виде URL, как в опции Outputs.
+	Outputs []string `json:"outputs" yaml:"outputs"`
+	// Sampling — сэмплирование по уровням, как в опции Sampling.
+	Sampling map[string]SamplingConfig `json:"sampling" yaml:"sampling"`
+	// Redaction — маскирование чувствительных данных.
+	Redaction RedactionConfig `json:"redaction" yaml:"redaction"`
+}
+
+// SamplingConfig — сэмплирование одного уровня в Config.
+type SamplingConfig struct {
+	First      int `json:"first" yaml:"first"`
+	Thereafter int `json:"thereafter" yaml:"thereafter"`
+}
+
+// RotationConfig — настройки ротации и хранения файлов в Config.
+type RotationConfig struct {
+	// Filename — шаблон имени файла, как в FilenamePattern.
+	Filename string `json:"filename" yaml:"filename"`
+	// Interval — интервал ротации, например "1h", как в RotationInterval.
+	Interval time.Duration `json:"interval" yaml:"interval"`
+	// RotateAt — время суточной ротации "HH:MM".
+	RotateAt string `json:"rotate_at" yaml:"rotate_at"`
+	// MaxSize — размер файла в байтах для ротации по размеру.
+	MaxSize int64 `json:"max_size" yaml:"max_size"`
+	// MaxAge — срок хранения файлов в сутках.
+	MaxAge int `json:"max_age" yaml:"max_age"`
+	// MaxBackups — число хранимых прошлых файлов.
+	MaxBackups int `json:"max_backups" yaml:"max_backups"`
+	// MaxTotalSize — предел общего размера логов в байтах.
+	MaxTotalSize int64 `json:"max_total_size" yaml:"max_total_size"`
+	// Compress отключает сжатие прошлых файлов значением false.
+	Compress *bool `json:"compress" yaml:"compress"`
+}
+
+// RedactionConfig — настройки маскирования в Config, как в RedactFields
+// и RedactPatterns.
+type RedactionConfig struct {
+	Fields   []string `json:"fields" yaml:"fields"`
+	Patterns []string `json:"patterns" yaml:"patterns"`
+}
+
+// LoadConfigFile читает Config из файла YAML (.yaml, .yml) или JSON
+// (.json). Неизвестные ключи считаются ошибкой. Длительности задаются
+// строками вида "15m".
+func LoadConfigFile(path string) (Config, error) {
+	var cfg Config
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml", ".json":
+	default:
+		return cfg, fmt.Errorf("logger: unsupported config file %q: want .yaml, .yml or .json", path)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return cfg, fmt.Errorf("logger: read config: %w", err)
+	}
+
+	// JSON — подмножество YAML, поэтому оба формата разбирает один
+	// декодер, понимающий длительности в виде строк.
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+
+	if err := dec.Decode(&cfg); err != nil {
+		return Config{}, fmt.Errorf("logger: parse config %q: %w", path, err)
+	}
+
+	return cfg, nil
+}
+
+// NewFromConfig создаёт логгер по cfg как Build: неверные значения
+// возвращаются ошибкой. options применяются после настроек cfg, например
+// для хуков и обработчиков, которые нельзя описать в файле.
+func NewFromConfig(cfg Config, options ...Option) (*Logger, error) {
+	// ReloadConfig заменяет правила маскирования из cfg, а правила из
+	// опций сохраняет.
+	redactBase := NewLogger(options...).redactor()
+
+	options = append(cfg.options(), options...)
+	options = append(options, func(l *Logger) {
+		l.config = &cfg
+		l.redactBase = redactBase
+	})
+
+	return Build(options...)
+}
+
+// options переводит заданные поля cfg в опции логгера.
+func (cfg Config) options() []Option {
+	options := []Option{Path(cfg.Path)}
+
+	if cfg.AppName != "" {
+		options = append(options, AppName(cfg.AppName))
+	}
+	if cfg.Level != "" {
+		options = append(options, Level(cfg.Level))
+	}
+	if cfg.Structured {
+		options = append(options, Structured(true))
+	}
+	if cfg.Format != "" {
+		options = append(options, Format(cfg.Format))
+	}
+
+	rotation := cfg.Rotation
+	if rotation.Filename != "" {
+		options = append(options, FilenamePattern(rotation.Filename))
+	}
+	if rotation.Interval != 0 {
+		options = append(options, RotationInterval(rotation.Interval))
+	}
+	if rotation.RotateAt != "" {
+		options = append(options, RotateAt(rotation.RotateAt))
+	}
+	if rotation.MaxSize != 0 {
+		options = append(options, MaxSize(rotation.MaxSize))
+	}
+	if rotation.MaxAge != 0 {
+		options = append(options, MaxAge(rotation.MaxAge))
+	}
+	if rotation.MaxBackups != 0 {
+		options = append(options, MaxBackups(rotation.MaxBackups))
+	}
+	if rotation.MaxTotalSize != 0 {
+		options = append(options, MaxTotalSize(rotation.MaxTotalSize))
+	}
+	if rotation.Compress != nil {
+		options = append(options, Compress(*rotation.Compress))
+	}
+
+	if len(cfg.Outputs) > 0 {
+		options = append(options, Outputs(cfg.Outputs...))
+	}
+	levels := make([]string, 0, len(cfg.Sampling))
+	for level := range cfg.Sampling {
+		levels = append(levels, level)
+	}
+	sort.Strings(levels)
+
+	for _, level := range levels {
+		rate := cfg.Sampling[level]
+		options = append(options, Sampling(level, rate.First, rate.Thereafter))
+	}
+
+	if len(cfg.Redaction.Fields) > 0 {
+		options = append(options, RedactFields(cfg.Redaction.Fields...))
+	}
+	if len(cfg.Redaction.Patterns) > 0 {
+		options = append(options, RedactPatterns(cfg.Redaction.Patterns...))
+	}
+
+	return options
+}
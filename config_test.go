@@ -0,0 +1,108 @@
+package logger
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// TestLoadConfigFile проверяет чтение одинаковой настройки из YAML и JSON.
+func TestLoadConfigFile(t *testing.T) {
+	dir := t.TempDir()
+
+	yamlPath := filepath.Join(dir, "logger.yaml")
+	require.NoError(t, os.WriteFile(yamlPath, []byte(`
+path: /var/log/app
+level: warn
+structured: true
+rotation:
+  interval: 1h
+  max_size: 1048576
+  max_age: 7
+  compress: false
+outputs:
+  - stderr?level=error
+redaction:
+  fields: [password, token]
+  patterns: ['\d{16}']
+`), 0o600))
+
+	jsonPath := filepath.Join(dir, "logger.json")
+	require.NoError(t, os.WriteFile(jsonPath, []byte(`{
+	"path": "/var/log/app",
+	"level": "warn",
+	"structured": true,
+	"rotation": {"interval": "1h", "max_size": 1048576, "max_age": 7, "compress": false},
+	"outputs": ["stderr?level=error"],
+	"redaction": {"fields": ["password", "token"], "patterns": ["\\d{16}"]}
+}`), 0o600))
+
+	compress := false
+	want := Config{
+		Path:       "/var/log/app",
+		Level:      "warn",
+		Structured: true,
+		Rotation:   RotationConfig{Interval: time.Hour, MaxSize: 1 << 20, MaxAge: 7, Compress: &compress},
+		Outputs:    []string{"stderr?level=error"},
+		Redaction:  RedactionConfig{Fields: []string{"password", "token"}, Patterns: []string{`\d{16}`}},
+	}
+
+	for _, path := range []string{yamlPath, jsonPath} {
+		cfg, err := LoadConfigFile(path)
+		require.NoError(t, err, path)
+		assert.Equal(t, want, cfg, path)
+	}
+}
+
+// TestLoadConfigFileErrors проверяет отказ для неизвестных ключей и
+// расширений.
+func TestLoadConfigFileErrors(t *testing.T) {
+	dir := t.TempDir()
+
+	typo := filepath.Join(dir, "typo.yml")
+	require.NoError(t, os.WriteFile(typo, []byte("levle: debug\n"), 0o600))
+	_, err := LoadConfigFile(typo)
+	assert.ErrorContains(t, err, "levle")
+
+	_, err = LoadConfigFile(filepath.Join(dir, "logger.toml"))
+	assert.ErrorContains(t, err, "unsupported config file")
+
+	_, err = LoadConfigFile(filepath.Join(dir, "missing.yaml"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+// TestNewFromConfig проверяет применение настроек Config.
+func TestNewFromConfig(t *testing.T) {
+	tmpDir := t.TempDir()
+	compress := false
+
+	logger, err := NewFromConfig(Config{
+		Path:       tmpDir,
+		Level:      "info",
+		Structured: true,
+		Rotation:   RotationConfig{MaxBackups: 3, Compress: &compress},
+		Redaction:  RedactionConfig{Fields: []string{"password"}},
+	}, AppName("billing"))
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, logger.maxBackups)
+	assert.True(t, logger.noCompress)
+	assert.Equal(t, "billing", logger.appName)
+
+	require.NoError(t, logger.Init(false))
+	logger.Debug("hidden")
+	logger.InfoW("login", zap.String("password", "secret"))
+	require.NoError(t, logger.Close())
+
+	lines := readJSONLines(t, filepath.Join(tmpDir, time.Now().Format(dateLayout)+".log"))
+	require.Len(t, lines, 1)
+	assert.Equal(t, RedactedValue, lines[0]["password"])
+
+	_, err = NewFromConfig(Config{Path: tmpDir, Level: "verbose"})
+	assert.ErrorContains(t, err, "unknown level")
+}
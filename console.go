@@ -0,0 +1,265 @@
+package logger
+
+import (
+	"hash/fnv"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// ConsoleMode определяет формат консольного вывода.
+type ConsoleMode int
+
+const (
+	// ConsoleAuto выводит цветной текст в терминал и простой текст, если
+	// stdout перенаправлен в файл или канал.
+	ConsoleAuto ConsoleMode = iota
+	// ConsoleAutoJSON выводит цветной текст в терминал и JSON, если stdout
+	// не терминал (например, под Kubernetes).
+	ConsoleAutoJSON
+	// ConsoleColor всегда выводит цветной текст.
+	ConsoleColor
+	// ConsolePlain всегда выводит простой текст.
+	ConsolePlain
+	// ConsoleJSON всегда выводит JSON.
+	ConsoleJSON
+)
+
+// resolve заменяет автоматические режимы конкретными с учётом того,
+// является ли вывод терминалом. Переменная NO_COLOR отключает цвет.
+func (m ConsoleMode) resolve(tty bool) ConsoleMode {
+	switch m {
+	case ConsoleAuto, ConsoleAutoJSON:
+		if tty && os.Getenv("NO_COLOR") == "" {
+			return ConsoleColor
+		}
+		if tty || m == ConsoleAuto {
+			return ConsolePlain
+		}
+		return ConsoleJSON
+	default:
+		return m
+	}
+}
+
+// BadgeStyle задаёт компактное обозначение уровня в текстовом консольном
+// выводе.
+type BadgeStyle int
+
+const (
+	// BadgeNone выводит уровень словом, как zap по умолчанию.
+	BadgeNone BadgeStyle = iota
+	// BadgeTags выводит трёхбуквенные теги: DBG, INF, WRN, ERR.
+	BadgeTags
+	// BadgeSymbols выводит символы: ·, ℹ, ⚠, ✖.
+	BadgeSymbols
+)
+
+// callerWidth — ширина колонки caller при выводе с бейджами, чтобы
+// сообщения начинались с одной позиции.
+const callerWidth = 28
+
+var levelBadges = map[BadgeStyle]map[zapcore.Level]string{
+	BadgeTags: {
+		zapcore.DebugLevel:  "DBG",
+		zapcore.InfoLevel:   "INF",
+		zapcore.WarnLevel:   "WRN",
+		zapcore.ErrorLevel:  "ERR",
+		zapcore.DPanicLevel: "DPN",
+		zapcore.PanicLevel:  "PNC",
+		zapcore.FatalLevel:  "FTL",
+	},
+	BadgeSymbols: {
+		zapcore.DebugLevel:  "·",
+		zapcore.InfoLevel:   "ℹ",
+		zapcore.WarnLevel:   "⚠",
+		zapcore.ErrorLevel:  "✖",
+		zapcore.DPanicLevel: "‼",
+		zapcore.PanicLevel:  "‼",
+		zapcore.FatalLevel:  "☠",
+	},
+}
+
+var levelColors = map[zapcore.Level]string{
+	zapcore.DebugLevel:  "\x1b[35m",
+	zapcore.InfoLevel:   "\x1b[34m",
+	zapcore.WarnLevel:   "\x1b[33m",
+	zapcore.ErrorLevel:  "\x1b[31m",
+	zapcore.DPanicLevel: "\x1b[31m",
+	zapcore.PanicLevel:  "\x1b[31m",
+	zapcore.FatalLevel:  "\x1b[31m",
+}
+
+const colorReset = "\x1b[0m"
+
+// nameColors — цвета имён логгеров; цвет выбирается по хешу имени, чтобы
+// подсистема всегда выводилась одним цветом.
+var nameColors = []string{"\x1b[36m", "\x1b[32m", "\x1b[35m", "\x1b[33m", "\x1b[34m", "\x1b[96m"}
+
+func colorNameEncoder(name string, enc zapcore.PrimitiveArrayEncoder) {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(name))
+
+	enc.AppendString(nameColors[h.Sum32()%uint32(len(nameColors))] + name + colorReset)
+}
+
+func newConsoleEncoder(mode ConsoleMode, badges BadgeStyle, cfg zapcore.EncoderConfig) zapcore.Encoder {
+	if mode == ConsoleJSON {
+		return zapcore.NewJSONEncoder(cfg)
+	}
+
+	if mode == ConsoleColor {
+		cfg.EncodeLevel = zapcore.LowercaseColorLevelEncoder
+		cfg.EncodeName = colorNameEncoder
+	}
+
+	if _, ok := levelBadges[badges]; ok {
+		cfg.EncodeLevel = badgeLevelEncoder(badges, mode == ConsoleColor)
+		cfg.EncodeCaller = alignedCallerEncoder
+	}
+
+	return zapcore.NewConsoleEncoder(cfg)
+}
+
+func badgeLevelEncoder(style BadgeStyle, color bool) zapcore.LevelEncoder {
+	badges := levelBadges[style]
+
+	return func(level zapcore.Level, enc zapcore.PrimitiveArrayEncoder) {
+		badge, ok := badges[level]
+		if !ok {
+			badge = level.CapitalString()
+		}
+
+		if color {
+			badge = levelColors[level] + badge + colorReset
+		}
+
+		enc.AppendString(badge)
+	}
+}
+
+func alignedCallerEncoder(caller zapcore.EntryCaller, enc zapcore.PrimitiveArrayEncoder) {
+	path := caller.TrimmedPath()
+	if pad := callerWidth - len(path); pad > 0 {
+		path += strings.Repeat(" ", pad)
+	}
+
+	enc.AppendString(path)
+}
+
+func isTerminal(file *os.File) bool {
+	info, err := file.Stat()
+	if err != nil {
+		return false
+	}
+
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// consoleSyncer пишет в консоль без fsync: для терминала и канала Sync
+// возвращает EINVAL или ENOTTY, и Close логгера завершался бы ошибкой.
+type consoleSyncer struct {
+	io.Writer
+}
+
+func (consoleSyncer) Sync() error {
+	return nil
+}
+
+// bufferedConsole накапливает записи и сбрасывает их одним системным
+// вызовом: при заполнении буфера, по таймеру и при Sync. Каждая запись zap
+// — целые строки, поэтому сброс никогда не разрывает строку.
+type bufferedConsole struct {
+	out  io.Writer
+	buf  []byte
+	size int
+	mu   sync.Mutex
+
+	metrics Metrics
+
+	stop chan struct{}
+	done chan struct{}
+	once sync.Once
+}
+
+func newBufferedConsole(out io.Writer, size int, interval time.Duration) *bufferedConsole {
+	c := &bufferedConsole{
+		out:  out,
+		buf:  make([]byte, 0, size),
+		size: size,
+		stop: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+
+	goLabeled("console-flush", func() { c.flushLoop(interval) })
+
+	return c
+}
+
+func (c *bufferedConsole) Write(p []byte) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.buf)+len(p) > c.size {
+		if err := c.flush(); err != nil {
+			return 0, err
+		}
+	}
+
+	if len(p) > c.size {
+		return c.out.Write(p)
+	}
+
+	c.buf = append(c.buf, p...)
+
+	if c.metrics != nil {
+		c.metrics.ObserveQueueDepth(len(c.buf))
+	}
+
+	return len(p), nil
+}
+
+func (c *bufferedConsole) Sync() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.flush()
+}
+
+func (c *bufferedConsole) Close() error {
+	c.once.Do(func() { close(c.stop) })
+	<-c.done
+
+	return c.Sync()
+}
+
+func (c *bufferedConsole) flush() error {
+	if len(c.buf) == 0 {
+		return nil
+	}
+
+	_, err := c.out.Write(c.buf)
+	c.buf = c.buf[:0]
+
+	return err
+}
+
+func (c *bufferedConsole) flushLoop(interval time.Duration) {
+	defer close(c.done)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			_ = c.Sync()
+		case <-c.stop:
+			return
+		}
+	}
+}
@@ -0,0 +1,199 @@
+package logger
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+type syncBuffer struct {
+	buf    bytes.Buffer
+	writes int
+	mu     sync.Mutex
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.writes++
+
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.buf.String()
+}
+
+// TestBufferedConsole проверяет накопление записей и их сброс одним вызовом.
+func TestBufferedConsole(t *testing.T) {
+	out := &syncBuffer{}
+	console := newBufferedConsole(out, 64, time.Hour)
+
+	_, err := console.Write([]byte("line 1\n"))
+	require.NoError(t, err)
+	_, err = console.Write([]byte("line 2\n"))
+	require.NoError(t, err)
+	assert.Empty(t, out.String(), "Entries should be buffered until flush")
+
+	require.NoError(t, console.Sync())
+	assert.Equal(t, "line 1\nline 2\n", out.String())
+	assert.Equal(t, 1, out.writes, "Buffered entries should be written with a single call")
+
+	_, err = console.Write(bytes.Repeat([]byte("x"), 100))
+	require.NoError(t, err)
+	assert.Len(t, out.String(), 114, "Entries larger than buffer should be written directly")
+
+	require.NoError(t, console.Close())
+	assert.NotPanics(t, func() { _ = console.Close() }, "Repeated Close should be safe")
+}
+
+// TestBufferedConsoleInterval проверяет сброс буфера по таймеру.
+func TestBufferedConsoleInterval(t *testing.T) {
+	out := &syncBuffer{}
+	console := newBufferedConsole(out, 1024, 10*time.Millisecond)
+	defer console.Close()
+
+	_, err := console.Write([]byte("line\n"))
+	require.NoError(t, err)
+
+	assert.Eventually(t, func() bool {
+		return out.String() == "line\n"
+	}, time.Second, 5*time.Millisecond)
+}
+
+// TestConsoleModeResolve проверяет выбор формата консоли в зависимости от терминала.
+func TestConsoleModeResolve(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+
+	tests := []struct {
+		name     string
+		mode     ConsoleMode
+		tty      bool
+		expected ConsoleMode
+	}{
+		{name: "Auto on terminal", mode: ConsoleAuto, tty: true, expected: ConsoleColor},
+		{name: "Auto on pipe", mode: ConsoleAuto, tty: false, expected: ConsolePlain},
+		{name: "AutoJSON on terminal", mode: ConsoleAutoJSON, tty: true, expected: ConsoleColor},
+		{name: "AutoJSON on pipe", mode: ConsoleAutoJSON, tty: false, expected: ConsoleJSON},
+		{name: "Forced JSON on terminal", mode: ConsoleJSON, tty: true, expected: ConsoleJSON},
+		{name: "Forced color on pipe", mode: ConsoleColor, tty: false, expected: ConsoleColor},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.mode.resolve(tt.tty))
+		})
+	}
+
+	t.Setenv("NO_COLOR", "1")
+	assert.Equal(t, ConsolePlain, ConsoleAuto.resolve(true), "NO_COLOR should disable colors")
+}
+
+// TestConsoleBadges проверяет вывод уровней в виде компактных бейджей.
+func TestConsoleBadges(t *testing.T) {
+	cfg := zap.NewProductionEncoderConfig()
+	cfg.TimeKey = ""
+
+	tests := []struct {
+		name     string
+		mode     ConsoleMode
+		style    BadgeStyle
+		expected string
+	}{
+		{name: "Tags", mode: ConsolePlain, style: BadgeTags, expected: "WRN\t"},
+		{name: "Symbols", mode: ConsolePlain, style: BadgeSymbols, expected: "⚠\t"},
+		{name: "Colored tags", mode: ConsoleColor, style: BadgeTags, expected: "\x1b[33mWRN\x1b[0m\t"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoder := newConsoleEncoder(tt.mode, tt.style, cfg)
+			buf, err := encoder.EncodeEntry(zapcore.Entry{
+				Level:   zapcore.WarnLevel,
+				Message: "message",
+				Caller:  zapcore.NewEntryCaller(0, "/src/pkg/file.go", 10, true),
+			}, nil)
+			require.NoError(t, err)
+
+			line := buf.String()
+			assert.True(t, strings.HasPrefix(line, tt.expected), "Unexpected line %q", line)
+			assert.Contains(t, line, "pkg/file.go:10"+strings.Repeat(" ", callerWidth-len("pkg/file.go:10"))+"\tmessage")
+		})
+	}
+}
+
+// TestColorNames проверяет цвет имени логгера в цветном режиме.
+func TestColorNames(t *testing.T) {
+	cfg := zap.NewProductionEncoderConfig()
+	cfg.TimeKey = ""
+	cfg.NameKey = "logger"
+
+	entry := zapcore.Entry{Level: zapcore.InfoLevel, LoggerName: "api.auth", Message: "message"}
+
+	buf, err := newConsoleEncoder(ConsoleColor, BadgeNone, cfg).EncodeEntry(entry, nil)
+	require.NoError(t, err)
+	assert.Regexp(t, `^\x1b\[34minfo\x1b\[0m\t\x1b\[\d+mapi\.auth\x1b\[0m\tmessage`, buf.String())
+
+	again, err := newConsoleEncoder(ConsoleColor, BadgeNone, cfg).EncodeEntry(entry, nil)
+	require.NoError(t, err)
+	assert.Equal(t, buf.String(), again.String(), "Name color should be stable")
+
+	buf, err = newConsoleEncoder(ConsolePlain, BadgeNone, cfg).EncodeEntry(entry, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "info\tapi.auth\tmessage\n", buf.String())
+
+	logger := NewLogger(Color(true))
+	assert.Equal(t, ConsoleColor, logger.consoleMode)
+	logger = NewLogger(Color(false))
+	assert.Equal(t, ConsolePlain, logger.consoleMode)
+}
+
+// TestConsoleStderrLevel проверяет разделение консольного вывода на stdout
+// и stderr по уровню.
+func TestConsoleStderrLevel(t *testing.T) {
+	oldStdout, oldStderr := os.Stdout, os.Stderr
+	outR, outW, err := os.Pipe()
+	require.NoError(t, err)
+	errR, errW, err := os.Pipe()
+	require.NoError(t, err)
+	os.Stdout, os.Stderr = outW, errW
+
+	logger := NewLogger(Path(t.TempDir()), Console(ConsolePlain), ConsoleStderrLevel("error"))
+	require.NoError(t, logger.Init(true))
+
+	logger.Info("info message")
+	logger.Warn("warn message")
+	logger.Error("error message")
+	require.NoError(t, logger.Close())
+
+	outW.Close()
+	errW.Close()
+	os.Stdout, os.Stderr = oldStdout, oldStderr
+
+	stdout, err := io.ReadAll(outR)
+	require.NoError(t, err)
+	stderr, err := io.ReadAll(errR)
+	require.NoError(t, err)
+
+	assert.Contains(t, string(stdout), "info message")
+	assert.Contains(t, string(stdout), "warn message")
+	assert.NotContains(t, string(stdout), "error message")
+	assert.Contains(t, string(stderr), "error message")
+	assert.NotContains(t, string(stderr), "warn message")
+
+	_, err = Build(Path(t.TempDir()), ConsoleStderrLevel("fatal!"))
+	assert.ErrorContains(t, err, `unknown stderr level "fatal!"`)
+}
@@ -0,0 +1,75 @@
+package logger
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+// ContextExtractor извлекает из контекста поля записи: идентификаторы
+// запроса, трассировки, пользователя и т.п.
+type ContextExtractor func(ctx context.Context) []zap.Field
+
+// WithContextExtractor добавляет извлекатель полей для методов DebugCtx,
+// InfoCtx, WarnCtx и ErrorCtx. Извлекатели вызываются в порядке
+// добавления.
+func WithContextExtractor(extractor ContextExtractor) Option {
+	return func(l *Logger) {
+		l.extractors = append(l.extractors, extractor)
+	}
+}
+
+// ctxLogger возвращает логгер с полями спана OpenTelemetry и полями
+// извлекателей из ctx.
+func (l *Logger) ctxLogger(ctx context.Context) *zap.SugaredLogger {
+	if ctx == nil {
+		return l.sugarLogger
+	}
+
+	var fields []interface{}
+	for _, field := range l.traceFields(ctx) {
+		fields = append(fields, field)
+	}
+	for _, extractor := range l.extractors {
+		for _, field := range extractor(ctx) {
+			fields = append(fields, field)
+		}
+	}
+
+	if len(fields) == 0 {
+		return l.sugarLogger
+	}
+
+	return l.sugarLogger.With(fields...)
+}
+
+func (l *Logger) DebugCtx(ctx context.Context, args ...interface{}) {
+	l.ctxLogger(ctx).Debug(args...)
+}
+
+func (l *Logger) InfoCtx(ctx context.Context, args ...interface{}) {
+	l.ctxLogger(ctx).Info(args...)
+}
+
+func (l *Logger) WarnCtx(ctx context.Context, args ...interface{}) {
+	l.ctxLogger(ctx).Warn(args...)
+}
+
+func (l *Logger) ErrorCtx(ctx context.Context, args ...interface{}) {
+	l.ctxLogger(ctx).Error(args...)
+}
+
+type loggerContextKey struct{}
+
+// NewContext возвращает копию ctx с логгером l, например логгером запроса
+// с его идентификатором (см. HTTPMiddleware).
+func NewContext(ctx context.Context, l *Logger) context.Context {
+	return context.WithValue(ctx, loggerContextKey{}, l)
+}
+
+// FromContext возвращает логгер, сохранённый NewContext, и признак его
+// наличия.
+func FromContext(ctx context.Context) (*Logger, bool) {
+	l, ok := ctx.Value(loggerContextKey{}).(*Logger)
+	return l, ok && l != nil
+}
@@ -0,0 +1,45 @@
+package logger
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+type requestIDKey struct{}
+
+// TestContextExtractor проверяет добавление полей из контекста.
+func TestContextExtractor(t *testing.T) {
+	core, logs := observer.New(zap.DebugLevel)
+
+	logger := NewLogger(
+		WithContextExtractor(func(ctx context.Context) []zap.Field {
+			if id, ok := ctx.Value(requestIDKey{}).(string); ok {
+				return []zap.Field{zap.String("request_id", id)}
+			}
+			return nil
+		}),
+		WithContextExtractor(func(ctx context.Context) []zap.Field {
+			return []zap.Field{zap.String("service", "billing")}
+		}),
+	)
+	logger.baseLogger = zap.New(core, zap.AddCaller(), zap.AddCallerSkip(1))
+	logger.sugarLogger = logger.baseLogger.Sugar()
+
+	ctx := context.WithValue(context.Background(), requestIDKey{}, "r1")
+
+	logger.DebugCtx(ctx, "debug")
+	logger.InfoCtx(ctx, "info")
+	logger.WarnCtx(context.Background(), "warn")
+	logger.ErrorCtx(ctx, "error")
+
+	entries := logs.All()
+	if assert.Len(t, entries, 4) {
+		assert.Equal(t, map[string]interface{}{"request_id": "r1", "service": "billing"}, entries[1].ContextMap())
+		assert.Equal(t, map[string]interface{}{"service": "billing"}, entries[2].ContextMap())
+		assert.Contains(t, entries[3].Caller.File, "context_test.go")
+	}
+}
@@ -0,0 +1,212 @@
+package logger
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+// Записи между процессами передаются кадрами: 4 байта длины (big endian)
+// и закодированная запись. Каждый кадр пишется в файл одним вызовом Write,
+// поэтому записи разных процессов не перемешиваются.
+const maxFrameSize = 16 << 20
+
+var errFrameTooLarge = errors.New("logger: socket frame too large")
+
+// ListenSocket запускает приём записей от других процессов через Unix-сокет
+// и пишет их в файл этого логгера. Сокет закрывается в Close.
+func (l *Logger) ListenSocket(path string) error {
+	if l.rotator == nil {
+		return errors.New("logger: ListenSocket requires file output, call InitLogger first")
+	}
+
+	if info, err := os.Lstat(path); err == nil && info.Mode()&os.ModeSocket != 0 {
+		_ = os.Remove(path)
+	}
+
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return err
+	}
+
+	server := &socketServer{
+		listener: listener,
+		writer:   l.rotator,
+		conns:    make(map[net.Conn]struct{}),
+	}
+
+	server.wg.Add(1)
+	goLabeled("socket-server", server.serve)
+
+	l.closers = append(l.closers, server)
+
+	return nil
+}
+
+type socketServer struct {
+	listener net.Listener
+	writer   io.Writer
+	conns    map[net.Conn]struct{}
+	closed   bool
+	mu       sync.Mutex
+	wg       sync.WaitGroup
+}
+
+func (s *socketServer) serve() {
+	defer s.wg.Done()
+
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		s.mu.Lock()
+		if s.closed {
+			s.mu.Unlock()
+			_ = conn.Close()
+			return
+		}
+		s.conns[conn] = struct{}{}
+		s.mu.Unlock()
+
+		s.wg.Add(1)
+		goLabeled("socket-conn", func() { s.handle(conn) })
+	}
+}
+
+func (s *socketServer) handle(conn net.Conn) {
+	defer s.wg.Done()
+	defer func() {
+		s.mu.Lock()
+		delete(s.conns, conn)
+		s.mu.Unlock()
+		_ = conn.Close()
+	}()
+
+	for {
+		frame, err := readFrame(conn)
+		if err != nil {
+			return
+		}
+
+		if _, err := s.writer.Write(frame); err != nil {
+			return
+		}
+	}
+}
+
+func (s *socketServer) Close() error {
+	s.mu.Lock()
+	s.closed = true
+	err := s.listener.Close()
+	for conn := range s.conns {
+		_ = conn.Close()
+	}
+	s.mu.Unlock()
+
+	s.wg.Wait()
+
+	return err
+}
+
+func readFrame(r io.Reader) ([]byte, error) {
+	var header [4]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return nil, err
+	}
+
+	size := binary.BigEndian.Uint32(header[:])
+	if size > maxFrameSize {
+		return nil, errFrameTooLarge
+	}
+
+	frame := make([]byte, size)
+	if _, err := io.ReadFull(r, frame); err != nil {
+		return nil, err
+	}
+
+	return frame, nil
+}
+
+// socketWriter отправляет каждую запись отдельным кадром процессу, который
+// владеет файлом. При обрыве соединения переподключается при следующей
+// записи.
+type socketWriter struct {
+	path   string
+	conn   net.Conn
+	buf    []byte
+	failed bool
+	diag   *zap.Logger
+	mu     sync.Mutex
+}
+
+var _ io.WriteCloser = (*socketWriter)(nil)
+
+func (w *socketWriter) Write(p []byte) (int, error) {
+	if len(p) > maxFrameSize {
+		return 0, errFrameTooLarge
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.buf = binary.BigEndian.AppendUint32(w.buf[:0], uint32(len(p)))
+	w.buf = append(w.buf, p...)
+
+	err := w.send()
+	if err != nil {
+		// Соединение могло быть закрыто сервером: пробуем ещё раз с новым.
+		err = w.send()
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+func (w *socketWriter) send() error {
+	if w.conn == nil {
+		conn, err := net.Dial("unix", w.path)
+		if err != nil {
+			return err
+		}
+
+		if w.failed {
+			diagLogger(w.diag).Info("socket reconnected", zap.String("path", w.path))
+		}
+
+		w.conn = conn
+		w.failed = false
+	}
+
+	if _, err := w.conn.Write(w.buf); err != nil {
+		diagLogger(w.diag).Warn("socket write failed", zap.String("path", w.path), zap.Error(err))
+		_ = w.conn.Close()
+		w.conn = nil
+		w.failed = true
+		return err
+	}
+
+	return nil
+}
+
+func (w *socketWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.conn == nil {
+		return nil
+	}
+
+	err := w.conn.Close()
+	w.conn = nil
+
+	return err
+}
@@ -0,0 +1,53 @@
+package logger
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSocketOutput проверяет запись в файл процесса-владельца через Unix-сокет.
+func TestSocketOutput(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "logger_test")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	sockDir, err := os.MkdirTemp("", "logger_sock")
+	require.NoError(t, err)
+	defer os.RemoveAll(sockDir)
+
+	socketPath := filepath.Join(sockDir, "log.sock")
+
+	owner := NewLogger(Path(tmpDir))
+	owner.InitLogger(false)
+	require.NoError(t, owner.ListenSocket(socketPath))
+
+	worker := NewLogger(SocketOutput(socketPath))
+	worker.InitLogger(false)
+
+	worker.Info("message from worker")
+	require.NoError(t, worker.Close())
+
+	filePath := filepath.Join(tmpDir, time.Now().Format(dateLayout)+".log")
+	assert.Eventually(t, func() bool {
+		content, err := os.ReadFile(filePath)
+		return err == nil && len(content) > 0
+	}, time.Second, 10*time.Millisecond)
+
+	require.NoError(t, owner.Close())
+
+	content, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "message from worker")
+}
+
+// TestReadFrameTooLarge проверяет отказ от кадров сверх допустимого размера.
+func TestReadFrameTooLarge(t *testing.T) {
+	_, err := readFrame(bytes.NewReader([]byte{0xff, 0xff, 0xff, 0xff}))
+	assert.ErrorIs(t, err, errFrameTooLarge)
+}
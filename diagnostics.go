@@ -0,0 +1,48 @@
+package logger
+
+import (
+	"io"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Diagnostics включает журнал событий самого логгера: параметры
+// инициализации, ротации, результаты сжатия, удаление артефактов,
+// отбрасывание записей и переподключения назначений. Обычно w — os.Stderr
+// или отдельный файл.
+func Diagnostics(w io.Writer) Option {
+	return func(l *Logger) {
+		l.diagOutput = w
+	}
+}
+
+func newDiagnostics(w io.Writer) *zap.Logger {
+	if w == nil {
+		return zap.NewNop()
+	}
+
+	encoderCfg := zap.NewProductionEncoderConfig()
+	encoderCfg.TimeKey = "time"
+	encoderCfg.MessageKey = "message"
+	encoderCfg.EncodeTime = zapcore.ISO8601TimeEncoder
+
+	core := zapcore.NewCore(zapcore.NewConsoleEncoder(encoderCfg), zapcore.Lock(zapcore.AddSync(w)), zapcore.DebugLevel)
+
+	return zap.New(core).Named("logger")
+}
+
+// diagnosable реализуют назначения, сообщающие о своих событиях.
+type diagnosable interface {
+	setDiagnostics(diag *zap.Logger)
+}
+
+// diagLogger возвращает diag или пустой логгер, если диагностика не
+// включена.
+func diagLogger(diag *zap.Logger) *zap.Logger {
+	if diag == nil {
+		return zap.NewNop()
+	}
+
+	return diag
+}
@@ -0,0 +1,51 @@
+package logger
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestDiagnostics проверяет запись событий инициализации, ротации и сжатия.
+func TestDiagnostics(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "logger_test")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	diag := &syncBuffer{}
+
+	logger := NewLogger(Path(tmpDir), Diagnostics(diag))
+	require.NoError(t, logger.Init(false))
+
+	logger.Info("Test log message")
+	require.NoError(t, logger.Rotate())
+
+	assert.Eventually(t, func() bool {
+		return strings.Contains(diag.String(), "compressed")
+	}, time.Second, 10*time.Millisecond)
+
+	require.NoError(t, logger.Close())
+
+	out := diag.String()
+	assert.Contains(t, out, "initialized")
+	assert.Contains(t, out, "rotated")
+	assert.Contains(t, out, tmpDir)
+}
+
+// TestDiagnosticsDisabled проверяет, что без опции события не пишутся.
+func TestDiagnosticsDisabled(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "logger_test")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	logger := NewLogger(Path(tmpDir))
+	require.NoError(t, logger.Init(false))
+
+	logger.Info("Test log message")
+	require.NoError(t, logger.Rotate())
+	require.NoError(t, logger.Close())
+}
@@ -0,0 +1,242 @@
+// Package loggerecho подключает логгер github.com/restfront/logger к Echo:
+// запись запросов и внутренний логгер Echo.
+package loggerecho
+
+import (
+	"io"
+	stdlog "log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/gommon/log"
+
+	"github.com/restfront/logger"
+)
+
+// ContextKey — ключ логгера запроса в echo.Context.
+const ContextKey = "logger"
+
+// EchoMiddleware записывает каждый запрос в формате logger.HTTPMiddleware
+// и добавляет логгер запроса с полем request_id в контекст запроса
+// (logger.FromContext) и в echo.Context под ключом ContextKey. Ошибка
+// обработчика передаётся в c.Error, чтобы запись содержала итоговый
+// статус, и попадает в поле error.
+func EchoMiddleware(l *logger.Logger) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			start := time.Now()
+			req := c.Request()
+
+			id := req.Header.Get(logger.DefaultRequestIDHeader)
+			if !logger.ValidRequestID(id) {
+				id = logger.NewRequestID()
+			}
+			c.Response().Header().Set(logger.DefaultRequestIDHeader, id)
+
+			ctx, reqLogger := l.RequestContext(req.Context(), id)
+			c.SetRequest(req.WithContext(ctx))
+			c.Set(ContextKey, reqLogger)
+
+			err := next(c)
+			if err != nil {
+				c.Error(err)
+			}
+
+			reqLogger.LogRequest(logger.RequestLog{
+				Method:     req.Method,
+				Path:       req.URL.Path,
+				Status:     c.Response().Status,
+				Latency:    time.Since(start),
+				Bytes:      c.Response().Size,
+				RemoteAddr: req.RemoteAddr,
+				Err:        err,
+			})
+
+			// Ошибка уже обработана c.Error.
+			return nil
+		}
+	}
+}
+
+// SetEchoLogger делает логгер внутренним логгером Echo (e.Logger и
+// e.StdLogger), чтобы ошибки и сообщения фреймворка попадали в те же
+// файлы.
+func SetEchoLogger(e *echo.Echo, l *logger.Logger) {
+	e.Logger = NewEchoLogger(l)
+	e.StdLogger = stdlog.New(l.Writer("error"), "", 0)
+}
+
+// EchoLogger реализует echo.Logger поверх *logger.Logger. Уровень
+// SetLevel дополнительно отсекает записи адаптера, не меняя уровень
+// логгера. Префикс SetPrefix становится именем логгера (поле "logger",
+// как у logger.Named). SetOutput и SetHeader не действуют: вывод и формат
+// задаёт логгер.
+type EchoLogger struct {
+	base   *logger.Logger
+	logger atomic.Pointer[logger.Logger]
+	level  atomic.Uint32
+
+	mu     sync.Mutex
+	prefix string
+}
+
+var _ echo.Logger = (*EchoLogger)(nil)
+
+// NewEchoLogger создаёт адаптер echo.Logger.
+func NewEchoLogger(l *logger.Logger) *EchoLogger {
+	a := &EchoLogger{base: l.WithCallerSkip(1)}
+	a.logger.Store(a.base)
+	a.level.Store(uint32(log.DEBUG))
+
+	return a
+}
+
+func (a *EchoLogger) Output() io.Writer {
+	return a.logger.Load().Writer("info")
+}
+
+func (a *EchoLogger) SetOutput(io.Writer) {}
+
+func (a *EchoLogger) Prefix() string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.prefix
+}
+
+func (a *EchoLogger) SetPrefix(p string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.prefix = p
+
+	if p == "" {
+		a.logger.Store(a.base)
+	} else {
+		a.logger.Store(a.base.Named(p))
+	}
+}
+
+func (a *EchoLogger) Level() log.Lvl {
+	return log.Lvl(a.level.Load())
+}
+
+func (a *EchoLogger) SetLevel(v log.Lvl) {
+	a.level.Store(uint32(v))
+}
+
+func (a *EchoLogger) SetHeader(string) {}
+
+func (a *EchoLogger) enabled(v log.Lvl) bool {
+	return v >= a.Level()
+}
+
+func (a *EchoLogger) Print(i ...interface{}) {
+	a.logger.Load().Info(i...)
+}
+
+func (a *EchoLogger) Printf(format string, args ...interface{}) {
+	a.logger.Load().Infof(format, args...)
+}
+
+func (a *EchoLogger) Printj(j log.JSON) {
+	a.logger.Load().WithFields(j).Info()
+}
+
+func (a *EchoLogger) Debug(i ...interface{}) {
+	if a.enabled(log.DEBUG) {
+		a.logger.Load().Debug(i...)
+	}
+}
+
+func (a *EchoLogger) Debugf(format string, args ...interface{}) {
+	if a.enabled(log.DEBUG) {
+		a.logger.Load().Debugf(format, args...)
+	}
+}
+
+func (a *EchoLogger) Debugj(j log.JSON) {
+	if a.enabled(log.DEBUG) {
+		a.logger.Load().WithFields(j).Debug()
+	}
+}
+
+func (a *EchoLogger) Info(i ...interface{}) {
+	if a.enabled(log.INFO) {
+		a.logger.Load().Info(i...)
+	}
+}
+
+func (a *EchoLogger) Infof(format string, args ...interface{}) {
+	if a.enabled(log.INFO) {
+		a.logger.Load().Infof(format, args...)
+	}
+}
+
+func (a *EchoLogger) Infoj(j log.JSON) {
+	if a.enabled(log.INFO) {
+		a.logger.Load().WithFields(j).Info()
+	}
+}
+
+func (a *EchoLogger) Warn(i ...interface{}) {
+	if a.enabled(log.WARN) {
+		a.logger.Load().Warn(i...)
+	}
+}
+
+func (a *EchoLogger) Warnf(format string, args ...interface{}) {
+	if a.enabled(log.WARN) {
+		a.logger.Load().Warnf(format, args...)
+	}
+}
+
+func (a *EchoLogger) Warnj(j log.JSON) {
+	if a.enabled(log.WARN) {
+		a.logger.Load().WithFields(j).Warn()
+	}
+}
+
+func (a *EchoLogger) Error(i ...interface{}) {
+	if a.enabled(log.ERROR) {
+		a.logger.Load().Error(i...)
+	}
+}
+
+func (a *EchoLogger) Errorf(format string, args ...interface{}) {
+	if a.enabled(log.ERROR) {
+		a.logger.Load().Errorf(format, args...)
+	}
+}
+
+func (a *EchoLogger) Errorj(j log.JSON) {
+	if a.enabled(log.ERROR) {
+		a.logger.Load().WithFields(j).Error()
+	}
+}
+
+func (a *EchoLogger) Fatal(i ...interface{}) {
+	a.logger.Load().Fatal(i...)
+}
+
+func (a *EchoLogger) Fatalj(j log.JSON) {
+	a.logger.Load().WithFields(j).Fatal()
+}
+
+func (a *EchoLogger) Fatalf(format string, args ...interface{}) {
+	a.logger.Load().Fatalf(format, args...)
+}
+
+func (a *EchoLogger) Panic(i ...interface{}) {
+	a.logger.Load().Panic(i...)
+}
+
+func (a *EchoLogger) Panicj(j log.JSON) {
+	a.logger.Load().WithFields(j).Panic()
+}
+
+func (a *EchoLogger) Panicf(format string, args ...interface{}) {
+	a.logger.Load().Panicf(format, args...)
+}
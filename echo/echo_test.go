@@ -0,0 +1,108 @@
+package loggerecho
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strconv"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/gommon/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/restfront/logger"
+)
+
+func newObservedLogger() (*logger.Logger, *observer.ObservedLogs) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	return logger.NewLogger(logger.BaseLogger(zap.New(core, zap.AddCaller(), zap.AddCallerSkip(1)))), logs
+}
+
+// TestEchoMiddleware проверяет запись запросов и ошибок обработчиков.
+func TestEchoMiddleware(t *testing.T) {
+	l, logs := newObservedLogger()
+
+	e := echo.New()
+	e.Use(EchoMiddleware(l))
+	e.GET("/users/:id", func(c echo.Context) error {
+		reqLogger, ok := logger.FromContext(c.Request().Context())
+		require.True(t, ok)
+		assert.Same(t, reqLogger, c.Get(ContextKey))
+
+		reqLogger.Info("loading user")
+		return c.String(http.StatusOK, "user")
+	})
+	e.GET("/forbidden", func(c echo.Context) error {
+		return echo.NewHTTPError(http.StatusForbidden, "no access")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/users/1", nil)
+	req.Header.Set(logger.DefaultRequestIDHeader, "req-1")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	assert.Equal(t, "req-1", rec.Header().Get(logger.DefaultRequestIDHeader))
+
+	entries := logs.TakeAll()
+	require.Len(t, entries, 2)
+	assert.Equal(t, "req-1", entries[0].ContextMap()["request_id"])
+	assert.Equal(t, int64(http.StatusOK), entries[1].ContextMap()["status"])
+	assert.Equal(t, int64(len("user")), entries[1].ContextMap()["bytes"])
+
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/forbidden", nil))
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+
+	entries = logs.TakeAll()
+	require.Len(t, entries, 1)
+	assert.Equal(t, zapcore.WarnLevel, entries[0].Level)
+	assert.Equal(t, int64(http.StatusForbidden), entries[0].ContextMap()["status"])
+	assert.Contains(t, entries[0].ContextMap()["error"], "no access")
+}
+
+// TestEchoLogger проверяет адаптер echo.Logger.
+func TestEchoLogger(t *testing.T) {
+	l, logs := newObservedLogger()
+
+	e := echo.New()
+	SetEchoLogger(e, l)
+
+	_, _, line, _ := runtime.Caller(0)
+	e.Logger.Infof("listening on %s", ":8080")
+	e.Logger.Warnj(log.JSON{"component": "router"})
+	e.Logger.SetLevel(log.ERROR)
+	e.Logger.Warn("filtered")
+	e.StdLogger.Print("http: TLS handshake error")
+
+	entries := logs.TakeAll()
+	require.Len(t, entries, 3)
+
+	assert.Equal(t, "listening on :8080", entries[0].Message)
+	assert.Equal(t, "echo/echo_test.go:"+strconv.Itoa(line+1), entries[0].Caller.TrimmedPath())
+	assert.Equal(t, zapcore.WarnLevel, entries[1].Level)
+	assert.Equal(t, "router", entries[1].ContextMap()["component"])
+	assert.Equal(t, zapcore.ErrorLevel, entries[2].Level)
+	assert.Equal(t, "http: TLS handshake error", entries[2].Message)
+	assert.Equal(t, log.ERROR, e.Logger.Level())
+}
+
+// TestEchoLoggerPrefix проверяет, что префикс становится именем логгера.
+func TestEchoLoggerPrefix(t *testing.T) {
+	l, logs := newObservedLogger()
+
+	a := NewEchoLogger(l)
+	a.SetPrefix("echo")
+	a.Info("started")
+	a.SetPrefix("")
+	a.Info("plain")
+
+	entries := logs.TakeAll()
+	require.Len(t, entries, 2)
+	assert.Equal(t, "echo", entries[0].LoggerName)
+	assert.Empty(t, entries[1].LoggerName)
+	assert.Empty(t, a.Prefix())
+}
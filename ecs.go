@@ -0,0 +1,159 @@
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/zapcore"
+)
+
+// ecsVersion — версия Elastic Common Schema, которой следует формат "ecs".
+const ecsVersion = "8.11.0"
+
+var ecsPool = buffer.NewPool()
+
+// ecsEncoder кодирует записи в JSON по Elastic Common Schema: служебные
+// поля получают имена ECS (@timestamp, log.level, log.logger,
+// log.origin, ecs.version), ошибка в поле "error" раскладывается в
+// error.message, error.type и error.stack_trace, а поля записи с точками в
+// именах ("http.request.method") вкладываются в объекты.
+type ecsEncoder struct {
+	*zapcore.MapObjectEncoder
+	errors map[string]interface{}
+}
+
+func newECSEncoder(zapcore.EncoderConfig) zapcore.Encoder {
+	return &ecsEncoder{MapObjectEncoder: zapcore.NewMapObjectEncoder()}
+}
+
+func (e *ecsEncoder) Clone() zapcore.Encoder {
+	clone := zapcore.NewMapObjectEncoder()
+	for k, v := range e.Fields {
+		clone.Fields[k] = v
+	}
+
+	var errs map[string]interface{}
+	if e.errors != nil {
+		errs = make(map[string]interface{}, len(e.errors))
+		for k, v := range e.errors {
+			errs[k] = v
+		}
+	}
+
+	return &ecsEncoder{MapObjectEncoder: clone, errors: errs}
+}
+
+// addField добавляет поле; ошибка в поле "error" сохраняется отдельно
+// для раскладки в объект error.
+func (e *ecsEncoder) addField(field zapcore.Field) {
+	if field.Key == "error" && field.Type == zapcore.ErrorType {
+		err, _ := field.Interface.(error)
+		if err != nil {
+			e.errors = map[string]interface{}{
+				"message": err.Error(),
+				"type":    fmt.Sprintf("%T", err),
+			}
+			if verbose := fmt.Sprintf("%+v", err); verbose != err.Error() {
+				e.errors["stack_trace"] = verbose
+			}
+		}
+		return
+	}
+
+	field.AddTo(e)
+}
+
+func (e *ecsEncoder) EncodeEntry(entry zapcore.Entry, fields []zapcore.Field) (*buffer.Buffer, error) {
+	enc := e.Clone().(*ecsEncoder)
+	for _, field := range fields {
+		enc.addField(field)
+	}
+
+	// Ошибка, добавленная через With, уже закодирована строками.
+	if message, ok := enc.Fields["error"].(string); ok && enc.errors == nil {
+		enc.errors = map[string]interface{}{"message": message}
+		if verbose, ok := enc.Fields["errorVerbose"].(string); ok {
+			enc.errors["stack_trace"] = verbose
+		}
+		delete(enc.Fields, "error")
+		delete(enc.Fields, "errorVerbose")
+	}
+
+	doc := make(map[string]interface{}, len(enc.Fields)+6)
+	for k, v := range enc.Fields {
+		ecsSet(doc, k, v)
+	}
+
+	if enc.errors != nil {
+		ecsSet(doc, "error", enc.errors)
+	}
+	if entry.Stack != "" {
+		ecsSet(doc, "error.stack_trace", entry.Stack)
+	}
+	if entry.Caller.Defined {
+		ecsSet(doc, "log.origin", map[string]interface{}{
+			"file":     map[string]interface{}{"name": ecsFileName(entry.Caller), "line": entry.Caller.Line},
+			"function": entry.Caller.Function,
+		})
+	}
+
+	// Обязательные поля ecs-logging пишутся с точками на верхнем уровне.
+	doc["@timestamp"] = entry.Time.UTC().Format(time.RFC3339Nano)
+	doc["log.level"] = entry.Level.String()
+	doc["message"] = entry.Message
+	doc["ecs.version"] = ecsVersion
+	if entry.LoggerName != "" {
+		doc["log.logger"] = entry.LoggerName
+	}
+
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	buf := ecsPool.Get()
+	_, _ = buf.Write(data)
+	buf.AppendByte('\n')
+
+	return buf, nil
+}
+
+// ecsSet записывает value по пути key с точками, создавая вложенные
+// объекты. Объекты по одному пути сливаются.
+func ecsSet(doc map[string]interface{}, key string, value interface{}) {
+	parts := strings.Split(key, ".")
+	for _, part := range parts[:len(parts)-1] {
+		next, ok := doc[part].(map[string]interface{})
+		if !ok {
+			next = make(map[string]interface{})
+			doc[part] = next
+		}
+		doc = next
+	}
+
+	last := parts[len(parts)-1]
+	if existing, ok := doc[last].(map[string]interface{}); ok {
+		if values, ok := value.(map[string]interface{}); ok {
+			for k, v := range values {
+				ecsSet(existing, k, v)
+			}
+			return
+		}
+	}
+
+	doc[last] = value
+}
+
+// ecsFileName возвращает путь файла в виде "пакет/файл.go", как
+// TrimmedPath, но без номера строки.
+func ecsFileName(caller zapcore.EntryCaller) string {
+	path := caller.TrimmedPath()
+	if i := strings.LastIndexByte(path, ':'); i >= 0 {
+		return path[:i]
+	}
+
+	return path
+}
@@ -0,0 +1,101 @@
+package logger
+
+import (
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// TestECSEncoder проверяет поля документа ECS.
+func TestECSEncoder(t *testing.T) {
+	enc := newECSEncoder(zapcore.EncoderConfig{}).(*ecsEncoder)
+	zap.String("service.name", "api").AddTo(enc)
+
+	entry := zapcore.Entry{
+		Level:      zapcore.ErrorLevel,
+		Time:       time.Date(2024, 3, 1, 12, 0, 0, 5e8, time.FixedZone("MSK", 3*3600)),
+		LoggerName: "db",
+		Message:    "query failed",
+		Caller:     zapcore.NewEntryCaller(0, "/src/app/db/query.go", 42, true),
+	}
+	entry.Caller.Function = "app/db.Query"
+
+	buf, err := enc.EncodeEntry(entry, []zapcore.Field{
+		zap.String("http.request.method", "GET"),
+		zap.Int("rows", 3),
+		zap.Error(errors.New("timeout")),
+	})
+	require.NoError(t, err)
+	assert.True(t, strings.HasSuffix(buf.String(), "\n"))
+
+	var doc map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &doc))
+
+	assert.Equal(t, map[string]interface{}{
+		"@timestamp":  "2024-03-01T09:00:00.5Z",
+		"log.level":   "error",
+		"log.logger":  "db",
+		"message":     "query failed",
+		"ecs.version": ecsVersion,
+		"log": map[string]interface{}{
+			"origin": map[string]interface{}{
+				"file":     map[string]interface{}{"name": "db/query.go", "line": float64(42)},
+				"function": "app/db.Query",
+			},
+		},
+		"service": map[string]interface{}{"name": "api"},
+		"http": map[string]interface{}{
+			"request": map[string]interface{}{"method": "GET"},
+		},
+		"rows": float64(3),
+		"error": map[string]interface{}{
+			"message": "timeout",
+			"type":    "*errors.errorString",
+		},
+	}, doc)
+
+	// Поля дочернего кодировщика не попадают в исходный.
+	assert.Len(t, enc.Fields, 1)
+	assert.Nil(t, enc.errors)
+}
+
+// TestECSEncoderStack проверяет запись стека в error.stack_trace.
+func TestECSEncoderStack(t *testing.T) {
+	enc := newECSEncoder(zapcore.EncoderConfig{})
+	zap.Error(errors.New("boom")).AddTo(enc)
+
+	buf, err := enc.EncodeEntry(zapcore.Entry{Message: "panic", Stack: "goroutine 1"}, nil)
+	require.NoError(t, err)
+
+	var doc struct {
+		Error map[string]string `json:"error"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &doc))
+	assert.Equal(t, map[string]string{"message": "boom", "stack_trace": "goroutine 1"}, doc.Error)
+}
+
+// TestFormatECS проверяет выбор формата ECS для файла.
+func TestFormatECS(t *testing.T) {
+	tmpDir := t.TempDir()
+	logger := NewLogger(Path(tmpDir), Format("ecs"))
+	require.NoError(t, logger.Init(false))
+
+	logger.InfoW("started", zap.String("event.action", "start"))
+	require.NoError(t, logger.Close())
+
+	lines := readJSONLines(t, filepath.Join(tmpDir, time.Now().Format(dateLayout)+".log"))
+	require.Len(t, lines, 1)
+	assert.Equal(t, "started", lines[0]["message"])
+	assert.Equal(t, "info", lines[0]["log.level"])
+	assert.Equal(t, ecsVersion, lines[0]["ecs.version"])
+	assert.Equal(t, map[string]interface{}{"action": "start"}, lines[0]["event"])
+	assert.Contains(t, lines[0], "@timestamp")
+}
@@ -0,0 +1,173 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// defaultElasticsearchIndex — шаблон индекса по умолчанию.
+const defaultElasticsearchIndex = "logs-{date}"
+
+// ElasticsearchConfig — настройки отправки записей в Elasticsearch или
+// OpenSearch через bulk API.
+type ElasticsearchConfig struct {
+	// URL кластера, например "https://es:9200".
+	URL string
+	// Index — шаблон имени индекса; {date} заменяется датой записи в
+	// формате 2006.01.02. По умолчанию "logs-{date}".
+	Index string
+	// Username и Password задают Basic-аутентификацию.
+	Username string
+	Password string
+	// BatchSize — число записей в одном bulk-запросе, по умолчанию 1000.
+	BatchSize int
+	// FlushInterval — наибольшая задержка отправки, по умолчанию 1с.
+	FlushInterval time.Duration
+}
+
+// ElasticsearchSink добавляет отправку записей в Elasticsearch. Записи
+// кодируются в JSON независимо от формата файла и отправляются пачками
+// в фоне с повторами при ошибках сети, 429 и 5xx. Тот же приёмник
+// доступен в Outputs по схемам "elasticsearch+http" и "elasticsearch+https"
+// с параметрами index, batch и flush.
+func ElasticsearchSink(cfg ElasticsearchConfig) Option {
+	return func(l *Logger) {
+		u, err := url.Parse(cfg.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			l.optionErrs = append(l.optionErrs, fmt.Errorf("logger: invalid Elasticsearch URL %q", cfg.URL))
+			return
+		}
+
+		if cfg.BatchSize < 0 || cfg.FlushInterval < 0 {
+			l.optionErrs = append(l.optionErrs, fmt.Errorf("logger: Elasticsearch batch size and flush interval must not be negative"))
+			return
+		}
+
+		u.Scheme = "elasticsearch+" + u.Scheme
+		if cfg.Username != "" {
+			u.User = url.UserPassword(cfg.Username, cfg.Password)
+		}
+
+		query := u.Query()
+		query.Set("format", "json")
+		if cfg.Index != "" {
+			query.Set("index", cfg.Index)
+		}
+		if cfg.BatchSize > 0 {
+			query.Set("batch", strconv.Itoa(cfg.BatchSize))
+		}
+		if cfg.FlushInterval > 0 {
+			query.Set("flush", cfg.FlushInterval.String())
+		}
+		u.RawQuery = query.Encode()
+
+		l.outputs = append(l.outputs, u.String())
+	}
+}
+
+// bulkResponse — часть ответа bulk API, нужная для подсчёта ошибок.
+type bulkResponse struct {
+	Errors bool `json:"errors"`
+	Items  []map[string]struct {
+		Status int `json:"status"`
+		Error  struct {
+			Type   string `json:"type"`
+			Reason string `json:"reason"`
+		} `json:"error"`
+	} `json:"items"`
+}
+
+func newElasticsearchSink(u *url.URL) (Sink, error) {
+	if u.Host == "" {
+		return nil, fmt.Errorf("logger: %s sink requires host", u.Scheme)
+	}
+
+	query := u.Query()
+
+	index := query.Get("index")
+	if index == "" {
+		index = defaultElasticsearchIndex
+	}
+
+	size := 0
+	if raw := query.Get("batch"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n <= 0 {
+			return nil, fmt.Errorf("logger: invalid batch %q in output %q", raw, u.Redacted())
+		}
+		size = n
+	}
+
+	var interval time.Duration
+	if raw := query.Get("flush"); raw != "" {
+		d, err := time.ParseDuration(raw)
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("logger: invalid flush %q in output %q", raw, u.Redacted())
+		}
+		interval = d
+	}
+
+	endpoint := *u
+	endpoint.Scheme = strings.TrimPrefix(u.Scheme, "elasticsearch+")
+	endpoint.User = nil
+	endpoint.RawQuery = ""
+	endpoint.Path = strings.TrimSuffix(endpoint.Path, "/") + "/_bulk"
+
+	client := &http.Client{Timeout: 30 * time.Second}
+
+	return newBatchSink("elasticsearch", size, interval, func(ctx context.Context, batch []batchEntry) (bool, error) {
+		var body bytes.Buffer
+		for _, entry := range batch {
+			action, _ := json.Marshal(map[string]interface{}{
+				"index": map[string]string{"_index": strings.ReplaceAll(index, "{date}", entry.ts.Format("2006.01.02"))},
+			})
+			body.Write(action)
+			body.WriteByte('\n')
+			body.WriteString(entry.line)
+			body.WriteByte('\n')
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.String(), &body)
+		if err != nil {
+			return false, err
+		}
+		req.Header.Set("Content-Type", "application/x-ndjson")
+		if u.User != nil {
+			password, _ := u.User.Password()
+			req.SetBasicAuth(u.User.Username(), password)
+		}
+
+		respBody, retry, err := postBatch(client, req)
+		if err != nil {
+			return retry, fmt.Errorf("logger: elasticsearch bulk: %w", err)
+		}
+
+		// Частично принятую пачку не повторяем, чтобы не дублировать
+		// записанные документы.
+		var resp bulkResponse
+		if err := json.Unmarshal(respBody, &resp); err != nil || !resp.Errors {
+			return false, nil
+		}
+
+		failed, reason := 0, ""
+		for _, item := range resp.Items {
+			for _, result := range item {
+				if result.Status/100 != 2 {
+					failed++
+					if reason == "" {
+						reason = result.Error.Type + ": " + result.Error.Reason
+					}
+				}
+			}
+		}
+
+		return false, fmt.Errorf("logger: elasticsearch bulk: %d of %d items failed: %s", failed, len(batch), reason)
+	}), nil
+}
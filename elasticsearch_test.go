@@ -0,0 +1,104 @@
+package logger
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestElasticsearchSink проверяет bulk-запрос с индексом по дате записи.
+func TestElasticsearchSink(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		lines []string
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/_bulk", r.URL.Path)
+		assert.Equal(t, "application/x-ndjson", r.Header.Get("Content-Type"))
+
+		user, password, ok := r.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "elastic", user)
+		assert.Equal(t, "secret", password)
+
+		mu.Lock()
+		scanner := bufio.NewScanner(r.Body)
+		for scanner.Scan() {
+			lines = append(lines, scanner.Text())
+		}
+		mu.Unlock()
+
+		_, _ = w.Write([]byte(`{"errors":false,"items":[]}`))
+	}))
+	defer server.Close()
+
+	logger := NewLogger(Path(t.TempDir()), AppName("app"), ElasticsearchSink(ElasticsearchConfig{
+		URL:      server.URL,
+		Index:    "app-{date}",
+		Username: "elastic",
+		Password: "secret",
+	}))
+	require.NoError(t, logger.Init(false))
+
+	logger.Info("first")
+	require.NoError(t, logger.Close())
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	require.Len(t, lines, 2)
+	assert.JSONEq(t, `{"index":{"_index":"app-`+time.Now().Format("2006.01.02")+`"}}`, lines[0])
+
+	var record map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &record))
+	assert.Equal(t, "first", record["message"])
+}
+
+// TestElasticsearchSinkItemErrors проверяет, что ошибки отдельных
+// документов возвращаются без повтора запроса.
+func TestElasticsearchSinkItemErrors(t *testing.T) {
+	var requests int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		_, _ = w.Write([]byte(`{"errors":true,"items":[
+			{"index":{"status":201}},
+			{"index":{"status":400,"error":{"type":"mapper_parsing_exception","reason":"failed to parse"}}}
+		]}`))
+	}))
+	defer server.Close()
+
+	u, err := parseSinkURL("elasticsearch+" + server.URL + "?batch=10&flush=1m")
+	require.NoError(t, err)
+
+	sink, err := newElasticsearchSink(u)
+	require.NoError(t, err)
+
+	_, err = sink.Write([]byte("{\"message\":\"ok\"}\n"))
+	require.NoError(t, err)
+	_, err = sink.Write([]byte("{\"message\":1}\n"))
+	require.NoError(t, err)
+
+	err = sink.Sync()
+	assert.ErrorContains(t, err, "1 of 2 items failed: mapper_parsing_exception: failed to parse")
+	require.NoError(t, sink.Close())
+	assert.Equal(t, 1, requests)
+}
+
+// TestElasticsearchSinkInvalidConfig проверяет ошибки настройки.
+func TestElasticsearchSinkInvalidConfig(t *testing.T) {
+	_, err := Build(Path(t.TempDir()), ElasticsearchSink(ElasticsearchConfig{URL: "es:9200"}))
+	assert.ErrorContains(t, err, `invalid Elasticsearch URL "es:9200"`)
+
+	_, err = newElasticsearchSink(&url.URL{Scheme: "elasticsearch+http", Host: "es:9200", RawQuery: "batch=0"})
+	assert.ErrorContains(t, err, `invalid batch "0"`)
+}
@@ -0,0 +1,154 @@
+package logger
+
+import (
+	"fmt"
+	"sync"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// EncoderFactory создаёт кодировщик записей по общей конфигурации логгера.
+type EncoderFactory func(zapcore.EncoderConfig) zapcore.Encoder
+
+var (
+	encodersMu sync.RWMutex
+	encoders   = map[string]EncoderFactory{
+		"console": zapcore.NewConsoleEncoder,
+		"json":    zapcore.NewJSONEncoder,
+		"gelf":    newGELFEncoder,
+		"ecs":     newECSEncoder,
+		"cef":     newCEFEncoder,
+		"leef":    newLEEFEncoder,
+		"otlp":    newOTLPEncoder,
+	}
+)
+
+// RegisterEncoder регистрирует формат вывода, который затем выбирается
+// опцией Format. Повторная регистрация имени возвращает ошибку.
+func RegisterEncoder(name string, factory EncoderFactory) error {
+	encodersMu.Lock()
+	defer encodersMu.Unlock()
+
+	if name == "" {
+		return fmt.Errorf("logger: encoder name must not be empty")
+	}
+
+	if _, exist := encoders[name]; exist {
+		return fmt.Errorf("logger: encoder %q is already registered", name)
+	}
+
+	encoders[name] = factory
+
+	return nil
+}
+
+func lookupEncoder(name string) (EncoderFactory, error) {
+	encodersMu.RLock()
+	defer encodersMu.RUnlock()
+
+	factory, exist := encoders[name]
+	if !exist {
+		return nil, fmt.Errorf("logger: unknown format %q", name)
+	}
+
+	return factory, nil
+}
+
+// EncoderKeys — имена ключей служебных полей записи для форматов "json" и
+// "console". Пустое имя оставляет ключ по умолчанию, "-" исключает поле
+// из записи.
+type EncoderKeys struct {
+	Message    string
+	Level      string
+	Time       string
+	Logger     string
+	Caller     string
+	Function   string
+	Stacktrace string
+}
+
+// RenameKeys переименовывает ключи служебных полей в консоли, файле и
+// Outputs, например EncoderKeys{Message: "msg", Time: "ts"}. Повторные
+// вызовы дополняют друг друга.
+func RenameKeys(keys EncoderKeys) Option {
+	return func(l *Logger) {
+		l.encoderKeys = append(l.encoderKeys, keys)
+	}
+}
+
+// EncoderConfig полностью заменяет базовую конфигурацию кодировщиков
+// консоли, файла и Outputs. RenameKeys, ConsoleEncoderConfig и
+// FileEncoderConfig применяются поверх неё, TimeLayout и UTC — только
+// если в cfg не задан EncodeTime.
+func EncoderConfig(cfg zapcore.EncoderConfig) Option {
+	return func(l *Logger) {
+		l.baseEncoderCfg = &cfg
+	}
+}
+
+// encoderConfig возвращает общую конфигурацию кодировщиков с учётом
+// EncoderConfig, TimeLayout, UTC, TrimCallerPath и RenameKeys.
+func (l *Logger) encoderConfig() zapcore.EncoderConfig {
+	cfg := zap.NewProductionEncoderConfig()
+	cfg.LevelKey = "level"
+	cfg.CallerKey = "caller"
+	cfg.TimeKey = "time"
+	cfg.NameKey = "logger"
+	cfg.MessageKey = "message"
+	cfg.StacktraceKey = "stacktrace"
+	cfg.EncodeTime = l.encodeTime
+
+	if l.baseEncoderCfg != nil {
+		cfg = *l.baseEncoderCfg
+		if cfg.EncodeTime == nil {
+			cfg.EncodeTime = l.encodeTime
+		}
+	}
+
+	if len(l.callerPrefixes) > 0 {
+		cfg.EncodeCaller = trimCallerEncoder(l.callerPrefixes)
+	}
+
+	for _, keys := range l.encoderKeys {
+		renameKey(&cfg.MessageKey, keys.Message)
+		renameKey(&cfg.LevelKey, keys.Level)
+		renameKey(&cfg.TimeKey, keys.Time)
+		renameKey(&cfg.NameKey, keys.Logger)
+		renameKey(&cfg.CallerKey, keys.Caller)
+		renameKey(&cfg.FunctionKey, keys.Function)
+		renameKey(&cfg.StacktraceKey, keys.Stacktrace)
+	}
+
+	return cfg
+}
+
+func renameKey(key *string, name string) {
+	switch name {
+	case "":
+	case "-":
+		*key = zapcore.OmitKey
+	default:
+		*key = name
+	}
+}
+
+// encoderFactory возвращает фабрику формата name с учётом настроек
+// логгера: форматы "cef" и "leef" получают заголовок и шкалу важности
+// из опций SIEMHeader и SIEMSeverity.
+func (l *Logger) encoderFactory(name string) (EncoderFactory, error) {
+	factory, err := lookupEncoder(name)
+	if err != nil || l.siem == nil {
+		return factory, err
+	}
+
+	switch name {
+	case "cef", "leef":
+		cfg := l.siemConfig()
+		return func(zapcore.EncoderConfig) zapcore.Encoder {
+			return &siemEncoder{MapObjectEncoder: zapcore.NewMapObjectEncoder(), cfg: cfg, leef: name == "leef"}
+		}, nil
+	}
+
+	return factory, nil
+}
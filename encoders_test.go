@@ -0,0 +1,105 @@
+package logger
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/zapcore"
+)
+
+type upperMessageEncoder struct {
+	zapcore.Encoder
+}
+
+func (e upperMessageEncoder) Clone() zapcore.Encoder {
+	return upperMessageEncoder{e.Encoder.Clone()}
+}
+
+func (e upperMessageEncoder) EncodeEntry(entry zapcore.Entry, fields []zapcore.Field) (*buffer.Buffer, error) {
+	entry.Message = "custom:" + entry.Message
+	return e.Encoder.EncodeEntry(entry, fields)
+}
+
+// TestRegisterEncoder проверяет регистрацию и использование пользовательского формата.
+func TestRegisterEncoder(t *testing.T) {
+	err := RegisterEncoder("test-custom", func(cfg zapcore.EncoderConfig) zapcore.Encoder {
+		return upperMessageEncoder{zapcore.NewJSONEncoder(cfg)}
+	})
+	require.NoError(t, err)
+
+	err = RegisterEncoder("json", zapcore.NewJSONEncoder)
+	assert.Error(t, err, "Builtin formats should not be overridden")
+
+	tmpDir, err := os.MkdirTemp("", "logger_test")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	logger := NewLogger(Path(tmpDir), Format("test-custom"))
+	require.NoError(t, logger.Init(false))
+
+	logger.Info("Test log message")
+	require.NoError(t, logger.Close())
+
+	files, err := os.ReadDir(tmpDir)
+	require.NoError(t, err)
+	require.NotEmpty(t, files)
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, files[0].Name()))
+	require.NoError(t, err)
+	assert.Contains(t, string(content), `"message":"custom:Test log message"`)
+
+	err = NewLogger(Path(tmpDir), Format("unknown")).Init(false)
+	assert.ErrorContains(t, err, "unknown format")
+}
+
+// TestRenameKeys проверяет переименование и исключение ключей.
+func TestRenameKeys(t *testing.T) {
+	tmpDir := t.TempDir()
+	logger := NewLogger(Path(tmpDir), Structured(true),
+		RenameKeys(EncoderKeys{Message: "msg", Time: "ts"}),
+		RenameKeys(EncoderKeys{Caller: "-"}))
+	require.NoError(t, logger.Init(false))
+
+	logger.Info("renamed")
+	require.NoError(t, logger.Close())
+
+	lines := readJSONLines(t, filepath.Join(tmpDir, time.Now().Format(dateLayout)+".log"))
+	require.Len(t, lines, 1)
+	assert.Equal(t, "renamed", lines[0]["msg"])
+	assert.Equal(t, "info", lines[0]["level"])
+	assert.Contains(t, lines[0], "ts")
+	assert.NotContains(t, lines[0], "message")
+	assert.NotContains(t, lines[0], "caller")
+}
+
+// TestEncoderConfig проверяет полную замену конфигурации кодировщика.
+func TestEncoderConfig(t *testing.T) {
+	tmpDir := t.TempDir()
+	logger := NewLogger(Path(tmpDir), Structured(true), UTC(true),
+		EncoderConfig(zapcore.EncoderConfig{
+			MessageKey:  "@m",
+			LevelKey:    "@l",
+			TimeKey:     "@t",
+			EncodeLevel: zapcore.CapitalLevelEncoder,
+		}),
+		RenameKeys(EncoderKeys{Level: "severity"}))
+	require.NoError(t, logger.Init(false))
+
+	logger.Warn("replaced")
+	require.NoError(t, logger.Close())
+
+	lines := readJSONLines(t, filepath.Join(tmpDir, time.Now().Format(dateLayout)+".log"))
+	require.Len(t, lines, 1)
+	assert.Equal(t, "replaced", lines[0]["@m"])
+	assert.Equal(t, "WARN", lines[0]["severity"])
+	assert.NotContains(t, lines[0], "caller")
+
+	ts, err := time.Parse(defaultTimeLayout, lines[0]["@t"].(string))
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().UTC(), ts, time.Minute)
+}
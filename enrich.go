@@ -0,0 +1,64 @@
+package logger
+
+import (
+	"os"
+	"runtime"
+	"runtime/debug"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// EnrichRuntimeInfo добавляет к каждой записи hostname, pid, go_version
+// и объект build с модулем, версией и ревизией VCS из debug.ReadBuildInfo.
+// Значения вычисляются один раз при инициализации.
+func EnrichRuntimeInfo(enable bool) Option {
+	return func(l *Logger) {
+		l.runtimeInfo = enable
+	}
+}
+
+// runtimeFields собирает поля EnrichRuntimeInfo; недоступные значения
+// пропускаются.
+func runtimeFields() []zap.Field {
+	fields := make([]zap.Field, 0, 4)
+
+	if hostname, err := os.Hostname(); err == nil {
+		fields = append(fields, zap.String("hostname", hostname))
+	}
+
+	fields = append(fields,
+		zap.Int("pid", os.Getpid()),
+		zap.String("go_version", runtime.Version()),
+	)
+
+	if info, ok := debug.ReadBuildInfo(); ok {
+		fields = append(fields, zap.Object("build", buildInfo{info}))
+	}
+
+	return fields
+}
+
+type buildInfo struct {
+	*debug.BuildInfo
+}
+
+func (b buildInfo) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("path", b.Main.Path)
+	if b.Main.Version != "" {
+		enc.AddString("version", b.Main.Version)
+	}
+
+	for _, setting := range b.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			enc.AddString("revision", setting.Value)
+		case "vcs.time":
+			enc.AddString("revision_time", setting.Value)
+		case "vcs.modified":
+			enc.AddBool("modified", setting.Value == "true")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,39 @@
+package logger
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestEnrichRuntimeInfo проверяет поля окружения в записях.
+func TestEnrichRuntimeInfo(t *testing.T) {
+	dir := t.TempDir()
+
+	logger := NewLogger(Path(dir), Structured(true), AppName("app"), EnrichRuntimeInfo(true))
+	require.NoError(t, logger.Init(false))
+
+	logger.Info("started")
+	logger.WithFields(map[string]interface{}{"user": "u1"}).Info("login")
+	require.NoError(t, logger.Close())
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.log"))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+
+	hostname, err := os.Hostname()
+	require.NoError(t, err)
+
+	lines := readJSONLines(t, files[0])
+	require.Len(t, lines, 2)
+	for _, line := range lines {
+		assert.Equal(t, hostname, line["hostname"])
+		assert.EqualValues(t, os.Getpid(), line["pid"])
+		assert.Equal(t, runtime.Version(), line["go_version"])
+		assert.Contains(t, line, "build")
+	}
+}
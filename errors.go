@@ -0,0 +1,114 @@
+package logger
+
+import (
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// WithError возвращает дочерний логгер с ошибкой err в поле "error".
+func (l *Logger) WithError(err error) *Logger {
+	field := zap.Error(err)
+
+	newBaseLogger := l.baseLogger.With(field)
+
+	child := *l
+	child.baseLogger = newBaseLogger
+	child.sugarLogger = newBaseLogger.Sugar()
+	child.fields = append(l.fields[:len(l.fields):len(l.fields)], field)
+
+	return &child
+}
+
+// StructuredErrors записывает ошибки в файл и Outputs объектом с полями
+// type, message и causes (цепочка errors.Unwrap) вместо одной строки,
+// чтобы по ним можно было искать в Kibana. Консоль не меняется.
+func StructuredErrors(enable bool) Option {
+	return func(l *Logger) {
+		l.structuredErrors = enable
+	}
+}
+
+// errorObject кодирует ошибку вместе с цепочкой причин.
+type errorObject struct {
+	err error
+}
+
+func (o errorObject) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("type", fmt.Sprintf("%T", o.err))
+	enc.AddString("message", o.err.Error())
+
+	var causes errorCauses
+	for cause := errors.Unwrap(o.err); cause != nil; cause = errors.Unwrap(cause) {
+		causes = append(causes, cause)
+	}
+
+	if len(causes) > 0 {
+		return enc.AddArray("causes", causes)
+	}
+
+	return nil
+}
+
+type errorCauses []error
+
+func (c errorCauses) MarshalLogArray(enc zapcore.ArrayEncoder) error {
+	for _, cause := range c {
+		err := enc.AppendObject(zapcore.ObjectMarshalerFunc(func(enc zapcore.ObjectEncoder) error {
+			enc.AddString("type", fmt.Sprintf("%T", cause))
+			enc.AddString("message", cause.Error())
+			return nil
+		}))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// errorFieldsCore заменяет поля-ошибки объектами errorObject.
+type errorFieldsCore struct {
+	zapcore.Core
+}
+
+func (c *errorFieldsCore) With(fields []zapcore.Field) zapcore.Core {
+	return &errorFieldsCore{Core: c.Core.With(structureErrors(fields))}
+}
+
+func (c *errorFieldsCore) Check(entry zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return ce.AddCore(entry, c)
+	}
+
+	return ce
+}
+
+func (c *errorFieldsCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	return c.Core.Write(entry, structureErrors(fields))
+}
+
+func structureErrors(fields []zapcore.Field) []zapcore.Field {
+	var structured []zapcore.Field
+
+	for i, field := range fields {
+		err, ok := field.Interface.(error)
+		if field.Type != zapcore.ErrorType || !ok {
+			continue
+		}
+
+		if structured == nil {
+			structured = append([]zapcore.Field(nil), fields...)
+		}
+
+		structured[i] = zap.Object(field.Key, errorObject{err: err})
+	}
+
+	if structured == nil {
+		return fields
+	}
+
+	return structured
+}
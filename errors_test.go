@@ -0,0 +1,63 @@
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestWithError проверяет поле ошибки дочернего логгера.
+func TestWithError(t *testing.T) {
+	dir := t.TempDir()
+
+	logger := NewLogger(Path(dir), Structured(true), AppName("app"))
+	require.NoError(t, logger.Init(false))
+
+	logger.WithError(errors.New("boom")).Error("failed")
+	require.NoError(t, logger.Close())
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.log"))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+
+	lines := readJSONLines(t, files[0])
+	require.Len(t, lines, 1)
+	assert.Equal(t, "boom", lines[0]["error"])
+}
+
+// TestStructuredErrors проверяет запись ошибки объектом с цепочкой причин.
+func TestStructuredErrors(t *testing.T) {
+	dir := t.TempDir()
+
+	logger := NewLogger(Path(dir), Structured(true), AppName("app"), StructuredErrors(true))
+	require.NoError(t, logger.Init(false))
+
+	cause := &fs.PathError{Op: "open", Path: "/etc/app.yaml", Err: fs.ErrNotExist}
+	logger.WithError(fmt.Errorf("load config: %w", cause)).Error("failed")
+	logger.ErrorW("plain", Err(errors.New("boom")))
+	require.NoError(t, logger.Close())
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.log"))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+
+	lines := readJSONLines(t, files[0])
+	require.Len(t, lines, 2)
+	assert.Equal(t, map[string]interface{}{
+		"type":    "*fmt.wrapError",
+		"message": "load config: open /etc/app.yaml: file does not exist",
+		"causes": []interface{}{
+			map[string]interface{}{"type": "*fs.PathError", "message": "open /etc/app.yaml: file does not exist"},
+			map[string]interface{}{"type": "*errors.errorString", "message": "file does not exist"},
+		},
+	}, lines[0]["error"])
+	assert.Equal(t, map[string]interface{}{
+		"type":    "*errors.errorString",
+		"message": "boom",
+	}, lines[1]["error"])
+}
@@ -0,0 +1,162 @@
+package logger
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// levelEscalation хранит исходный уровень на время временного повышения
+// детализации. Общий для логгера и его копий WithFields.
+type levelEscalation struct {
+	base  zapcore.Level
+	timer *time.Timer
+	id    uint64
+	named map[string]*namedEscalation
+	mu    sync.Mutex
+}
+
+// namedEscalation хранит переопределение имени до повышения
+// EscalateNamed (exist отмечает, было ли оно задано) и действовавший
+// тогда уровень имени.
+type namedEscalation struct {
+	base    zapcore.Level
+	exist   bool
+	current zapcore.Level
+	timer   *time.Timer
+	id      uint64
+}
+
+// EscalateLevel временно повышает детализацию до level и через d
+// возвращает исходный уровень. Возвращённая функция возвращает уровень
+// досрочно. Повторный вызов продлевает повышение с новым уровнем, а
+// восстановлен будет уровень до первого повышения. Если текущий уровень
+// уже подробнее level, он не меняется.
+func (l *Logger) EscalateLevel(level string, d time.Duration) (func(), error) {
+	target, exist := loggerLevelMap[level]
+	if !exist {
+		return nil, fmt.Errorf("logger: unknown level %q", level)
+	}
+
+	if l.escalation == nil {
+		return nil, fmt.Errorf("logger: not initialized")
+	}
+
+	e := l.escalation
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.timer == nil {
+		e.base = l.atomicLevel.Level()
+	} else {
+		e.timer.Stop()
+	}
+
+	if target > e.base {
+		target = e.base
+	}
+
+	l.atomicLevel.SetLevel(target)
+
+	e.id++
+	id := e.id
+
+	restore := func() {
+		e.mu.Lock()
+		defer e.mu.Unlock()
+
+		if e.id != id || e.timer == nil {
+			return
+		}
+
+		e.timer.Stop()
+		e.timer = nil
+		l.atomicLevel.SetLevel(e.base)
+
+		diagLogger(l.diag).Info("level restored", zap.Stringer("level", e.base))
+	}
+
+	e.timer = time.AfterFunc(d, restore)
+
+	diagLogger(l.diag).Info("level escalated", zap.Stringer("level", target), zap.Duration("duration", d))
+
+	return restore, nil
+}
+
+// EscalateNamed временно повышает детализацию до level для именованного
+// логгера name и его потомков (см. LevelOverrides) и через d возвращает
+// прежнее переопределение или снимает его. Повторный вызов и досрочный
+// возврат работают как в EscalateLevel. Если текущий уровень name уже
+// подробнее level, он не меняется.
+func (l *Logger) EscalateNamed(name, level string, d time.Duration) (func(), error) {
+	target, exist := loggerLevelMap[level]
+	if !exist {
+		return nil, fmt.Errorf("logger: unknown level %q", level)
+	}
+
+	if l.escalation == nil || l.gate == nil {
+		return nil, fmt.Errorf("logger: not initialized")
+	}
+
+	e := l.escalation
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	n := e.named[name]
+	if n == nil {
+		n = &namedEscalation{}
+		if e.named == nil {
+			e.named = make(map[string]*namedEscalation)
+		}
+		e.named[name] = n
+	}
+
+	if n.timer == nil {
+		var overridden bool
+		if n.current, overridden = l.gate.lookup(name); !overridden {
+			n.current = l.atomicLevel.Level()
+		}
+	} else {
+		n.timer.Stop()
+	}
+
+	if target > n.current {
+		target = n.current
+	}
+
+	prev, existed := l.gate.set(name, target, false)
+	if n.timer == nil {
+		n.base, n.exist = prev, existed
+	}
+
+	e.id++
+	n.id = e.id
+	id := n.id
+
+	restore := func() {
+		e.mu.Lock()
+		defer e.mu.Unlock()
+
+		if n.id != id || n.timer == nil {
+			return
+		}
+
+		n.timer.Stop()
+		n.timer = nil
+		delete(e.named, name)
+		l.gate.set(name, n.base, !n.exist)
+
+		diagLogger(l.diag).Info("level restored", zap.String("name", name))
+	}
+
+	n.timer = time.AfterFunc(d, restore)
+
+	diagLogger(l.diag).Info("level escalated", zap.String("name", name), zap.Stringer("level", target), zap.Duration("duration", d))
+
+	return restore, nil
+}
@@ -0,0 +1,100 @@
+package logger
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+// TestEscalateLevel проверяет повышение детализации и возврат уровня по таймеру.
+func TestEscalateLevel(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "logger_test")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	logger := NewLogger(Path(tmpDir), Level("warn"))
+	require.NoError(t, logger.Init(false))
+	defer logger.Close()
+
+	child := logger.WithFields(map[string]interface{}{"request": "r1"})
+
+	_, err = logger.EscalateLevel("verbose", time.Minute)
+	assert.Error(t, err)
+
+	_, err = child.EscalateLevel("debug", 50*time.Millisecond)
+	require.NoError(t, err)
+
+	assert.True(t, logger.baseLogger.Core().Enabled(zapcore.DebugLevel))
+
+	assert.Eventually(t, func() bool {
+		return !logger.baseLogger.Core().Enabled(zapcore.InfoLevel)
+	}, time.Second, 10*time.Millisecond)
+
+	assert.Equal(t, zapcore.WarnLevel, logger.atomicLevel.Level())
+}
+
+// TestEscalateLevelCancel проверяет досрочный возврат и повторное повышение.
+func TestEscalateLevelCancel(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "logger_test")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	logger := NewLogger(Path(tmpDir), Level("warn"))
+	require.NoError(t, logger.Init(false))
+	defer logger.Close()
+
+	first, err := logger.EscalateLevel("info", time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, zapcore.InfoLevel, logger.atomicLevel.Level())
+
+	second, err := logger.EscalateLevel("debug", time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, zapcore.DebugLevel, logger.atomicLevel.Level())
+
+	first()
+	assert.Equal(t, zapcore.DebugLevel, logger.atomicLevel.Level())
+
+	second()
+	assert.Equal(t, zapcore.WarnLevel, logger.atomicLevel.Level())
+
+	second()
+	assert.Equal(t, zapcore.WarnLevel, logger.atomicLevel.Level())
+}
+
+// TestEscalateNamed проверяет повышение детализации для именованного
+// логгера и возврат прежнего переопределения.
+func TestEscalateNamed(t *testing.T) {
+	logger := NewLogger(Path(t.TempDir()), Level("warn"), LevelOverrides(map[string]string{"db": "error"}))
+	require.NoError(t, logger.Init(false))
+	defer logger.Close()
+
+	db, pool, api := logger.Named("db"), logger.Named("db").Named("pool"), logger.Named("api")
+
+	_, err := logger.EscalateNamed("db", "verbose", time.Minute)
+	assert.Error(t, err)
+
+	restore, err := logger.EscalateNamed("db", "debug", time.Hour)
+	require.NoError(t, err)
+
+	assert.NotNil(t, pool.baseLogger.Check(zapcore.DebugLevel, "pool"))
+	assert.Nil(t, api.baseLogger.Check(zapcore.InfoLevel, "api"))
+
+	restore()
+	assert.Nil(t, db.baseLogger.Check(zapcore.WarnLevel, "db"))
+	assert.NotNil(t, db.baseLogger.Check(zapcore.ErrorLevel, "db"))
+
+	_, err = logger.EscalateNamed("api", "info", 50*time.Millisecond)
+	require.NoError(t, err)
+	assert.NotNil(t, api.baseLogger.Check(zapcore.InfoLevel, "api"))
+
+	assert.Eventually(t, func() bool {
+		return api.baseLogger.Check(zapcore.InfoLevel, "api") == nil
+	}, time.Second, 10*time.Millisecond)
+
+	_, exist := logger.gate.lookup("api")
+	assert.False(t, exist)
+}
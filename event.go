@@ -0,0 +1,46 @@
+package logger
+
+import (
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Event — типизированное событие с фиксированным набором полей. Поля
+// описываются структурой, поэтому их имена проверяются компилятором:
+//
+//	type RequestCompleted struct {
+//		Method   string
+//		Status   int
+//		Duration time.Duration
+//	}
+//
+//	func (RequestCompleted) EventName() string { return "request_completed" }
+//
+//	func (e RequestCompleted) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+//		enc.AddString("method", e.Method)
+//		enc.AddInt("status", e.Status)
+//		enc.AddDuration("duration", e.Duration)
+//		return nil
+//	}
+type Event interface {
+	zapcore.ObjectMarshaler
+	EventName() string
+}
+
+// EventLeveler реализуют события, которые пишутся не на уровне info.
+type EventLeveler interface {
+	EventLevel() zapcore.Level
+}
+
+// Event пишет событие: сообщение и поле "event" содержат имя события,
+// остальные поля берутся из MarshalLogObject.
+func (l *Logger) Event(ev Event) {
+	level := zapcore.InfoLevel
+	if leveler, ok := ev.(EventLeveler); ok {
+		level = leveler.EventLevel()
+	}
+
+	if ce := l.baseLogger.Check(level, ev.EventName()); ce != nil {
+		ce.Write(zap.String("event", ev.EventName()), zap.Inline(ev))
+	}
+}
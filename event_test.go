@@ -0,0 +1,72 @@
+package logger
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+type requestCompleted struct {
+	Method   string
+	Status   int
+	Duration time.Duration
+}
+
+func (requestCompleted) EventName() string {
+	return "request_completed"
+}
+
+func (e requestCompleted) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("method", e.Method)
+	enc.AddInt("status", e.Status)
+	enc.AddDuration("duration", e.Duration)
+	return nil
+}
+
+type paymentFailed struct {
+	Reason string
+}
+
+func (paymentFailed) EventName() string {
+	return "payment_failed"
+}
+
+func (paymentFailed) EventLevel() zapcore.Level {
+	return zapcore.ErrorLevel
+}
+
+func (e paymentFailed) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("reason", e.Reason)
+	return nil
+}
+
+// TestEvent проверяет запись полей и уровня типизированных событий.
+func TestEvent(t *testing.T) {
+	core, logs := observer.New(zap.DebugLevel)
+
+	logger := NewLogger()
+	logger.baseLogger = zap.New(core, zap.AddCaller(), zap.AddCallerSkip(1))
+
+	logger.Event(requestCompleted{Method: "GET", Status: 200, Duration: time.Second})
+	logger.Event(paymentFailed{Reason: "declined"})
+
+	entries := logs.All()
+	if assert.Len(t, entries, 2) {
+		assert.Equal(t, zapcore.InfoLevel, entries[0].Level)
+		assert.Equal(t, "request_completed", entries[0].Message)
+		assert.Equal(t, map[string]interface{}{
+			"event":    "request_completed",
+			"method":   "GET",
+			"status":   200,
+			"duration": time.Second,
+		}, entries[0].ContextMap())
+		assert.Contains(t, entries[0].Caller.File, "event_test.go")
+
+		assert.Equal(t, zapcore.ErrorLevel, entries[1].Level)
+		assert.Equal(t, "declined", entries[1].ContextMap()["reason"])
+	}
+}
@@ -0,0 +1,109 @@
+package logger
+
+import (
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// Fallback задаёт запасные назначения записи в файл: при ошибке записи
+// (диск заполнен, том отключён) запись уходит в первое назначение из
+// outputs, принявшее её. Назначения задаются как в Outputs, например
+// "stderr" или "/var/tmp/app-logs". Каждая следующая запись снова
+// сначала пробует основной файл.
+func Fallback(outputs ...string) Option {
+	return func(l *Logger) {
+		l.fallbacks = append(l.fallbacks, outputs...)
+	}
+}
+
+// OnWriteError вызывает fn при каждой ошибке записи в основной файл,
+// в том числе когда запись удалось сохранить в запасное назначение.
+// fn вызывается синхронно из вызова логгера и не должен писать в логгер.
+func OnWriteError(fn func(err error)) Option {
+	return func(l *Logger) {
+		l.onWriteError = fn
+	}
+}
+
+// openFallbacks открывает запасные назначения.
+func (l *Logger) openFallbacks() ([]Sink, []error) {
+	var (
+		sinks []Sink
+		errs  []error
+	)
+
+	for _, raw := range l.fallbacks {
+		u, err := parseSinkURL(raw)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		sink, err := l.openSink(u)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("logger: cannot open fallback %q: %w", u.Redacted(), err))
+			continue
+		}
+
+		if d, ok := sink.(diagnosable); ok {
+			d.setDiagnostics(l.diag)
+		}
+
+		sinks = append(sinks, sink)
+		l.closers = append(l.closers, sink)
+	}
+
+	return sinks, errs
+}
+
+// withFallback оборачивает writer запасными назначениями и уведомлением
+// об ошибках, если они заданы.
+func (l *Logger) withFallback(writer zapcore.WriteSyncer, fallbacks []Sink) zapcore.WriteSyncer {
+	if len(fallbacks) == 0 && l.onWriteError == nil {
+		return writer
+	}
+
+	return &fallbackWriter{primary: writer, fallbacks: fallbacks, onError: l.onWriteError}
+}
+
+// fallbackWriter пишет в primary, а при ошибке — в первое принявшее
+// запись запасное назначение.
+type fallbackWriter struct {
+	primary   zapcore.WriteSyncer
+	fallbacks []Sink
+	onError   func(err error)
+}
+
+func (w *fallbackWriter) Write(p []byte) (int, error) {
+	n, err := w.primary.Write(p)
+	if err == nil {
+		return n, nil
+	}
+
+	if w.onError != nil {
+		w.onError(err)
+	}
+
+	errs := []error{err}
+	for _, fallback := range w.fallbacks {
+		if _, fallbackErr := fallback.Write(p); fallbackErr != nil {
+			errs = append(errs, fallbackErr)
+			continue
+		}
+
+		return len(p), nil
+	}
+
+	return n, errors.Join(errs...)
+}
+
+func (w *fallbackWriter) Sync() error {
+	errs := []error{w.primary.Sync()}
+	for _, fallback := range w.fallbacks {
+		errs = append(errs, fallback.Sync())
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,84 @@
+package logger
+
+import (
+	"io/fs"
+	"net/url"
+	"path/filepath"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fullFS — memFS, запись в файлы которой завершается ENOSPC после
+// установки full.
+type fullFS struct {
+	*memFS
+	full atomic.Bool
+}
+
+type fullFile struct {
+	File
+	fs *fullFS
+}
+
+func (f *fullFS) OpenFile(name string, flag int, perm fs.FileMode) (File, error) {
+	file, err := f.memFS.OpenFile(name, flag, perm)
+	if err != nil {
+		return nil, err
+	}
+
+	return fullFile{File: file, fs: f}, nil
+}
+
+func (f fullFile) Write(p []byte) (int, error) {
+	if f.fs.full.Load() {
+		return 0, syscall.ENOSPC
+	}
+
+	return f.File.Write(p)
+}
+
+func TestFallback(t *testing.T) {
+	fsys := &fullFS{memFS: newMemFS()}
+
+	fallback := &memorySink{}
+	require.NoError(t, RegisterSink("fallback-test", func(*url.URL) (Sink, error) { return fallback, nil }))
+
+	var writeErrs atomic.Int32
+
+	logger := NewLogger(Path("logs"), WithFS(fsys), Structured(true), Compress(false),
+		Fallback("fallback-test://"),
+		OnWriteError(func(err error) {
+			assert.ErrorIs(t, err, syscall.ENOSPC)
+			writeErrs.Add(1)
+		}),
+	)
+	require.NoError(t, logger.Init(false))
+
+	logger.Info("primary")
+	fsys.full.Store(true)
+	logger.Info("fallback")
+	fsys.full.Store(false)
+	logger.Info("recovered")
+	require.NoError(t, logger.Close())
+
+	today := time.Now().Format(dateLayout)
+	primary := fsys.files[filepath.Join("logs", today+".log")].String()
+	assert.Contains(t, primary, "primary")
+	assert.Contains(t, primary, "recovered")
+	assert.NotContains(t, primary, `"fallback"`)
+
+	assert.Contains(t, fallback.String(), `"fallback"`)
+	assert.NotContains(t, fallback.String(), "recovered")
+
+	assert.EqualValues(t, 1, writeErrs.Load())
+}
+
+func TestFallbackInvalid(t *testing.T) {
+	_, err := Build(Path(t.TempDir()), Fallback("loki+http://host?level=loud"))
+	assert.ErrorContains(t, err, "unknown level")
+}
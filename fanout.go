@@ -0,0 +1,277 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// ParallelOutputs включает параллельную отправку записей в Outputs: запись
+// кодируется один раз для каждого формата, а назначения получают её через
+// собственные очереди по queueSize записей и пишут в своих горутинах.
+// Медленное назначение задерживает вызов логгера, только когда его
+// очередь заполнена. Ошибки записи сообщаются в Diagnostics.
+func ParallelOutputs(queueSize int) Option {
+	return func(l *Logger) {
+		l.parallelQueue = queueSize
+	}
+}
+
+// newFanoutCores открывает назначения и создаёт общие для них ядра:
+// отдельно для назначений с уровнем логгера и с собственным уровнем.
+func (l *Logger) newFanoutCores(encoderCfg zapcore.EncoderConfig, lvl zapcore.LevelEnabler) ([]zapcore.Core, []error) {
+	var errs []error
+
+	shared, own := &fanoutCore{}, &fanoutCore{}
+	formats := make(map[*fanoutCore]map[string]*fanoutFormat)
+
+	for _, raw := range l.outputs {
+		o, err := l.openOutput(raw, encoderCfg, lvl)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		core := shared
+		if o.own {
+			core = own
+		}
+
+		if formats[core] == nil {
+			formats[core] = make(map[string]*fanoutFormat)
+		}
+
+		format, exist := formats[core][o.format]
+		if !exist {
+			newEncoder, _ := l.encoderFactory(o.format)
+			format = &fanoutFormat{encoder: newEncoder(encoderCfg)}
+			formats[core][o.format] = format
+			core.formats = append(core.formats, format)
+		}
+
+		target := newAsyncSink(o, l.parallelQueue, l.diag)
+		format.targets = append(format.targets, target)
+		core.targets = append(core.targets, target)
+	}
+
+	var cores []zapcore.Core
+
+	if len(shared.targets) > 0 {
+		l.closers = append(l.closers, shared)
+		cores = append(cores, shared)
+	}
+
+	if len(own.targets) > 0 {
+		l.closers = append(l.closers, own)
+		cores = append(cores, ownLevelCore{Core: own})
+	}
+
+	return cores, errs
+}
+
+// fanoutCore кодирует запись один раз для каждого формата и передаёт
+// результат в очереди назначений этого формата.
+type fanoutCore struct {
+	formats []*fanoutFormat
+	targets []*asyncSink
+}
+
+type fanoutFormat struct {
+	encoder zapcore.Encoder
+	targets []*asyncSink
+}
+
+func (c *fanoutCore) Enabled(level zapcore.Level) bool {
+	for _, target := range c.targets {
+		if target.level.Enabled(level) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c *fanoutCore) With(fields []zapcore.Field) zapcore.Core {
+	clone := &fanoutCore{targets: c.targets}
+
+	for _, format := range c.formats {
+		encoder := format.encoder.Clone()
+		for _, field := range fields {
+			field.AddTo(encoder)
+		}
+
+		clone.formats = append(clone.formats, &fanoutFormat{encoder: encoder, targets: format.targets})
+	}
+
+	return clone
+}
+
+func (c *fanoutCore) Check(entry zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return ce.AddCore(entry, c)
+	}
+
+	return ce
+}
+
+func (c *fanoutCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	var errs []error
+
+	for _, format := range c.formats {
+		var data []byte
+
+		for _, target := range format.targets {
+			if !target.level.Enabled(entry.Level) {
+				continue
+			}
+
+			if data == nil {
+				buf, err := format.encoder.EncodeEntry(entry, fields)
+				if err != nil {
+					errs = append(errs, err)
+					break
+				}
+
+				// Назначения только читают данные, поэтому копия общая.
+				data = append([]byte(nil), buf.Bytes()...)
+				buf.Free()
+			}
+
+			target.enqueue(asyncItem{data: data})
+		}
+	}
+
+	// Как ioCore в zap: перед паникой или завершением процесса запись
+	// должна дойти до назначений.
+	if entry.Level > zapcore.ErrorLevel {
+		errs = append(errs, c.Sync())
+	}
+
+	return errors.Join(errs...)
+}
+
+// Sync дожидается записи поставленных в очереди данных и сбрасывает
+// назначения.
+func (c *fanoutCore) Sync() error {
+	var errs []error
+
+	for _, target := range c.targets {
+		if err := target.sync(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Close дописывает очереди, останавливает горутины и закрывает
+// назначения.
+func (c *fanoutCore) Close() error {
+	return c.closeContext(context.Background())
+}
+
+func (c *fanoutCore) closeContext(ctx context.Context) error {
+	var errs []error
+
+	for _, target := range c.targets {
+		if err := target.close(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+type asyncItem struct {
+	data   []byte
+	synced chan error
+}
+
+// asyncSink пишет данные из очереди в назначение в отдельной горутине.
+type asyncSink struct {
+	sink  Sink
+	level zapcore.LevelEnabler
+	queue chan asyncItem
+	done  chan struct{}
+	diag  *zap.Logger
+
+	closed bool
+	mu     sync.RWMutex
+}
+
+func newAsyncSink(o outputTarget, queueSize int, diag *zap.Logger) *asyncSink {
+	s := &asyncSink{
+		sink:  o.sink,
+		level: o.level,
+		queue: make(chan asyncItem, queueSize),
+		done:  make(chan struct{}),
+		diag:  diag,
+	}
+
+	goLabeled("output", s.run)
+
+	return s
+}
+
+func (s *asyncSink) run() {
+	defer close(s.done)
+
+	for item := range s.queue {
+		if item.synced != nil {
+			item.synced <- s.sink.Sync()
+			continue
+		}
+
+		if _, err := s.sink.Write(item.data); err != nil {
+			diagLogger(s.diag).Warn("output write failed", zap.Error(err))
+		}
+	}
+}
+
+// enqueue ставит данные в очередь. После close данные отбрасываются.
+func (s *asyncSink) enqueue(item asyncItem) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return false
+	}
+
+	s.queue <- item
+
+	return true
+}
+
+func (s *asyncSink) sync() error {
+	synced := make(chan error, 1)
+	if !s.enqueue(asyncItem{synced: synced}) {
+		return nil
+	}
+
+	return <-synced
+}
+
+func (s *asyncSink) close(ctx context.Context) error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return nil
+	}
+	s.closed = true
+	close(s.queue)
+	s.mu.Unlock()
+
+	select {
+	case <-s.done:
+	case <-ctx.Done():
+		// Зависшее назначение закрывается, чтобы прервать его запись.
+		_ = closeContext(ctx, s.sink)
+		return fmt.Errorf("logger: closing output: %w", ctx.Err())
+	}
+
+	return closeContext(ctx, s.sink)
+}
@@ -0,0 +1,100 @@
+package logger
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestParallelOutputs проверяет доставку записей во все назначения с их
+// уровнями и форматами.
+func TestParallelOutputs(t *testing.T) {
+	sinks := map[string]*memorySink{}
+	err := RegisterSink("test-fanout", func(u *url.URL) (Sink, error) {
+		sink := &memorySink{}
+		sinks[u.Host] = sink
+		return sink, nil
+	})
+	require.NoError(t, err)
+
+	logger := NewLogger(
+		Path(t.TempDir()),
+		ParallelOutputs(16),
+		Outputs("test-fanout://a?format=json", "test-fanout://b?format=json&level=warn", "test-fanout://c?format=console"),
+	)
+	require.NoError(t, logger.Init(false))
+
+	child := logger.WithFields(map[string]interface{}{"request": "r1"})
+	child.Info("info message")
+	child.Warn("warn message")
+	require.NoError(t, logger.Close())
+
+	assert.Equal(t, 2, strings.Count(sinks["a"].String(), `"request":"r1"`))
+	assert.NotContains(t, sinks["b"].String(), "info message")
+	assert.Contains(t, sinks["b"].String(), `"message":"warn message"`)
+	assert.Contains(t, sinks["c"].String(), "info message")
+	assert.NotContains(t, sinks["c"].String(), `"message"`)
+
+	for name, sink := range sinks {
+		assert.True(t, sink.closed, name)
+	}
+
+	assert.NotPanics(t, func() { child.Info("after close") })
+}
+
+// TestParallelOutputsSyncOnPanic проверяет, что записи уровня выше error
+// доходят до назначений до возврата из вызова логгера.
+func TestParallelOutputsSyncOnPanic(t *testing.T) {
+	sink := &memorySink{}
+	require.NoError(t, RegisterSink("test-fanout-panic", func(*url.URL) (Sink, error) {
+		return sink, nil
+	}))
+
+	logger := NewLogger(Path(t.TempDir()), ParallelOutputs(16), Outputs("test-fanout-panic://a?format=json"))
+	require.NoError(t, logger.Init(false))
+	defer logger.Close()
+
+	logger.baseLogger.DPanic("fatal condition")
+	assert.Contains(t, sink.String(), "fatal condition")
+}
+
+// stuckSink не завершает запись до закрытия.
+type stuckSink struct {
+	closed chan struct{}
+}
+
+func (s *stuckSink) Write(p []byte) (int, error) {
+	<-s.closed
+	return len(p), nil
+}
+
+func (s *stuckSink) Sync() error {
+	return nil
+}
+
+func (s *stuckSink) Close() error {
+	close(s.closed)
+	return nil
+}
+
+// TestParallelOutputsCloseContext проверяет, что CloseContext не ждёт
+// зависшее назначение дольше ctx.
+func TestParallelOutputsCloseContext(t *testing.T) {
+	require.NoError(t, RegisterSink("test-fanout-stuck", func(*url.URL) (Sink, error) {
+		return &stuckSink{closed: make(chan struct{})}, nil
+	}))
+
+	logger := NewLogger(Path(t.TempDir()), ParallelOutputs(16), Outputs("test-fanout-stuck://a"))
+	require.NoError(t, logger.Init(false))
+	logger.Info("stuck")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	assert.ErrorIs(t, logger.CloseContext(ctx), context.DeadlineExceeded)
+}
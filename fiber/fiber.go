@@ -0,0 +1,241 @@
+// Package loggerfiber подключает логгер github.com/restfront/logger к
+// Fiber: запись запросов и внутренний логгер Fiber (пакет fiber/log).
+package loggerfiber
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync/atomic"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+
+	"github.com/restfront/logger"
+)
+
+// ContextKey — ключ логгера запроса в c.Locals.
+const ContextKey = "logger"
+
+// FiberMiddleware записывает каждый запрос в формате
+// logger.HTTPMiddleware и добавляет логгер запроса с полем request_id в
+// c.UserContext() (logger.FromContext) и в c.Locals под ключом
+// ContextKey. Ошибка обработчика передаётся ErrorHandler приложения,
+// чтобы запись содержала итоговый статус, и попадает в поле error.
+func FiberMiddleware(l *logger.Logger) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+
+		id := c.Get(logger.DefaultRequestIDHeader)
+		if !logger.ValidRequestID(id) {
+			id = logger.NewRequestID()
+		}
+		c.Set(logger.DefaultRequestIDHeader, id)
+
+		ctx, reqLogger := l.RequestContext(c.UserContext(), id)
+		c.SetUserContext(ctx)
+		c.Locals(ContextKey, reqLogger)
+
+		err := c.Next()
+		if err != nil {
+			if handlerErr := c.App().ErrorHandler(c, err); handlerErr != nil {
+				_ = c.SendStatus(fiber.StatusInternalServerError)
+			}
+		}
+
+		reqLogger.LogRequest(logger.RequestLog{
+			Method:     c.Method(),
+			Path:       c.Path(),
+			Status:     c.Response().StatusCode(),
+			Latency:    time.Since(start),
+			Bytes:      int64(len(c.Response().Body())),
+			RemoteAddr: c.Context().RemoteAddr().String(),
+			Err:        err,
+		})
+
+		// Ошибка уже обработана ErrorHandler.
+		return nil
+	}
+}
+
+// SetFiberLogger делает логгер внутренним логгером Fiber (log.SetLogger),
+// чтобы сообщения фреймворка и вызовы log.Info и т.п. попадали в те же
+// файлы.
+func SetFiberLogger(l *logger.Logger) {
+	// Функции пакета fiber/log добавляют к адаптеру ещё один кадр стека.
+	log.SetLogger(newFiberLogger(l, 2, new(atomic.Int32)))
+}
+
+// FiberLogger реализует log.AllLogger поверх *logger.Logger. Trace
+// пишется как debug. Уровень SetLevel дополнительно отсекает записи
+// адаптера, не меняя уровень логгера; SetOutput не действует.
+type FiberLogger struct {
+	base   *logger.Logger
+	logger *logger.Logger
+	level  *atomic.Int32
+}
+
+var _ log.AllLogger = (*FiberLogger)(nil)
+
+// NewFiberLogger создаёт адаптер log.AllLogger.
+func NewFiberLogger(l *logger.Logger) *FiberLogger {
+	return newFiberLogger(l, 1, new(atomic.Int32))
+}
+
+func newFiberLogger(l *logger.Logger, callerSkip int, level *atomic.Int32) *FiberLogger {
+	return &FiberLogger{base: l, logger: l.WithCallerSkip(callerSkip), level: level}
+}
+
+func (a *FiberLogger) SetLevel(level log.Level) {
+	a.level.Store(int32(level))
+}
+
+func (a *FiberLogger) SetOutput(io.Writer) {}
+
+// WithContext возвращает адаптер над логгером запроса из ctx, если он
+// есть, с вызовом напрямую, а не через функции пакета fiber/log.
+func (a *FiberLogger) WithContext(ctx context.Context) log.CommonLogger {
+	l, ok := logger.FromContext(ctx)
+	if !ok {
+		l = a.base
+	}
+
+	return newFiberLogger(l, 1, a.level)
+}
+
+func (a *FiberLogger) enabled(level log.Level) bool {
+	return level >= log.Level(a.level.Load())
+}
+
+// with добавляет к логгеру пары ключ-значение.
+func (a *FiberLogger) with(keysAndValues []interface{}) *logger.Logger {
+	if len(keysAndValues) == 0 {
+		return a.logger
+	}
+
+	fields := make(map[string]interface{}, (len(keysAndValues)+1)/2)
+	for i := 0; i < len(keysAndValues); i += 2 {
+		if i+1 == len(keysAndValues) {
+			fields["!BADKEY"] = keysAndValues[i]
+			break
+		}
+		fields[fmt.Sprint(keysAndValues[i])] = keysAndValues[i+1]
+	}
+
+	return a.logger.WithFields(fields)
+}
+
+func (a *FiberLogger) Trace(v ...interface{}) {
+	if a.enabled(log.LevelTrace) {
+		a.logger.Debug(v...)
+	}
+}
+
+func (a *FiberLogger) Debug(v ...interface{}) {
+	if a.enabled(log.LevelDebug) {
+		a.logger.Debug(v...)
+	}
+}
+
+func (a *FiberLogger) Info(v ...interface{}) {
+	if a.enabled(log.LevelInfo) {
+		a.logger.Info(v...)
+	}
+}
+
+func (a *FiberLogger) Warn(v ...interface{}) {
+	if a.enabled(log.LevelWarn) {
+		a.logger.Warn(v...)
+	}
+}
+
+func (a *FiberLogger) Error(v ...interface{}) {
+	if a.enabled(log.LevelError) {
+		a.logger.Error(v...)
+	}
+}
+
+func (a *FiberLogger) Fatal(v ...interface{}) {
+	a.logger.Fatal(v...)
+}
+
+func (a *FiberLogger) Panic(v ...interface{}) {
+	a.logger.Panic(v...)
+}
+
+func (a *FiberLogger) Tracef(format string, v ...interface{}) {
+	if a.enabled(log.LevelTrace) {
+		a.logger.Debugf(format, v...)
+	}
+}
+
+func (a *FiberLogger) Debugf(format string, v ...interface{}) {
+	if a.enabled(log.LevelDebug) {
+		a.logger.Debugf(format, v...)
+	}
+}
+
+func (a *FiberLogger) Infof(format string, v ...interface{}) {
+	if a.enabled(log.LevelInfo) {
+		a.logger.Infof(format, v...)
+	}
+}
+
+func (a *FiberLogger) Warnf(format string, v ...interface{}) {
+	if a.enabled(log.LevelWarn) {
+		a.logger.Warnf(format, v...)
+	}
+}
+
+func (a *FiberLogger) Errorf(format string, v ...interface{}) {
+	if a.enabled(log.LevelError) {
+		a.logger.Errorf(format, v...)
+	}
+}
+
+func (a *FiberLogger) Fatalf(format string, v ...interface{}) {
+	a.logger.Fatalf(format, v...)
+}
+
+func (a *FiberLogger) Panicf(format string, v ...interface{}) {
+	a.logger.Panicf(format, v...)
+}
+
+func (a *FiberLogger) Tracew(msg string, keysAndValues ...interface{}) {
+	if a.enabled(log.LevelTrace) {
+		a.with(keysAndValues).Debug(msg)
+	}
+}
+
+func (a *FiberLogger) Debugw(msg string, keysAndValues ...interface{}) {
+	if a.enabled(log.LevelDebug) {
+		a.with(keysAndValues).Debug(msg)
+	}
+}
+
+func (a *FiberLogger) Infow(msg string, keysAndValues ...interface{}) {
+	if a.enabled(log.LevelInfo) {
+		a.with(keysAndValues).Info(msg)
+	}
+}
+
+func (a *FiberLogger) Warnw(msg string, keysAndValues ...interface{}) {
+	if a.enabled(log.LevelWarn) {
+		a.with(keysAndValues).Warn(msg)
+	}
+}
+
+func (a *FiberLogger) Errorw(msg string, keysAndValues ...interface{}) {
+	if a.enabled(log.LevelError) {
+		a.with(keysAndValues).Error(msg)
+	}
+}
+
+func (a *FiberLogger) Fatalw(msg string, keysAndValues ...interface{}) {
+	a.with(keysAndValues).Fatal(msg)
+}
+
+func (a *FiberLogger) Panicw(msg string, keysAndValues ...interface{}) {
+	a.with(keysAndValues).Panic(msg)
+}
@@ -0,0 +1,97 @@
+package loggerfiber
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strconv"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/restfront/logger"
+)
+
+func newObservedLogger() (*logger.Logger, *observer.ObservedLogs) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	return logger.NewLogger(logger.BaseLogger(zap.New(core, zap.AddCaller(), zap.AddCallerSkip(1)))), logs
+}
+
+// TestFiberMiddleware проверяет запись запросов и ошибок обработчиков.
+func TestFiberMiddleware(t *testing.T) {
+	l, logs := newObservedLogger()
+
+	app := fiber.New()
+	app.Use(FiberMiddleware(l))
+	app.Get("/items", func(c *fiber.Ctx) error {
+		reqLogger, ok := logger.FromContext(c.UserContext())
+		require.True(t, ok)
+		assert.Same(t, reqLogger, c.Locals(ContextKey))
+
+		reqLogger.Info("listing items")
+		return c.SendString("items")
+	})
+	app.Get("/fail", func(c *fiber.Ctx) error {
+		return errors.New("database down")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/items", nil)
+	req.Header.Set(logger.DefaultRequestIDHeader, "req-9")
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	assert.Equal(t, "req-9", resp.Header.Get(logger.DefaultRequestIDHeader))
+
+	entries := logs.TakeAll()
+	require.Len(t, entries, 2)
+	assert.Equal(t, "req-9", entries[0].ContextMap()["request_id"])
+	assert.Equal(t, int64(http.StatusOK), entries[1].ContextMap()["status"])
+	assert.Equal(t, int64(len("items")), entries[1].ContextMap()["bytes"])
+	assert.Equal(t, "/items", entries[1].ContextMap()["path"])
+
+	resp, err = app.Test(httptest.NewRequest(http.MethodGet, "/fail", nil))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+
+	entries = logs.TakeAll()
+	require.Len(t, entries, 1)
+	assert.Equal(t, zapcore.ErrorLevel, entries[0].Level)
+	assert.Equal(t, "database down", entries[0].ContextMap()["error"])
+}
+
+// TestFiberLogger проверяет адаптер log.AllLogger.
+func TestFiberLogger(t *testing.T) {
+	l, logs := newObservedLogger()
+
+	SetFiberLogger(l)
+	defer log.SetLogger(log.DefaultLogger())
+
+	_, _, line, _ := runtime.Caller(0)
+	log.Infow("started", "port", 3000, "tls")
+	log.Trace("trace")
+	log.SetLevel(log.LevelWarn)
+	log.Info("filtered")
+
+	ctx, _ := l.RequestContext(context.Background(), "req-3")
+	log.WithContext(ctx).Warnf("slow %s", "query")
+
+	entries := logs.TakeAll()
+	require.Len(t, entries, 3)
+
+	assert.Equal(t, "started", entries[0].Message)
+	assert.Equal(t, "fiber/fiber_test.go:"+strconv.Itoa(line+1), entries[0].Caller.TrimmedPath())
+	assert.Equal(t, int64(3000), entries[0].ContextMap()["port"])
+	assert.Equal(t, "tls", entries[0].ContextMap()["!BADKEY"])
+	assert.Equal(t, zapcore.DebugLevel, entries[1].Level)
+
+	assert.Equal(t, "slow query", entries[2].Message)
+	assert.Equal(t, "req-3", entries[2].ContextMap()["request_id"])
+	assert.Equal(t, "fiber/fiber_test.go:"+strconv.Itoa(line+7), entries[2].Caller.TrimmedPath())
+}
@@ -0,0 +1,75 @@
+package logger
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Field — типизированное поле записи. Методы с суффиксом W принимают
+// поля без рефлексии и промежуточных interface{}, что важно в горячих
+// участках кода.
+type Field = zap.Field
+
+func String(key, value string) Field {
+	return zap.String(key, value)
+}
+
+func Strings(key string, values []string) Field {
+	return zap.Strings(key, values)
+}
+
+func Int(key string, value int) Field {
+	return zap.Int(key, value)
+}
+
+func Int64(key string, value int64) Field {
+	return zap.Int64(key, value)
+}
+
+func Uint64(key string, value uint64) Field {
+	return zap.Uint64(key, value)
+}
+
+func Float64(key string, value float64) Field {
+	return zap.Float64(key, value)
+}
+
+func Bool(key string, value bool) Field {
+	return zap.Bool(key, value)
+}
+
+func Duration(key string, value time.Duration) Field {
+	return zap.Duration(key, value)
+}
+
+func Time(key string, value time.Time) Field {
+	return zap.Time(key, value)
+}
+
+// Err добавляет ошибку в поле "error".
+func Err(err error) Field {
+	return zap.Error(err)
+}
+
+// Any выбирает тип поля по значению; для известных типов быстрее
+// использовать конкретные конструкторы.
+func Any(key string, value interface{}) Field {
+	return zap.Any(key, value)
+}
+
+func (l *Logger) DebugW(msg string, fields ...Field) {
+	l.baseLogger.Debug(msg, fields...)
+}
+
+func (l *Logger) InfoW(msg string, fields ...Field) {
+	l.baseLogger.Info(msg, fields...)
+}
+
+func (l *Logger) WarnW(msg string, fields ...Field) {
+	l.baseLogger.Warn(msg, fields...)
+}
+
+func (l *Logger) ErrorW(msg string, fields ...Field) {
+	l.baseLogger.Error(msg, fields...)
+}
@@ -0,0 +1,47 @@
+package logger
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// TestTypedFields проверяет запись типизированных полей.
+func TestTypedFields(t *testing.T) {
+	core, logs := observer.New(zap.DebugLevel)
+
+	logger := NewLogger()
+	logger.baseLogger = zap.New(core, zap.AddCaller(), zap.AddCallerSkip(1))
+
+	logger.DebugW("debug")
+	logger.InfoW("order paid",
+		String("order_id", "o1"),
+		Int("items", 3),
+		Float64("amount", 9.5),
+		Bool("gift", true),
+		Duration("took", time.Second),
+		Err(errors.New("retry")),
+	)
+	logger.WarnW("warn")
+	logger.ErrorW("error")
+
+	entries := logs.All()
+	if assert.Len(t, entries, 4) {
+		assert.Equal(t, zapcore.InfoLevel, entries[1].Level)
+		assert.Equal(t, map[string]interface{}{
+			"order_id": "o1",
+			"items":    int64(3),
+			"amount":   9.5,
+			"gift":     true,
+			"took":     time.Second,
+			"error":    "retry",
+		}, entries[1].ContextMap())
+		assert.Contains(t, entries[1].Caller.File, "fields_test.go")
+		assert.Equal(t, zapcore.ErrorLevel, entries[3].Level)
+	}
+}
@@ -0,0 +1,335 @@
+package logger
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const defaultFilenamePattern = "{date}.log"
+
+var defaultPattern, _ = newFilenamePattern(defaultFilenamePattern, "")
+
+// filenameTokenPattern находит подстановки шаблона имени, в том числе
+// {date:LAYOUT} с собственным форматом даты.
+var filenameTokenPattern = regexp.MustCompile(`\{[a-z_]+(?::[^{}]*)?\}`)
+
+// layoutElements — элементы формата времени Go, допустимые в {date:LAYOUT},
+// и выражения для их разбора.
+var layoutElements = []struct {
+	element string
+	expr    string
+}{
+	{"2006", `\d{4}`},
+	{"01", `\d{2}`},
+	{"02", `\d{2}`},
+	{"15", `\d{2}`},
+	{"04", `\d{2}`},
+	{"05", `\d{2}`},
+}
+
+var filenameUnsafe = strings.NewReplacer("/", "_", "\\", "_", ":", "_", "*", "_", "?", "_", "\"", "_", "<", "_", ">", "_", "|", "_")
+
+// filenamePattern строит имена файлов логов по шаблону вида
+// "{app}-{host}-{date}.log" и разбирает их обратно, чтобы находить файлы
+// прошлых дней при сжатии. Номер сегмента при ротации по размеру
+// вставляется перед расширением: 2024_05_01.1.log.
+type filenamePattern struct {
+	parts      []patternPart
+	ext        string
+	re         *regexp.Regexp
+	dateLayout string
+	// pid — подстановка {pid} этого процесса; файлы с другим pid пишут
+	// другие запуски.
+	pid string
+	// customLayout — формат из {date:LAYOUT}; он не меняется интервалом
+	// ротации.
+	customLayout string
+}
+
+type patternPart struct {
+	literal string
+	date    bool
+}
+
+func newFilenamePattern(template, app string) (*filenamePattern, error) {
+	if template == "" {
+		template = defaultFilenamePattern
+	}
+
+	if strings.ContainsAny(template, `/\`) {
+		return nil, fmt.Errorf("logger: filename pattern %q must not contain path separators", template)
+	}
+
+	ext := filepath.Ext(template)
+	if strings.Contains(ext, "}") {
+		ext = ""
+	}
+	base := strings.TrimSuffix(template, ext)
+
+	tokens := filenameTokenPattern.FindAllStringIndex(base, -1)
+
+	p := &filenamePattern{ext: ext}
+	expr := strings.Builder{}
+	expr.WriteString("^")
+	hasDate := false
+	last := 0
+
+	addLiteral := func(literal string) {
+		p.parts = append(p.parts, patternPart{literal: literal})
+		expr.WriteString(regexp.QuoteMeta(literal))
+	}
+
+	for _, loc := range tokens {
+		addLiteral(base[last:loc[0]])
+		last = loc[1]
+
+		token := base[loc[0]:loc[1]]
+		if layout, ok := strings.CutPrefix(token, "{date:"); ok {
+			layout = strings.TrimSuffix(layout, "}")
+			layoutExpr, err := layoutPattern(layout)
+			if err != nil {
+				return nil, fmt.Errorf("logger: %w in filename pattern %q", err, template)
+			}
+
+			hasDate = true
+			p.customLayout = layout
+			p.parts = append(p.parts, patternPart{date: true})
+			expr.WriteString("(?P<date>" + layoutExpr + ")")
+			continue
+		}
+
+		switch token {
+		case "{date}":
+			hasDate = true
+			p.parts = append(p.parts, patternPart{date: true})
+			expr.WriteString(`(?P<date>\d{4}_\d{2}_\d{2}(?:_\d{2}(?:\d{2})?)?)`)
+		case "{app}":
+			addLiteral(filenameUnsafe.Replace(appName(app)))
+		case "{pid}":
+			// Файлы прошлых запусков с другим pid тоже относятся к шаблону,
+			// но трогает их только очистка (parseFile).
+			p.pid = strconv.Itoa(os.Getpid())
+			p.parts = append(p.parts, patternPart{literal: p.pid})
+			expr.WriteString(`(?P<pid>\d+)`)
+		case "{host}", "{hostname}":
+			host, err := os.Hostname()
+			if err != nil {
+				return nil, fmt.Errorf("logger: cannot resolve {host} in filename pattern: %w", err)
+			}
+			addLiteral(filenameUnsafe.Replace(host))
+		default:
+			return nil, fmt.Errorf("logger: unknown token %s in filename pattern %q", token, template)
+		}
+	}
+
+	addLiteral(base[last:])
+	expr.WriteString(`(?:\.(?P<segment>\d+))?`)
+	expr.WriteString(regexp.QuoteMeta(ext))
+	expr.WriteString("$")
+
+	if !hasDate {
+		return nil, fmt.Errorf("logger: filename pattern %q must contain {date}", template)
+	}
+
+	re, err := regexp.Compile(expr.String())
+	if err != nil {
+		return nil, err
+	}
+
+	p.re = re
+
+	return p, nil
+}
+
+// layoutPattern проверяет формат {date:LAYOUT} и возвращает выражение для
+// разбора дат в этом формате. Кроме элементов года, месяца, дня, часа,
+// минуты и секунды допускаются только разделители "-", "_", "." и "T".
+func layoutPattern(layout string) (string, error) {
+	var expr strings.Builder
+
+	for rest := layout; rest != ""; {
+		matched := false
+		for _, e := range layoutElements {
+			if strings.HasPrefix(rest, e.element) {
+				expr.WriteString(e.expr)
+				rest = rest[len(e.element):]
+				matched = true
+				break
+			}
+		}
+		if matched {
+			continue
+		}
+
+		if !strings.ContainsRune("-_.T", rune(rest[0])) {
+			return "", fmt.Errorf("unsupported date layout %q", layout)
+		}
+
+		expr.WriteString(regexp.QuoteMeta(rest[:1]))
+		rest = rest[1:]
+	}
+
+	if !strings.Contains(layout, "2006") || !strings.Contains(layout, "01") || !strings.Contains(layout, "02") {
+		return "", fmt.Errorf("date layout %q must contain year, month and day", layout)
+	}
+
+	return expr.String(), nil
+}
+
+// withLayout возвращает копию шаблона, подставляющую {date} в формате
+// layout, например с часом при почасовой ротации. Формат из {date:LAYOUT}
+// не меняется.
+func (p *filenamePattern) withLayout(layout string) *filenamePattern {
+	c := *p
+	c.dateLayout = layout
+
+	return &c
+}
+
+// validateInterval проверяет, что формат {date:LAYOUT} различает файлы
+// соседних периодов при ротации чаще раза в сутки.
+func (p *filenamePattern) validateInterval(interval time.Duration) error {
+	if p.customLayout == "" || interval <= 0 {
+		return nil
+	}
+
+	if (interval < 24*time.Hour && !strings.Contains(p.customLayout, "15")) ||
+		(interval < time.Hour && !strings.Contains(p.customLayout, "04")) {
+		return fmt.Errorf("logger: date layout %q is too coarse for rotation interval %s", p.customLayout, interval)
+	}
+
+	return nil
+}
+
+// layout возвращает формат {date}.
+func (p *filenamePattern) layout() string {
+	if p.customLayout != "" {
+		return p.customLayout
+	}
+
+	if p.dateLayout == "" {
+		return dateLayout
+	}
+
+	return p.dateLayout
+}
+
+func (p *filenamePattern) format(date time.Time) string {
+	return p.formatSegment(date, 0)
+}
+
+func (p *filenamePattern) formatSegment(date time.Time, segment int) string {
+	name := strings.Builder{}
+	for _, part := range p.parts {
+		if part.date {
+			name.WriteString(date.Format(p.layout()))
+		} else {
+			name.WriteString(part.literal)
+		}
+	}
+
+	if segment > 0 {
+		name.WriteString("." + strconv.Itoa(segment))
+	}
+
+	name.WriteString(p.ext)
+
+	return name.String()
+}
+
+// parse возвращает дату файла, если имя соответствует шаблону.
+func (p *filenamePattern) parse(name string) (time.Time, bool) {
+	date, _, ok := p.parseSegment(name)
+
+	return date, ok
+}
+
+// parseSegment возвращает дату и номер сегмента файла этого процесса,
+// если имя соответствует шаблону. Файлы с {pid} других процессов не
+// подходят: их нельзя дописывать, сжимать или загружать.
+func (p *filenamePattern) parseSegment(name string) (time.Time, int, bool) {
+	date, segment, own, ok := p.parseFile(name)
+
+	return date, segment, ok && own
+}
+
+// parseFile разбирает имя файла любого процесса; own сообщает, что файл
+// записан этим процессом (для шаблона без {pid} — всегда).
+func (p *filenamePattern) parseFile(name string) (date time.Time, segment int, own, ok bool) {
+	match := p.re.FindStringSubmatch(name)
+	if match == nil {
+		return time.Time{}, 0, false, false
+	}
+
+	own = true
+	for i, group := range p.re.SubexpNames() {
+		if group == "pid" && match[i] != p.pid {
+			own = false
+		}
+	}
+
+	value := match[p.re.SubexpIndex("date")]
+
+	// Разбираются все форматы {date}, чтобы файлы, записанные до смены
+	// интервала ротации, тоже находились при сжатии и очистке.
+	layout := dateLayout
+	switch {
+	case p.customLayout != "":
+		layout = p.customLayout
+	case len(value) == len(hourLayout):
+		layout = hourLayout
+	case len(value) == len(minuteLayout):
+		layout = minuteLayout
+	}
+
+	date, err := time.ParseInLocation(layout, value, time.Local)
+	if err != nil {
+		return time.Time{}, 0, false, false
+	}
+
+	if value := match[p.re.SubexpIndex("segment")]; value != "" {
+		segment, err = strconv.Atoi(value)
+		if err != nil {
+			return time.Time{}, 0, false, false
+		}
+	}
+
+	return date, segment, own, true
+}
+
+// errorFilename возвращает шаблон файла ошибок для SplitByLevel:
+// "{date}.log" превращается в "{date}.error.log".
+func errorFilename(template string) string {
+	if template == "" {
+		template = defaultFilenamePattern
+	}
+
+	ext := filepath.Ext(template)
+	if strings.Contains(ext, "}") {
+		ext = ""
+	}
+
+	return strings.TrimSuffix(template, ext) + ".error" + ext
+}
+
+// appName возвращает имя приложения для {app}: заданное явно или имя
+// исполняемого файла без расширения.
+func appName(name string) string {
+	if name != "" {
+		return name
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		return "app"
+	}
+
+	base := filepath.Base(exe)
+
+	return strings.TrimSuffix(base, filepath.Ext(base))
+}
@@ -0,0 +1,157 @@
+package logger
+
+import (
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestFilenamePattern проверяет построение и разбор имён файлов по шаблону.
+func TestFilenamePattern(t *testing.T) {
+	host, err := os.Hostname()
+	require.NoError(t, err)
+
+	date := time.Date(2024, 5, 28, 0, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name     string
+		template string
+		expected string
+		wantErr  bool
+	}{
+		{
+			name:     "Default pattern",
+			template: "",
+			expected: "2024_05_28.log",
+		},
+		{
+			name:     "App and host",
+			template: "{app}-{host}-{date}.log",
+			expected: "billing-" + filenameUnsafe.Replace(host) + "-2024_05_28.log",
+		},
+		{
+			name:     "Hostname and pid",
+			template: "{app}-{hostname}-{pid}-{date}.log",
+			expected: "billing-" + filenameUnsafe.Replace(host) + "-" + strconv.Itoa(os.Getpid()) + "-2024_05_28.log",
+		},
+		{
+			name:     "Custom date layout",
+			template: "app-{date:2006-01-02}.log",
+			expected: "app-2024-05-28.log",
+		},
+		{
+			name:     "Unsupported date layout",
+			template: "{date:Jan 2 2006}.log",
+			wantErr:  true,
+		},
+		{
+			name:     "Date layout without day",
+			template: "{date:2006-01}.log",
+			wantErr:  true,
+		},
+		{
+			name:     "Missing date",
+			template: "{app}.log",
+			wantErr:  true,
+		},
+		{
+			name:     "Unknown token",
+			template: "{date}-{user}.log",
+			wantErr:  true,
+		},
+		{
+			name:     "Path separator",
+			template: "logs/{date}.log",
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pattern, err := newFilenamePattern(tt.template, "billing")
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, pattern.format(date))
+
+			parsed, ok := pattern.parse(tt.expected)
+			require.True(t, ok)
+			assert.True(t, date.Equal(parsed))
+
+			_, ok = pattern.parse("other-" + tt.expected)
+			assert.False(t, ok, "Foreign file names should not match")
+		})
+	}
+}
+
+// TestFilenamePatternLayout проверяет имена файлов с часом и минутами.
+func TestFilenamePatternLayout(t *testing.T) {
+	date := time.Date(2024, 5, 28, 13, 45, 0, 0, time.Local)
+
+	pattern := defaultPattern.withLayout(hourLayout)
+	assert.Equal(t, "2024_05_28_13.log", pattern.format(date))
+	assert.Equal(t, "2024_05_28_13.2.log", pattern.formatSegment(date, 2))
+
+	parsed, segment, ok := pattern.parseSegment("2024_05_28_13.2.log")
+	require.True(t, ok)
+	assert.Equal(t, 2, segment)
+	assert.True(t, date.Truncate(time.Hour).Equal(parsed))
+
+	pattern = defaultPattern.withLayout(minuteLayout)
+	assert.Equal(t, "2024_05_28_1345.log", pattern.format(date))
+
+	parsed, ok = pattern.parse("2024_05_28_1345.log")
+	require.True(t, ok)
+	assert.True(t, date.Equal(parsed))
+
+	parsed, ok = pattern.parse("2024_05_27.log")
+	require.True(t, ok, "Daily files should still be recognized")
+	assert.Equal(t, 27, parsed.Day())
+}
+
+// TestFilenamePatternCustomLayout проверяет формат {date:LAYOUT} при
+// почасовой ротации и файлы других процессов с {pid}.
+func TestFilenamePatternCustomLayout(t *testing.T) {
+	date := time.Date(2024, 5, 28, 13, 0, 0, 0, time.Local)
+
+	pattern, err := newFilenamePattern("app-{pid}-{date:2006-01-02T15}.log", "")
+	require.NoError(t, err)
+
+	// Интервал ротации не меняет заданный формат.
+	pattern = pattern.withLayout(hourLayout)
+	assert.Equal(t, "app-"+strconv.Itoa(os.Getpid())+"-2024-05-28T13.1.log", pattern.formatSegment(date, 1))
+
+	parsed, segment, ok := pattern.parseSegment("app-" + strconv.Itoa(os.Getpid()) + "-2024-05-28T13.2.log")
+	require.True(t, ok)
+	assert.Equal(t, 2, segment)
+	assert.True(t, date.Equal(parsed))
+
+	// Файл другого процесса находит только очистка.
+	_, _, ok = pattern.parseSegment("app-1-2024-05-28T13.2.log")
+	assert.False(t, ok)
+
+	parsed, segment, own, ok := pattern.parseFile("app-1-2024-05-28T13.2.log")
+	require.True(t, ok)
+	assert.False(t, own)
+	assert.Equal(t, 2, segment)
+	assert.True(t, date.Equal(parsed))
+
+	assert.NoError(t, pattern.validateInterval(time.Hour))
+	assert.Error(t, pattern.validateInterval(15*time.Minute))
+
+	_, err = Build(Path(t.TempDir()), FilenamePattern("{date:2006.01.02}.log"), RotationInterval(time.Hour))
+	assert.ErrorContains(t, err, "too coarse")
+}
+
+func TestErrorFilename(t *testing.T) {
+	assert.Equal(t, "{date}.error.log", errorFilename(""))
+	assert.Equal(t, "app-{date}.error.json", errorFilename("app-{date}.json"))
+	assert.Equal(t, "{app}_{date}.error", errorFilename("{app}_{date}"))
+}
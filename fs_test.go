@@ -0,0 +1,218 @@
+package logger
+
+import (
+	"bytes"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memFS — файловая система в памяти для тестов ротатора.
+type memFS struct {
+	files map[string]*bytes.Buffer
+	dirs  map[string]bool
+	mu    sync.Mutex
+}
+
+func newMemFS() *memFS {
+	return &memFS{files: make(map[string]*bytes.Buffer), dirs: map[string]bool{".": true}}
+}
+
+type memFile struct {
+	fs     *memFS
+	name   string
+	reader *bytes.Reader
+}
+
+type memFileInfo struct {
+	name string
+	size int64
+	dir  bool
+}
+
+func (i memFileInfo) Name() string       { return filepath.Base(i.name) }
+func (i memFileInfo) Size() int64        { return i.size }
+func (i memFileInfo) ModTime() time.Time { return time.Time{} }
+func (i memFileInfo) IsDir() bool        { return i.dir }
+func (i memFileInfo) Sys() interface{}   { return nil }
+
+func (i memFileInfo) Mode() fs.FileMode {
+	if i.dir {
+		return fs.ModeDir | 0777
+	}
+	return 0666
+}
+
+func (m *memFS) OpenFile(name string, flag int, _ fs.FileMode) (File, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	name = filepath.Clean(name)
+	data, exist := m.files[name]
+
+	switch {
+	case !exist && flag&os.O_CREATE == 0:
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	case !m.dirs[filepath.Dir(name)]:
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	case !exist || flag&os.O_TRUNC != 0:
+		data = &bytes.Buffer{}
+		m.files[name] = data
+	}
+
+	return &memFile{fs: m, name: name, reader: bytes.NewReader(bytes.Clone(data.Bytes()))}, nil
+}
+
+func (m *memFS) Stat(name string) (fs.FileInfo, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	name = filepath.Clean(name)
+	if m.dirs[name] {
+		return memFileInfo{name: name, dir: true}, nil
+	}
+
+	if data, exist := m.files[name]; exist {
+		return memFileInfo{name: name, size: int64(data.Len())}, nil
+	}
+
+	return nil, &fs.PathError{Op: "stat", Path: name, Err: fs.ErrNotExist}
+}
+
+func (m *memFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	name = filepath.Clean(name)
+	if !m.dirs[name] {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrNotExist}
+	}
+
+	var entries []fs.DirEntry
+	for path, data := range m.files {
+		if filepath.Dir(path) == name {
+			entries = append(entries, fs.FileInfoToDirEntry(memFileInfo{name: path, size: int64(data.Len())}))
+		}
+	}
+	for path := range m.dirs {
+		if path != name && filepath.Dir(path) == name {
+			entries = append(entries, fs.FileInfoToDirEntry(memFileInfo{name: path, dir: true}))
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+
+	return entries, nil
+}
+
+func (m *memFS) MkdirAll(path string, _ fs.FileMode) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for path = filepath.Clean(path); !m.dirs[path]; path = filepath.Dir(path) {
+		m.dirs[path] = true
+	}
+
+	return nil
+}
+
+func (m *memFS) Remove(name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	name = filepath.Clean(name)
+	if _, exist := m.files[name]; !exist {
+		return &fs.PathError{Op: "remove", Path: name, Err: fs.ErrNotExist}
+	}
+
+	delete(m.files, name)
+
+	return nil
+}
+
+func (m *memFS) Rename(oldpath, newpath string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	data, exist := m.files[filepath.Clean(oldpath)]
+	if !exist {
+		return &fs.PathError{Op: "rename", Path: oldpath, Err: fs.ErrNotExist}
+	}
+
+	delete(m.files, filepath.Clean(oldpath))
+	m.files[filepath.Clean(newpath)] = data
+
+	return nil
+}
+
+func (m *memFS) names() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var names []string
+	for name := range m.files {
+		names = append(names, filepath.Base(name))
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+func (f *memFile) Read(p []byte) (int, error) {
+	return f.reader.Read(p)
+}
+
+func (f *memFile) ReadAt(p []byte, off int64) (int, error) {
+	return f.reader.ReadAt(p, off)
+}
+
+func (f *memFile) Write(p []byte) (int, error) {
+	f.fs.mu.Lock()
+	defer f.fs.mu.Unlock()
+
+	data, exist := f.fs.files[f.name]
+	if !exist {
+		return 0, io.ErrClosedPipe
+	}
+
+	return data.Write(p)
+}
+
+func (f *memFile) Name() string { return f.name }
+func (f *memFile) Sync() error  { return nil }
+func (f *memFile) Close() error { return nil }
+
+func (f *memFile) Stat() (fs.FileInfo, error) {
+	return f.fs.Stat(f.name)
+}
+
+// TestFileRotatorMemFS проверяет ротацию и сжатие на файловой системе в памяти.
+func TestFileRotatorMemFS(t *testing.T) {
+	fsys := newMemFS()
+	clock := &fakeClock{now: time.Date(2024, 5, 28, 23, 59, 0, 0, time.Local)}
+
+	rotator := &fileRotator{path: "logs", fsys: fsys, clock: clock}
+
+	_, err := rotator.Write([]byte("before midnight\n"))
+	require.NoError(t, err)
+
+	clock.Add(2 * time.Minute)
+
+	_, err = rotator.Write([]byte("after midnight\n"))
+	require.NoError(t, err)
+	require.NoError(t, rotator.Close())
+
+	rotator.compressLeftovers()
+
+	assert.Equal(t, []string{"2024_05_28.log.zip", "2024_05_29.log"}, fsys.names())
+	assert.True(t, strings.HasPrefix(fsys.files[filepath.Join("logs", "2024_05_28.log.zip")].String(), "PK"))
+}
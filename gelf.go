@@ -0,0 +1,193 @@
+package logger
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	// gelfChunkSize — размер UDP-датаграммы по умолчанию, безопасный для
+	// WAN. В локальной сети можно увеличить до 8192 параметром chunk.
+	gelfChunkSize = 1420
+	// gelfMaxChunks — предел частей одного сообщения по спецификации GELF.
+	gelfMaxChunks = 128
+	// gelfChunkHeader — магические байты, идентификатор сообщения,
+	// номер части и число частей.
+	gelfChunkHeader = 12
+)
+
+var gelfPool = buffer.NewPool()
+
+// GraylogSink добавляет отправку записей в Graylog в формате GELF по UDP;
+// addr — адрес GELF UDP input, например "graylog:12201". Сообщения
+// больше датаграммы разбиваются на части. Тот же приёмник доступен в
+// Outputs по схеме "gelf" с параметром chunk (размер датаграммы).
+func GraylogSink(addr string) Option {
+	return func(l *Logger) {
+		l.outputs = append(l.outputs, (&url.URL{Scheme: "gelf", Host: addr, RawQuery: "format=gelf"}).String())
+	}
+}
+
+// gelfEncoder кодирует записи в GELF 1.1: уровень — важность syslog,
+// поля записи — дополнительные поля с префиксом "_".
+type gelfEncoder struct {
+	*zapcore.MapObjectEncoder
+	host string
+}
+
+func newGELFEncoder(zapcore.EncoderConfig) zapcore.Encoder {
+	host, _ := os.Hostname()
+
+	return &gelfEncoder{MapObjectEncoder: zapcore.NewMapObjectEncoder(), host: host}
+}
+
+func (e *gelfEncoder) Clone() zapcore.Encoder {
+	clone := zapcore.NewMapObjectEncoder()
+	for k, v := range e.Fields {
+		clone.Fields[k] = v
+	}
+
+	return &gelfEncoder{MapObjectEncoder: clone, host: e.host}
+}
+
+func (e *gelfEncoder) EncodeEntry(entry zapcore.Entry, fields []zapcore.Field) (*buffer.Buffer, error) {
+	enc := e.Clone().(*gelfEncoder)
+	for _, field := range fields {
+		field.AddTo(enc)
+	}
+
+	if entry.LoggerName != "" {
+		enc.Fields["logger"] = entry.LoggerName
+	}
+	if entry.Caller.Defined {
+		enc.Fields["caller"] = entry.Caller.TrimmedPath()
+	}
+
+	msg := make(map[string]interface{}, len(enc.Fields)+6)
+	for k, v := range enc.Fields {
+		msg[gelfFieldName(k)] = v
+	}
+
+	msg["version"] = "1.1"
+	msg["host"] = e.host
+	msg["short_message"] = entry.Message
+	msg["timestamp"] = float64(entry.Time.UnixMilli()) / 1000
+	msg["level"] = syslogSeverities[entry.Level]
+	if entry.Stack != "" {
+		msg["full_message"] = entry.Stack
+	}
+
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	buf := gelfPool.Get()
+	_, _ = buf.Write(data)
+	buf.AppendByte('\n')
+
+	return buf, nil
+}
+
+// gelfFieldName переводит имя поля в имя дополнительного поля GELF:
+// допустимы буквы, цифры, "_", "." и "-", а имя "_id" зарезервировано.
+func gelfFieldName(key string) string {
+	if key == "id" {
+		return "_id_"
+	}
+
+	return "_" + strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_', r == '.', r == '-':
+			return r
+		default:
+			return '_'
+		}
+	}, key)
+}
+
+// gelfSink отправляет сообщения GELF датаграммами UDP, разбивая большие
+// сообщения на части.
+type gelfSink struct {
+	conn      net.Conn
+	chunkSize int
+	mu        sync.Mutex
+}
+
+func newGELFSink(u *url.URL) (Sink, error) {
+	if u.Host == "" {
+		return nil, fmt.Errorf("logger: gelf sink requires host:port")
+	}
+
+	chunkSize := gelfChunkSize
+	if raw := u.Query().Get("chunk"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n <= gelfChunkHeader || n > 8192 {
+			return nil, fmt.Errorf("logger: invalid chunk %q in output %q", raw, u.Redacted())
+		}
+		chunkSize = n
+	}
+
+	conn, err := net.Dial("udp", u.Host)
+	if err != nil {
+		return nil, fmt.Errorf("logger: cannot open output %q: %w", u.Redacted(), err)
+	}
+
+	return &gelfSink{conn: conn, chunkSize: chunkSize}, nil
+}
+
+func (s *gelfSink) Write(p []byte) (int, error) {
+	msg := []byte(strings.TrimSuffix(string(p), "\n"))
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(msg) <= s.chunkSize {
+		if _, err := s.conn.Write(msg); err != nil {
+			return 0, err
+		}
+		return len(p), nil
+	}
+
+	payload := s.chunkSize - gelfChunkHeader
+	count := (len(msg) + payload - 1) / payload
+	if count > gelfMaxChunks {
+		return 0, fmt.Errorf("logger: GELF message of %d bytes exceeds %d chunks", len(msg), gelfMaxChunks)
+	}
+
+	chunk := make([]byte, 0, s.chunkSize)
+	chunk = append(chunk, 0x1e, 0x0f)
+	chunk = append(chunk, make([]byte, 8)...)
+	_, _ = rand.Read(chunk[2:10])
+	chunk = append(chunk, 0, byte(count))
+
+	for i := 0; i < count; i++ {
+		chunk = chunk[:gelfChunkHeader]
+		chunk[10] = byte(i)
+		chunk = append(chunk, msg[i*payload:min(len(msg), (i+1)*payload)]...)
+
+		if _, err := s.conn.Write(chunk); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(p), nil
+}
+
+func (s *gelfSink) Sync() error {
+	return nil
+}
+
+func (s *gelfSink) Close() error {
+	return s.conn.Close()
+}
@@ -0,0 +1,109 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"net"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// TestGELFEncoder проверяет поля сообщения GELF.
+func TestGELFEncoder(t *testing.T) {
+	enc := newGELFEncoder(zapcore.EncoderConfig{}).(*gelfEncoder)
+	zap.String("app", "api").AddTo(enc)
+
+	entry := zapcore.Entry{
+		Level:      zapcore.WarnLevel,
+		Time:       time.Unix(1700000000, 250*int64(time.Millisecond)),
+		LoggerName: "db",
+		Message:    "slow query",
+	}
+
+	buf, err := enc.EncodeEntry(entry, []zapcore.Field{zap.Int("id", 7), zap.Int("rows affected", 3)})
+	require.NoError(t, err)
+	assert.True(t, strings.HasSuffix(buf.String(), "\n"))
+
+	var msg map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &msg))
+
+	hostname, _ := os.Hostname()
+	assert.Equal(t, map[string]interface{}{
+		"version":        "1.1",
+		"host":           hostname,
+		"short_message":  "slow query",
+		"timestamp":      1700000000.25,
+		"level":          float64(4),
+		"_app":           "api",
+		"_logger":        "db",
+		"_id_":           float64(7),
+		"_rows_affected": float64(3),
+	}, msg)
+
+	// Поля дочернего кодировщика не попадают в исходный.
+	assert.Len(t, enc.Fields, 1)
+}
+
+// TestGELFSinkChunking проверяет разбиение большого сообщения на части.
+func TestGELFSinkChunking(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer conn.Close()
+
+	u, err := url.Parse("gelf://" + conn.LocalAddr().String() + "?chunk=100")
+	require.NoError(t, err)
+
+	sink, err := newGELFSink(u)
+	require.NoError(t, err)
+	defer sink.Close()
+
+	small := []byte(`{"short_message":"ok"}` + "\n")
+	_, err = sink.Write(small)
+	require.NoError(t, err)
+
+	large := bytes.Repeat([]byte("x"), 250)
+	_, err = sink.Write(append(large, '\n'))
+	require.NoError(t, err)
+
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(time.Second)))
+	packet := make([]byte, 200)
+
+	n, _, err := conn.ReadFrom(packet)
+	require.NoError(t, err)
+	assert.Equal(t, `{"short_message":"ok"}`, string(packet[:n]))
+
+	var joined []byte
+	var id []byte
+	for i := 0; i < 3; i++ {
+		n, _, err := conn.ReadFrom(packet)
+		require.NoError(t, err)
+
+		chunk := packet[:n]
+		assert.Equal(t, []byte{0x1e, 0x0f}, chunk[:2])
+		if id == nil {
+			id = append([]byte(nil), chunk[2:10]...)
+		}
+		assert.Equal(t, id, chunk[2:10])
+		assert.Equal(t, byte(i), chunk[10])
+		assert.Equal(t, byte(3), chunk[11])
+		joined = append(joined, chunk[gelfChunkHeader:]...)
+	}
+	assert.Equal(t, large, joined)
+
+	_, err = sink.Write(bytes.Repeat([]byte("x"), 88*gelfMaxChunks+1))
+	assert.ErrorContains(t, err, "exceeds 128 chunks")
+}
+
+// TestGraylogSink проверяет назначение, добавленное опцией GraylogSink.
+func TestGraylogSink(t *testing.T) {
+	logger := NewLogger(GraylogSink("graylog:12201"))
+	assert.Equal(t, []string{"gelf://graylog:12201?format=gelf"}, logger.outputs)
+}
@@ -0,0 +1,93 @@
+// Package loggergin подключает логгер github.com/restfront/logger к Gin:
+// запись запросов, восстановление после паник и служебный вывод Gin.
+package loggergin
+
+import (
+	"fmt"
+	"net/http"
+	"runtime/debug"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/restfront/logger"
+)
+
+// ContextKey — ключ логгера запроса в gin.Context.
+const ContextKey = "logger"
+
+// GinMiddleware записывает каждый запрос в формате logger.HTTPMiddleware
+// и добавляет логгер запроса с полем request_id в контекст запроса
+// (logger.FromContext) и в gin.Context под ключом ContextKey. Ошибки из
+// c.Errors попадают в поле error.
+func GinMiddleware(l *logger.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		id := c.GetHeader(logger.DefaultRequestIDHeader)
+		if !logger.ValidRequestID(id) {
+			id = logger.NewRequestID()
+		}
+		c.Header(logger.DefaultRequestIDHeader, id)
+
+		ctx, reqLogger := l.RequestContext(c.Request.Context(), id)
+		c.Request = c.Request.WithContext(ctx)
+		c.Set(ContextKey, reqLogger)
+
+		c.Next()
+
+		req := logger.RequestLog{
+			Method:     c.Request.Method,
+			Path:       c.Request.URL.Path,
+			Status:     c.Writer.Status(),
+			Latency:    time.Since(start),
+			Bytes:      int64(max(c.Writer.Size(), 0)),
+			RemoteAddr: c.Request.RemoteAddr,
+		}
+		if last := c.Errors.Last(); last != nil {
+			req.Err = last.Err
+		}
+
+		reqLogger.LogRequest(req)
+	}
+}
+
+// GinRecovery перехватывает панику обработчика, записывает её со стеком
+// вызовов с уровнем error и отвечает 500. Подключается после
+// GinMiddleware, чтобы запись паники содержала request_id, а запись
+// запроса — статус 500.
+func GinRecovery(l *logger.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		defer func() {
+			rec := recover()
+			if rec == nil {
+				return
+			}
+
+			reqLogger, ok := logger.FromContext(c.Request.Context())
+			if !ok {
+				reqLogger = l
+			}
+
+			reqLogger.ErrorW("panic recovered",
+				logger.String("panic", fmt.Sprint(rec)),
+				logger.String("method", c.Request.Method),
+				logger.String("path", c.Request.URL.Path),
+				logger.String("stack", string(debug.Stack())),
+			)
+
+			c.AbortWithStatus(http.StatusInternalServerError)
+		}()
+
+		c.Next()
+	}
+}
+
+// RedirectGinOutput направляет служебный вывод Gin (gin.DefaultWriter и
+// gin.DefaultErrorWriter) в логгер: сообщения режима отладки — с уровнем
+// debug, ошибки — с уровнем error. Так весь вывод проходит общую ротацию
+// и формат. Вызывается до создания gin.Engine.
+func RedirectGinOutput(l *logger.Logger) {
+	gin.DefaultWriter = l.Writer("debug")
+	gin.DefaultErrorWriter = l.Writer("error")
+}
@@ -0,0 +1,96 @@
+package loggergin
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/restfront/logger"
+)
+
+func newObservedLogger() (*logger.Logger, *observer.ObservedLogs) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	return logger.NewLogger(logger.BaseLogger(zap.New(core))), logs
+}
+
+// TestGinMiddleware проверяет запись запросов, логгер запроса и
+// восстановление после паники.
+func TestGinMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	l, logs := newObservedLogger()
+
+	router := gin.New()
+	router.Use(GinMiddleware(l), GinRecovery(l))
+	router.GET("/orders/:id", func(c *gin.Context) {
+		reqLogger, ok := logger.FromContext(c.Request.Context())
+		require.True(t, ok)
+		assert.Same(t, reqLogger, c.MustGet(ContextKey))
+
+		reqLogger.Info("loading order")
+		_ = c.Error(errors.New("cache miss"))
+		c.String(http.StatusOK, "order")
+	})
+	router.GET("/panic", func(c *gin.Context) {
+		panic("boom")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/orders/7", nil)
+	req.Header.Set(logger.DefaultRequestIDHeader, "req-7")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	assert.Equal(t, "req-7", rec.Header().Get(logger.DefaultRequestIDHeader))
+
+	entries := logs.TakeAll()
+	require.Len(t, entries, 2)
+	assert.Equal(t, "loading order", entries[0].Message)
+	assert.Equal(t, "req-7", entries[0].ContextMap()["request_id"])
+
+	fields := entries[1].ContextMap()
+	assert.Equal(t, "http request", entries[1].Message)
+	assert.Equal(t, zapcore.InfoLevel, entries[1].Level)
+	assert.Equal(t, "req-7", fields["request_id"])
+	assert.Equal(t, "/orders/7", fields["path"])
+	assert.Equal(t, int64(http.StatusOK), fields["status"])
+	assert.Equal(t, int64(len("order")), fields["bytes"])
+	assert.Equal(t, "cache miss", fields["error"])
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/panic", nil))
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+
+	entries = logs.TakeAll()
+	require.Len(t, entries, 2)
+	assert.Equal(t, "panic recovered", entries[0].Message)
+	assert.Equal(t, "boom", entries[0].ContextMap()["panic"])
+	assert.Contains(t, entries[0].ContextMap()["stack"], "runtime/debug.Stack")
+	assert.Equal(t, entries[0].ContextMap()["request_id"], entries[1].ContextMap()["request_id"])
+	assert.Equal(t, zapcore.ErrorLevel, entries[1].Level)
+	assert.Equal(t, int64(http.StatusInternalServerError), entries[1].ContextMap()["status"])
+}
+
+// TestRedirectGinOutput проверяет вывод служебных сообщений Gin в логгер.
+func TestRedirectGinOutput(t *testing.T) {
+	defaultWriter, defaultErrorWriter := gin.DefaultWriter, gin.DefaultErrorWriter
+	defer func() {
+		gin.DefaultWriter, gin.DefaultErrorWriter = defaultWriter, defaultErrorWriter
+	}()
+
+	l, logs := newObservedLogger()
+	RedirectGinOutput(l)
+
+	_, err := gin.DefaultErrorWriter.Write([]byte("[GIN-debug] [WARNING] trusted proxies\n"))
+	require.NoError(t, err)
+
+	entries := logs.TakeAll()
+	require.Len(t, entries, 1)
+	assert.Equal(t, zapcore.ErrorLevel, entries[0].Level)
+	assert.Equal(t, "[GIN-debug] [WARNING] trusted proxies", entries[0].Message)
+}
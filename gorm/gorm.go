@@ -0,0 +1,121 @@
+// Package loggergorm подключает логгер github.com/restfront/logger к GORM:
+// запись SQL-запросов с поиском медленных.
+package loggergorm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+	"gorm.io/gorm/utils"
+
+	"github.com/restfront/logger"
+)
+
+// GormLogger возвращает logger.Interface GORM поверх логгера: каждый
+// запрос пишется с текстом SQL (поле sql), числом строк (rows),
+// длительностью (duration) и местом вызова в коде приложения (source).
+// Обычные запросы пишутся с уровнем debug, запросы дольше slowThreshold —
+// warn с полем slow_threshold, ошибки — error; ErrRecordNotFound ошибкой
+// не считается. Нулевой slowThreshold отключает поиск медленных запросов.
+// Если в контексте запроса есть логгер (logger.FromContext), записи идут
+// через него и получают его поля, например request_id.
+func GormLogger(l *logger.Logger, slowThreshold time.Duration) gormlogger.Interface {
+	return &gormLogger{logger: l, slowThreshold: slowThreshold, mode: gormlogger.Info}
+}
+
+type gormLogger struct {
+	logger        *logger.Logger
+	slowThreshold time.Duration
+	mode          gormlogger.LogLevel
+}
+
+// LogMode ограничивает записи уровнем GORM: Silent отключает их, Error
+// оставляет только ошибки, Warn — ошибки и медленные запросы.
+func (g *gormLogger) LogMode(mode gormlogger.LogLevel) gormlogger.Interface {
+	clone := *g
+	clone.mode = mode
+
+	return &clone
+}
+
+func (g *gormLogger) from(ctx context.Context) *logger.Logger {
+	if l, ok := logger.FromContext(ctx); ok {
+		return l
+	}
+
+	return g.logger
+}
+
+func (g *gormLogger) Info(ctx context.Context, msg string, data ...interface{}) {
+	if g.mode >= gormlogger.Info {
+		g.from(ctx).InfoW(fmt.Sprintf(msg, data...), logger.String("source", utils.FileWithLineNum()))
+	}
+}
+
+func (g *gormLogger) Warn(ctx context.Context, msg string, data ...interface{}) {
+	if g.mode >= gormlogger.Warn {
+		g.from(ctx).WarnW(fmt.Sprintf(msg, data...), logger.String("source", utils.FileWithLineNum()))
+	}
+}
+
+func (g *gormLogger) Error(ctx context.Context, msg string, data ...interface{}) {
+	if g.mode >= gormlogger.Error {
+		g.from(ctx).ErrorW(fmt.Sprintf(msg, data...), logger.String("source", utils.FileWithLineNum()))
+	}
+}
+
+func (g *gormLogger) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	if g.mode <= gormlogger.Silent {
+		return
+	}
+
+	elapsed := time.Since(begin)
+	failed := err != nil && !errors.Is(err, gorm.ErrRecordNotFound)
+	slow := g.slowThreshold > 0 && elapsed > g.slowThreshold
+
+	var level string
+
+	switch {
+	case failed && g.mode >= gormlogger.Error:
+		level = "error"
+	case slow && g.mode >= gormlogger.Warn:
+		level = "warn"
+	case g.mode >= gormlogger.Info:
+		level = "debug"
+	default:
+		return
+	}
+
+	l := g.from(ctx)
+
+	// fc собирает текст запроса с подставленными параметрами, поэтому
+	// вызывается, только если запись будет сделана.
+	if !l.Enabled(level) {
+		return
+	}
+
+	sql, rows := fc()
+
+	fields := []logger.Field{
+		logger.String("sql", sql),
+		logger.Duration("duration", elapsed),
+		logger.String("source", utils.FileWithLineNum()),
+	}
+	// GORM передаёт -1, если число строк неизвестно.
+	if rows >= 0 {
+		fields = append(fields, logger.Int64("rows", rows))
+	}
+
+	switch {
+	case failed:
+		l.ErrorW("sql query failed", append(fields, logger.Err(err))...)
+	case slow:
+		l.WarnW("slow sql query", append(fields, logger.Duration("slow_threshold", g.slowThreshold))...)
+	default:
+		l.DebugW("sql query", fields...)
+	}
+}
@@ -0,0 +1,106 @@
+package loggergorm
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+
+	"github.com/restfront/logger"
+)
+
+func newObservedLogger() (*logger.Logger, *observer.ObservedLogs) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	return logger.NewLogger(logger.BaseLogger(zap.New(core))), logs
+}
+
+func query(sql string, rows int64) func() (string, int64) {
+	return func() (string, int64) { return sql, rows }
+}
+
+// TestGormLoggerTrace проверяет запись запросов, медленных запросов и
+// ошибок.
+func TestGormLoggerTrace(t *testing.T) {
+	l, logs := newObservedLogger()
+	g := GormLogger(l, 100*time.Millisecond)
+
+	now := time.Now()
+	g.Trace(context.Background(), now, query("SELECT 1", 1), nil)
+	g.Trace(context.Background(), now.Add(-time.Second), query("SELECT * FROM orders", 500), nil)
+	g.Trace(context.Background(), now, query("SELECT * FROM users WHERE id = 7", 0), gorm.ErrRecordNotFound)
+	g.Trace(context.Background(), now, query("INSERT INTO users", -1), errors.New("duplicate key"))
+
+	entries := logs.TakeAll()
+	require.Len(t, entries, 4)
+
+	assert.Equal(t, "sql query", entries[0].Message)
+	assert.Equal(t, zapcore.DebugLevel, entries[0].Level)
+	assert.Equal(t, "SELECT 1", entries[0].ContextMap()["sql"])
+	assert.Equal(t, int64(1), entries[0].ContextMap()["rows"])
+	assert.Contains(t, entries[0].ContextMap(), "duration")
+	assert.Contains(t, entries[0].ContextMap()["source"], "gorm_test.go")
+
+	assert.Equal(t, "slow sql query", entries[1].Message)
+	assert.Equal(t, zapcore.WarnLevel, entries[1].Level)
+	assert.Equal(t, 100*time.Millisecond, entries[1].ContextMap()["slow_threshold"])
+
+	assert.Equal(t, zapcore.DebugLevel, entries[2].Level)
+
+	assert.Equal(t, "sql query failed", entries[3].Message)
+	assert.Equal(t, zapcore.ErrorLevel, entries[3].Level)
+	assert.Equal(t, "duplicate key", entries[3].ContextMap()["error"])
+	assert.NotContains(t, entries[3].ContextMap(), "rows")
+}
+
+// TestGormLoggerLogMode проверяет ограничение записей уровнем GORM и
+// логгер запроса из контекста.
+func TestGormLoggerLogMode(t *testing.T) {
+	l, logs := newObservedLogger()
+	g := GormLogger(l, time.Millisecond).LogMode(gormlogger.Warn)
+
+	ctx, _ := l.RequestContext(context.Background(), "req-5")
+	now := time.Now()
+
+	g.Trace(ctx, now.Add(time.Hour), query("SELECT 1", 1), nil)
+	g.Trace(ctx, now.Add(-time.Second), query("SELECT pg_sleep(1)", 1), nil)
+	g.Info(ctx, "skipped %d", 1)
+	g.Warn(ctx, "deprecated %s", "callback")
+
+	entries := logs.TakeAll()
+	require.Len(t, entries, 2)
+	assert.Equal(t, "slow sql query", entries[0].Message)
+	assert.Equal(t, "req-5", entries[0].ContextMap()["request_id"])
+	assert.Equal(t, "deprecated callback", entries[1].Message)
+
+	GormLogger(l, 0).LogMode(gormlogger.Silent).Trace(ctx, now, query("SELECT 1", 1), errors.New("boom"))
+	assert.Zero(t, logs.Len())
+
+	_ = gorm.Config{Logger: GormLogger(l, time.Second)}
+}
+
+// TestGormLoggerTraceDisabledLevel проверяет, что текст запроса не
+// собирается, если его уровень не пишется.
+func TestGormLoggerTraceDisabledLevel(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	g := GormLogger(logger.NewLogger(logger.BaseLogger(zap.New(core))), time.Second)
+
+	called := false
+	g.Trace(context.Background(), time.Now(), func() (string, int64) {
+		called = true
+		return "SELECT 1", 1
+	}, nil)
+
+	assert.False(t, called)
+	assert.Zero(t, logs.Len())
+
+	g.Trace(context.Background(), time.Now(), query("INSERT INTO users", 1), errors.New("duplicate key"))
+	assert.Equal(t, 1, logs.Len())
+}
@@ -0,0 +1,129 @@
+package logger
+
+import (
+	"sync"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Group откладывает записи единицы работы до Commit или Discard: например,
+// подробности запроса выводятся, только если запрос завершился ошибкой.
+// Записи сохраняют время, место вызова и имя логгера (Named) и при Commit
+// попадают во все выводы логгера; в статистике учитываются только они.
+type Group struct {
+	target      zapcore.Core
+	entries     []groupEntry
+	mu          sync.Mutex
+	sugarLogger *zap.SugaredLogger
+}
+
+type groupEntry struct {
+	entry  zapcore.Entry
+	fields []zapcore.Field
+}
+
+// Group создаёт пустую группу записей.
+func (l *Logger) Group() *Group {
+	g := &Group{target: l.baseLogger.Core()}
+
+	core := &groupCore{group: g}
+	g.sugarLogger = zap.New(core, l.zapOptions()...).Named(l.baseLogger.Name()).Sugar()
+
+	return g
+}
+
+// Commit пишет отложенные записи и очищает группу.
+func (g *Group) Commit() {
+	g.mu.Lock()
+	entries := g.entries
+	g.entries = nil
+	g.mu.Unlock()
+
+	for _, e := range entries {
+		if ce := g.target.Check(e.entry, nil); ce != nil {
+			ce.Write(e.fields...)
+		}
+	}
+}
+
+// Discard отбрасывает отложенные записи.
+func (g *Group) Discard() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.entries = nil
+}
+
+func (g *Group) Debug(args ...interface{}) {
+	g.sugarLogger.Debug(args...)
+}
+
+func (g *Group) Debugf(template string, args ...interface{}) {
+	g.sugarLogger.Debugf(template, args...)
+}
+
+func (g *Group) Info(args ...interface{}) {
+	g.sugarLogger.Info(args...)
+}
+
+func (g *Group) Infof(template string, args ...interface{}) {
+	g.sugarLogger.Infof(template, args...)
+}
+
+func (g *Group) Warn(args ...interface{}) {
+	g.sugarLogger.Warn(args...)
+}
+
+func (g *Group) Warnf(template string, args ...interface{}) {
+	g.sugarLogger.Warnf(template, args...)
+}
+
+func (g *Group) Error(args ...interface{}) {
+	g.sugarLogger.Error(args...)
+}
+
+func (g *Group) Errorf(template string, args ...interface{}) {
+	g.sugarLogger.Errorf(template, args...)
+}
+
+// groupCore сохраняет записи в группу вместо вывода.
+type groupCore struct {
+	group  *Group
+	fields []zapcore.Field
+}
+
+func (c *groupCore) Enabled(level zapcore.Level) bool {
+	return c.group.target.Enabled(level)
+}
+
+func (c *groupCore) With(fields []zapcore.Field) zapcore.Core {
+	return &groupCore{
+		group:  c.group,
+		fields: append(c.fields[:len(c.fields):len(c.fields)], fields...),
+	}
+}
+
+func (c *groupCore) Check(entry zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return ce.AddCore(entry, c)
+	}
+
+	return ce
+}
+
+func (c *groupCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	c.group.mu.Lock()
+	defer c.group.mu.Unlock()
+
+	c.group.entries = append(c.group.entries, groupEntry{
+		entry:  entry,
+		fields: append(c.fields[:len(c.fields):len(c.fields)], fields...),
+	})
+
+	return nil
+}
+
+func (c *groupCore) Sync() error {
+	return nil
+}
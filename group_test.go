@@ -0,0 +1,79 @@
+package logger
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// TestGroupCommit проверяет, что записи выводятся только после Commit.
+func TestGroupCommit(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+
+	logger := NewLogger()
+	logger.baseLogger = zap.New(core).With(zap.String("request", "r1"))
+
+	g := logger.Group()
+	g.Debug("filtered")
+	g.Info("step 1")
+	g.Warnf("step %d", 2)
+
+	assert.Zero(t, logs.Len())
+
+	g.Commit()
+
+	entries := logs.All()
+	if assert.Len(t, entries, 2) {
+		assert.Equal(t, "step 1", entries[0].Message)
+		assert.Equal(t, "step 2", entries[1].Message)
+		assert.Equal(t, "r1", entries[1].ContextMap()["request"])
+		assert.True(t, entries[0].Caller.Defined)
+	}
+
+	g.Commit()
+	assert.Equal(t, 2, logs.Len())
+}
+
+// TestGroupDiscard проверяет, что отброшенные записи не выводятся.
+func TestGroupDiscard(t *testing.T) {
+	core, logs := observer.New(zap.DebugLevel)
+
+	logger := NewLogger()
+	logger.baseLogger = zap.New(core)
+
+	g := logger.Group()
+	g.Info("detail")
+	g.Discard()
+	g.Commit()
+
+	assert.Zero(t, logs.Len())
+}
+
+// TestGroupNamed проверяет имя логгера у записей группы и учёт в
+// статистике только выведенных записей.
+func TestGroupNamed(t *testing.T) {
+	tmpDir := t.TempDir()
+	logger := NewLogger(Path(tmpDir), Structured(true))
+	require.NoError(t, logger.Init(false))
+
+	g := logger.Named("billing").Group()
+	g.Info("discarded")
+	g.Discard()
+	g.Info("committed")
+	g.Commit()
+	require.NoError(t, logger.Close())
+
+	assert.EqualValues(t, 1, logger.stats.entries[zapcore.InfoLevel-zapcore.DebugLevel].Load())
+
+	lines := readJSONLines(t, filepath.Join(tmpDir, time.Now().Format(dateLayout)+".log"))
+	if assert.Len(t, lines, 1) {
+		assert.Equal(t, "committed", lines[0]["message"])
+		assert.Equal(t, "billing", lines[0]["logger"])
+	}
+}
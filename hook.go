@@ -0,0 +1,122 @@
+package logger
+
+import (
+	"errors"
+	"strings"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Entry — запись, передаваемая хукам AddHook. Хук может менять любые
+// поля записи, в том числе уровень и список полей.
+type Entry struct {
+	zapcore.Entry
+	// Fields — поля вызова логгера без полей WithFields.
+	Fields []Field
+}
+
+// Hook обрабатывает запись перед выводом. Ошибка отменяет вывод записи;
+// ошибки, кроме ErrDropEntry, сообщаются в Diagnostics.
+type Hook func(entry *Entry) error
+
+// ErrDropEntry возвращается хуком, чтобы молча отбросить запись.
+var ErrDropEntry = errors.New("logger: entry dropped by hook")
+
+// AddHook добавляет хук, через который проходят все записи до вывода.
+// Хуки вызываются в порядке добавления; на их основе можно строить
+// скрытие данных, обогащение и динамическую фильтрацию:
+//
+//	logger.AddHook(func(e *logger.Entry) error {
+//		if strings.HasPrefix(e.Message, "healthcheck") {
+//			return logger.ErrDropEntry
+//		}
+//		e.Fields = append(e.Fields, logger.String("region", region))
+//		return nil
+//	})
+func AddHook(hook Hook) Option {
+	return func(l *Logger) {
+		l.hooks = append(l.hooks, hook)
+	}
+}
+
+// hookCore пропускает записи через хуки. Check заранее выбирает ядра для
+// исходной записи, а хуки вызываются один раз при записи.
+type hookCore struct {
+	zapcore.Core
+	hooks []Hook
+	diag  *zap.Logger
+}
+
+func (c *hookCore) With(fields []zapcore.Field) zapcore.Core {
+	return &hookCore{Core: c.Core.With(fields), hooks: c.hooks, diag: c.diag}
+}
+
+func (c *hookCore) Check(entry zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	inner := c.Core.Check(entry, nil)
+	if inner == nil {
+		return ce
+	}
+
+	return ce.AddCore(entry, &hookWrite{hookCore: c, inner: inner})
+}
+
+// hookWrite — выбранные для одной записи ядра.
+type hookWrite struct {
+	*hookCore
+	inner *zapcore.CheckedEntry
+}
+
+func (w *hookWrite) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	e := &Entry{Entry: entry, Fields: fields}
+
+	for _, hook := range w.hooks {
+		if err := hook(e); err != nil {
+			if !errors.Is(err, ErrDropEntry) {
+				diagLogger(w.diag).Warn("hook failed", zap.String("message", entry.Message), zap.Error(err))
+			}
+			return nil
+		}
+	}
+
+	inner := w.inner
+	if e.Level != entry.Level {
+		// Уровень изменён хуком: ядра выбираются заново.
+		if inner = w.Core.Check(e.Entry, nil); inner == nil {
+			return nil
+		}
+	}
+
+	inner.Entry = e.Entry
+
+	return writeChecked(inner, e.Fields)
+}
+
+// writeChecked пишет запись через ce и возвращает ошибки ядер, которые
+// CheckedEntry.Write без ErrorOutput молча отбрасывает.
+func writeChecked(ce *zapcore.CheckedEntry, fields []zapcore.Field) error {
+	var errs writeErrors
+	ce.ErrorOutput = &errs
+	ce.Write(fields...)
+
+	return errors.Join(errs...)
+}
+
+// writeErrors собирает ошибки, которые CheckedEntry.Write сообщает в
+// ErrorOutput строками "<время> write error: <ошибка>".
+type writeErrors []error
+
+func (e *writeErrors) Write(p []byte) (int, error) {
+	msg := strings.TrimSuffix(string(p), "\n")
+	if _, err, found := strings.Cut(msg, " write error: "); found {
+		msg = err
+	}
+
+	*e = append(*e, errors.New(msg))
+
+	return len(p), nil
+}
+
+func (e *writeErrors) Sync() error {
+	return nil
+}
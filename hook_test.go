@@ -0,0 +1,86 @@
+package logger
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestHooks(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	diag := &syncBuffer{}
+
+	calls := 0
+	hooks := []Hook{
+		func(e *Entry) error {
+			calls++
+			if strings.HasPrefix(e.Message, "healthcheck") {
+				return ErrDropEntry
+			}
+			if e.Message == "broken" {
+				return errors.New("hook exploded")
+			}
+			return nil
+		},
+		func(e *Entry) error {
+			e.Message = strings.ToUpper(e.Message)
+			e.Fields = append(e.Fields, String("region", "eu"))
+			if e.Message == "PROMOTE" {
+				e.Level = zapcore.ErrorLevel
+			}
+			if e.Message == "DEMOTE" {
+				e.Level = zapcore.DebugLevel
+			}
+			return nil
+		},
+	}
+
+	logger := NewLogger(AddHook(hooks[0]), AddHook(hooks[1]))
+	require.Len(t, logger.hooks, 2)
+
+	base := zap.New(&hookCore{Core: core, hooks: logger.hooks, diag: newDiagnostics(diag)}).With(zap.String("service", "api"))
+
+	base.Info("request", zap.Int("status", 200))
+	base.Info("healthcheck ok")
+	base.Info("broken")
+	base.Debug("filtered")
+	base.Info("promote")
+	base.Info("demote")
+
+	assert.Equal(t, 5, calls)
+
+	entries := logs.All()
+	require.Len(t, entries, 2)
+	assert.Equal(t, "REQUEST", entries[0].Message)
+	assert.Equal(t, map[string]interface{}{"service": "api", "status": int64(200), "region": "eu"}, entries[0].ContextMap())
+	assert.Equal(t, "PROMOTE", entries[1].Message)
+	assert.Equal(t, zapcore.ErrorLevel, entries[1].Level)
+
+	assert.Contains(t, diag.String(), "hook exploded")
+	assert.NotContains(t, diag.String(), "healthcheck")
+}
+
+func TestHookOption(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	logger := NewLogger(Path(tmpDir), Structured(true), AddHook(func(e *Entry) error {
+		e.Fields = append(e.Fields, Bool("hooked", true))
+		return nil
+	}))
+	require.NoError(t, logger.Init(false))
+
+	logger.Info("message")
+	require.NoError(t, logger.Close())
+
+	lines := readJSONLines(t, filepath.Join(tmpDir, time.Now().Format(dateLayout)+".log"))
+	require.Len(t, lines, 1)
+	assert.Equal(t, true, lines[0]["hooked"])
+}
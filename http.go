@@ -0,0 +1,229 @@
+package logger
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+)
+
+// DefaultRequestIDHeader — заголовок идентификатора запроса по умолчанию.
+const DefaultRequestIDHeader = "X-Request-ID"
+
+// MWOption настраивает HTTPMiddleware.
+type MWOption func(*httpMiddleware)
+
+// RequestIDHeader задаёт заголовок, из которого берётся идентификатор
+// запроса и в котором он возвращается клиенту.
+func RequestIDHeader(name string) MWOption {
+	return func(m *httpMiddleware) {
+		m.requestIDHeader = name
+	}
+}
+
+// SkipPaths отключает запись запросов к путям paths, например к проверкам
+// готовности. Логгер запроса в контекст при этом всё равно добавляется.
+func SkipPaths(paths ...string) MWOption {
+	return func(m *httpMiddleware) {
+		for _, path := range paths {
+			m.skip[path] = true
+		}
+	}
+}
+
+type httpMiddleware struct {
+	logger          *Logger
+	requestIDHeader string
+	skip            map[string]bool
+}
+
+type requestIDContextKey struct{}
+
+// RequestIDFromContext возвращает идентификатор запроса, сохранённый
+// HTTPMiddleware.
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDContextKey{}).(string)
+	return id
+}
+
+// HTTPMiddleware записывает каждый запрос: метод, путь, статус, время
+// обработки, размер ответа и адрес клиента. Ответы 5xx пишутся с уровнем
+// error, 4xx — warn, остальные — info. Идентификатор запроса берётся из
+// заголовка X-Request-ID, если он проходит ValidRequestID, или создаётся,
+// возвращается в ответе и вместе с логгером запроса с полем request_id
+// добавляется в контекст (FromContext, RequestIDFromContext).
+func HTTPMiddleware(l *Logger, opts ...MWOption) func(http.Handler) http.Handler {
+	m := &httpMiddleware{logger: l, requestIDHeader: DefaultRequestIDHeader, skip: make(map[string]bool)}
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m.wrap
+}
+
+func (m *httpMiddleware) wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+
+		id := r.Header.Get(m.requestIDHeader)
+		if !ValidRequestID(id) {
+			id = NewRequestID()
+		}
+		w.Header().Set(m.requestIDHeader, id)
+
+		ctx, reqLogger := m.logger.RequestContext(r.Context(), id)
+
+		rec := &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r.WithContext(ctx))
+
+		if m.skip[r.URL.Path] {
+			return
+		}
+
+		reqLogger.LogRequest(RequestLog{
+			Method:     r.Method,
+			Path:       r.URL.Path,
+			Status:     rec.status,
+			Latency:    time.Since(start),
+			Bytes:      rec.bytes,
+			RemoteAddr: r.RemoteAddr,
+		})
+	})
+}
+
+// NewRequestID создаёт случайный идентификатор запроса из 32
+// шестнадцатеричных символов.
+func NewRequestID() string {
+	var id [16]byte
+	_, _ = rand.Read(id[:])
+
+	return hex.EncodeToString(id[:])
+}
+
+// maxRequestIDLength ограничивает длину идентификатора запроса из
+// заголовка.
+const maxRequestIDLength = 128
+
+// ValidRequestID сообщает, можно ли записать идентификатор запроса из
+// заголовка клиента как есть: он не пуст, не длиннее 128 символов и
+// состоит из латинских букв, цифр и символов "-", "_", ".", ":". Иначе
+// клиент мог бы подделать строки журнала или раздуть каждую запись.
+func ValidRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+
+	for i := 0; i < len(id); i++ {
+		switch c := id[i]; {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case c == '-', c == '_', c == '.', c == ':':
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+// RequestContext возвращает копию ctx с идентификатором запроса и
+// логгером запроса с полем request_id, а также сам этот логгер. Через него
+// адаптеры веб-фреймворков добавляют в контекст то же, что HTTPMiddleware.
+func (l *Logger) RequestContext(ctx context.Context, requestID string) (context.Context, *Logger) {
+	reqLogger := l.WithFields(map[string]interface{}{"request_id": requestID})
+
+	ctx = context.WithValue(ctx, requestIDContextKey{}, requestID)
+
+	return NewContext(ctx, reqLogger), reqLogger
+}
+
+// RequestLog — итог обработки HTTP-запроса для LogRequest.
+type RequestLog struct {
+	Method     string
+	Path       string
+	Status     int
+	Latency    time.Duration
+	Bytes      int64
+	RemoteAddr string
+	// Err — ошибка обработки, если фреймворк её сообщает.
+	Err error
+}
+
+// LogRequest записывает итог запроса в формате HTTPMiddleware: ответы 5xx
+// с уровнем error, 4xx — warn, остальные — info.
+func (l *Logger) LogRequest(req RequestLog) {
+	fields := []Field{
+		String("method", req.Method),
+		String("path", req.Path),
+		Int("status", req.Status),
+		Duration("latency", req.Latency),
+		Int64("bytes", req.Bytes),
+		String("remote_addr", req.RemoteAddr),
+	}
+	if req.Err != nil {
+		fields = append(fields, Err(req.Err))
+	}
+
+	switch {
+	case req.Status >= http.StatusInternalServerError:
+		l.ErrorW("http request", fields...)
+	case req.Status >= http.StatusBadRequest:
+		l.WarnW("http request", fields...)
+	default:
+		l.InfoW("http request", fields...)
+	}
+}
+
+// responseRecorder запоминает статус и размер ответа. Unwrap открывает
+// исходный ResponseWriter для http.ResponseController.
+type responseRecorder struct {
+	http.ResponseWriter
+	status      int
+	bytes       int64
+	wroteHeader bool
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	// Информационные ответы 1xx не окончательные.
+	if !r.wroteHeader && status >= http.StatusOK {
+		r.status = status
+		r.wroteHeader = true
+	}
+
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *responseRecorder) Write(p []byte) (int, error) {
+	r.wroteHeader = true
+
+	n, err := r.ResponseWriter.Write(p)
+	r.bytes += int64(n)
+
+	return n, err
+}
+
+func (r *responseRecorder) Flush() {
+	if flusher, ok := r.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Hijack передаёт соединение обработчику, например для WebSocket, если
+// исходный ResponseWriter это поддерживает.
+func (r *responseRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := r.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("logger: %T does not support hijacking: %w", r.ResponseWriter, http.ErrNotSupported)
+	}
+
+	r.wroteHeader = true
+
+	return hijacker.Hijack()
+}
+
+func (r *responseRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
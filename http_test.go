@@ -0,0 +1,155 @@
+package logger
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestHTTPMiddleware проверяет запись запросов и логгер запроса в
+// контексте.
+func TestHTTPMiddleware(t *testing.T) {
+	tmpDir := t.TempDir()
+	logger := NewLogger(Path(tmpDir), Structured(true))
+	require.NoError(t, logger.Init(false))
+
+	handler := HTTPMiddleware(logger, SkipPaths("/healthz"))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reqLogger, ok := FromContext(r.Context())
+		require.True(t, ok)
+		reqLogger.Info("handling")
+
+		if r.URL.Path == "/missing" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/missing", nil)
+	req.Header.Set("X-Request-ID", "req-1")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, "req-1", rec.Header().Get("X-Request-ID"))
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/orders", nil))
+	generated := rec.Header().Get("X-Request-ID")
+	assert.Len(t, generated, 32)
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	require.NoError(t, logger.Close())
+
+	lines := readJSONLines(t, filepath.Join(tmpDir, time.Now().Format(dateLayout)+".log"))
+	require.Len(t, lines, 5)
+
+	assert.Equal(t, "handling", lines[0]["message"])
+	assert.Equal(t, "req-1", lines[0]["request_id"])
+
+	assert.Equal(t, "http request", lines[1]["message"])
+	assert.Equal(t, "warn", lines[1]["level"])
+	assert.Equal(t, "req-1", lines[1]["request_id"])
+	assert.Equal(t, "GET", lines[1]["method"])
+	assert.Equal(t, "/missing", lines[1]["path"])
+	assert.Equal(t, float64(http.StatusNotFound), lines[1]["status"])
+	assert.Equal(t, float64(len("404 page not found\n")), lines[1]["bytes"])
+	assert.Equal(t, "192.0.2.1:1234", lines[1]["remote_addr"])
+	assert.Contains(t, lines[1], "latency")
+
+	assert.Equal(t, "info", lines[3]["level"])
+	assert.Equal(t, generated, lines[3]["request_id"])
+	assert.Equal(t, float64(http.StatusOK), lines[3]["status"])
+	assert.Equal(t, float64(2), lines[3]["bytes"])
+
+	assert.Equal(t, "handling", lines[4]["message"])
+}
+
+// TestRequestIDHeader проверяет собственный заголовок идентификатора.
+func TestRequestIDHeader(t *testing.T) {
+	logger := ForTesting(t)
+
+	var id string
+	handler := HTTPMiddleware(logger, RequestIDHeader("X-Trace"))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id = RequestIDFromContext(r.Context())
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("X-Trace", "abc")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, "abc", id)
+	assert.Equal(t, "abc", rec.Header().Get("X-Trace"))
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+
+	_, ok := FromContext(context.Background())
+	assert.False(t, ok)
+	assert.Empty(t, RequestIDFromContext(context.Background()))
+}
+
+// TestRequestIDValidation проверяет замену недопустимого идентификатора
+// из заголовка новым.
+func TestRequestIDValidation(t *testing.T) {
+	logger := ForTesting(t)
+
+	var id string
+	handler := HTTPMiddleware(logger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id = RequestIDFromContext(r.Context())
+	}))
+
+	for _, header := range []string{
+		"req-1\nlevel=error",
+		"<script>",
+		strings.Repeat("a", 129),
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("X-Request-ID", header)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		assert.Len(t, id, 32)
+		assert.Equal(t, id, rec.Header().Get("X-Request-ID"))
+	}
+
+	assert.True(t, ValidRequestID("01HZX3-trace_1.2:3"))
+	assert.True(t, ValidRequestID(strings.Repeat("a", 128)))
+	assert.False(t, ValidRequestID(""))
+}
+
+// TestHTTPMiddlewareHijack проверяет передачу соединения обработчику
+// через middleware.
+func TestHTTPMiddlewareHijack(t *testing.T) {
+	handler := HTTPMiddleware(ForTesting(t))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, buf, err := http.NewResponseController(w).Hijack()
+		if !assert.NoError(t, err) {
+			return
+		}
+		defer conn.Close()
+
+		_, _ = buf.WriteString("HTTP/1.1 200 OK\r\nContent-Length: 8\r\nConnection: close\r\n\r\nhijacked")
+		_ = buf.Flush()
+	}))
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	resp, err := http.Get(server.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "hijacked", string(body))
+
+	rec := &responseRecorder{ResponseWriter: httptest.NewRecorder()}
+	_, _, err = rec.Hijack()
+	assert.ErrorIs(t, err, http.ErrNotSupported)
+}
@@ -0,0 +1,72 @@
+package logger
+
+import (
+	"os"
+
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/zapcore"
+)
+
+// JournaldMode определяет, добавляются ли к строкам консоли префиксы
+// приоритета sd-daemon.
+type JournaldMode int
+
+const (
+	// JournaldAuto добавляет префиксы, если stdout подключён к journald:
+	// устройство и inode stdout совпадают с переменной JOURNAL_STREAM,
+	// которую задаёт systemd.
+	JournaldAuto JournaldMode = iota
+	// JournaldOff не добавляет префиксы.
+	JournaldOff
+	// JournaldOn всегда добавляет префиксы.
+	JournaldOn
+)
+
+// JournaldPriority задаёт добавление префиксов <N> к консольным записям,
+// по которым journald определяет приоритет строк, захваченных со stdout.
+// Приоритет получает только первая строка записи; продолжения (например,
+// stacktrace) journald запишет с приоритетом по умолчанию.
+func JournaldPriority(mode JournaldMode) Option {
+	return func(l *Logger) {
+		l.journaldMode = mode
+	}
+}
+
+func (m JournaldMode) enabled() bool {
+	switch m {
+	case JournaldOn:
+		return true
+	case JournaldAuto:
+		return stdoutIsJournal(os.Getenv("JOURNAL_STREAM"))
+	default:
+		return false
+	}
+}
+
+var priorityPool = buffer.NewPool()
+
+// priorityEncoder дописывает перед записью префикс <N> с приоритетом
+// syslog её уровня.
+type priorityEncoder struct {
+	zapcore.Encoder
+}
+
+func (e priorityEncoder) Clone() zapcore.Encoder {
+	return priorityEncoder{Encoder: e.Encoder.Clone()}
+}
+
+func (e priorityEncoder) EncodeEntry(entry zapcore.Entry, fields []zapcore.Field) (*buffer.Buffer, error) {
+	encoded, err := e.Encoder.EncodeEntry(entry, fields)
+	if err != nil {
+		return nil, err
+	}
+	defer encoded.Free()
+
+	buf := priorityPool.Get()
+	buf.AppendByte('<')
+	buf.AppendInt(int64(syslogSeverities[entry.Level]))
+	buf.AppendByte('>')
+	_, _ = buf.Write(encoded.Bytes())
+
+	return buf, nil
+}
@@ -0,0 +1,9 @@
+//go:build !(darwin || dragonfly || freebsd || linux || netbsd || openbsd)
+
+package logger
+
+// На платформах без journald stdout к нему не подключён.
+
+func stdoutIsJournal(_ string) bool {
+	return false
+}
@@ -0,0 +1,39 @@
+package logger
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+// TestJournaldModeEnabled проверяет определение запуска под systemd.
+func TestJournaldModeEnabled(t *testing.T) {
+	t.Setenv("JOURNAL_STREAM", "")
+	assert.False(t, JournaldAuto.enabled())
+	assert.True(t, JournaldOn.enabled())
+	assert.False(t, JournaldOff.enabled())
+
+	// Переменная унаследована, но stdout перенаправлен.
+	t.Setenv("JOURNAL_STREAM", "8:12345")
+	assert.False(t, JournaldAuto.enabled())
+	assert.False(t, JournaldOff.enabled())
+}
+
+// TestPriorityEncoder проверяет префиксы приоритета по уровням.
+func TestPriorityEncoder(t *testing.T) {
+	encoder := priorityEncoder{Encoder: zapcore.NewConsoleEncoder(zapcore.EncoderConfig{MessageKey: "message"})}
+
+	for level, want := range map[zapcore.Level]string{
+		zapcore.DebugLevel: "<7>message\n",
+		zapcore.InfoLevel:  "<6>message\n",
+		zapcore.WarnLevel:  "<4>message\n",
+		zapcore.ErrorLevel: "<3>message\n",
+	} {
+		buf, err := encoder.Clone().EncodeEntry(zapcore.Entry{Level: level, Message: "message"}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, want, buf.String())
+		buf.Free()
+	}
+}
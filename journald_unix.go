@@ -0,0 +1,32 @@
+//go:build darwin || dragonfly || freebsd || linux || netbsd || openbsd
+
+package logger
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+)
+
+// stdoutIsJournal сверяет устройство и inode stdout со значением
+// JOURNAL_STREAM ("dev:inode"). Переменная наследуется дочерними
+// процессами, поэтому одного её наличия мало: stdout мог быть
+// перенаправлен в файл или канал.
+func stdoutIsJournal(stream string) bool {
+	var dev, ino uint64
+	if _, err := fmt.Sscanf(stream, "%d:%d", &dev, &ino); err != nil {
+		return false
+	}
+
+	info, err := os.Stdout.Stat()
+	if err != nil {
+		return false
+	}
+
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return false
+	}
+
+	return uint64(stat.Dev) == dev && uint64(stat.Ino) == ino
+}
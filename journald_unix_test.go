@@ -0,0 +1,38 @@
+//go:build darwin || dragonfly || freebsd || linux || netbsd || openbsd
+
+package logger
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestJournaldModeStdoutStream проверяет сверку JOURNAL_STREAM с stdout.
+func TestJournaldModeStdoutStream(t *testing.T) {
+	stdout, err := os.Create(filepath.Join(t.TempDir(), "stdout"))
+	require.NoError(t, err)
+	defer stdout.Close()
+
+	oldStdout := os.Stdout
+	os.Stdout = stdout
+	defer func() { os.Stdout = oldStdout }()
+
+	info, err := stdout.Stat()
+	require.NoError(t, err)
+	stat := info.Sys().(*syscall.Stat_t)
+
+	t.Setenv("JOURNAL_STREAM", fmt.Sprintf("%d:%d", stat.Dev, stat.Ino))
+	assert.True(t, JournaldAuto.enabled())
+
+	t.Setenv("JOURNAL_STREAM", fmt.Sprintf("%d:%d", stat.Dev, stat.Ino+1))
+	assert.False(t, JournaldAuto.enabled())
+
+	t.Setenv("JOURNAL_STREAM", "journal")
+	assert.False(t, JournaldAuto.enabled())
+}
@@ -0,0 +1,169 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const kafkaBatchTimeout = 100 * time.Millisecond
+
+// KafkaKeyFunc вычисляет ключ сообщения Kafka по записи; fields содержит
+// поля записи вместе с полями WithFields. Записи с одинаковым ключом
+// попадают в одну партицию. nil-ключ распределяет записи равномерно.
+type KafkaKeyFunc func(entry zapcore.Entry, fields []zapcore.Field) []byte
+
+// KafkaOption настраивает KafkaSink.
+type KafkaOption func(*kafkaConfig)
+
+// KafkaKey задаёт вычисление ключа сообщения.
+func KafkaKey(fn KafkaKeyFunc) KafkaOption {
+	return func(c *kafkaConfig) {
+		c.key = fn
+	}
+}
+
+// KafkaKeyField использует ключом строковое значение поля name, например
+// идентификатор запроса или арендатора.
+func KafkaKeyField(name string) KafkaOption {
+	return KafkaKey(func(_ zapcore.Entry, fields []zapcore.Field) []byte {
+		for i := len(fields) - 1; i >= 0; i-- {
+			if fields[i].Key != name {
+				continue
+			}
+
+			if fields[i].Type == zapcore.StringType {
+				return []byte(fields[i].String)
+			}
+
+			enc := zapcore.NewMapObjectEncoder()
+			fields[i].AddTo(enc)
+
+			return []byte(fmt.Sprint(enc.Fields[name]))
+		}
+
+		return nil
+	})
+}
+
+type kafkaConfig struct {
+	brokers []string
+	topic   string
+	key     KafkaKeyFunc
+}
+
+// KafkaSink публикует каждую запись в JSON сообщением в топик topic.
+// Сообщения отправляются асинхронно пачками; ошибки доставки попадают в
+// Diagnostics. Неотправленные сообщения дописываются в Close.
+func KafkaSink(brokers []string, topic string, options ...KafkaOption) Option {
+	return func(l *Logger) {
+		if len(brokers) == 0 || topic == "" {
+			l.optionErrs = append(l.optionErrs, fmt.Errorf("logger: KafkaSink requires brokers and topic"))
+			return
+		}
+
+		cfg := kafkaConfig{brokers: brokers, topic: topic}
+		for _, option := range options {
+			option(&cfg)
+		}
+
+		l.kafka = append(l.kafka, cfg)
+	}
+}
+
+// kafkaWriter — часть kafka.Writer, используемая ядром.
+type kafkaWriter interface {
+	WriteMessages(ctx context.Context, msgs ...kafka.Message) error
+	Close() error
+}
+
+func (l *Logger) newKafkaCore(cfg kafkaConfig, encoderCfg zapcore.EncoderConfig, lvl zapcore.LevelEnabler) zapcore.Core {
+	diag := l.diag
+
+	writer := &kafka.Writer{
+		Addr:         kafka.TCP(cfg.brokers...),
+		Topic:        cfg.topic,
+		Balancer:     &kafka.Hash{},
+		BatchTimeout: kafkaBatchTimeout,
+		RequiredAcks: kafka.RequireOne,
+		Async:        true,
+		Completion: func(messages []kafka.Message, err error) {
+			if err != nil {
+				diagLogger(diag).Warn("kafka write failed",
+					zap.String("topic", cfg.topic),
+					zap.Int("messages", len(messages)),
+					zap.Error(err),
+				)
+			}
+		},
+	}
+
+	l.closers = append(l.closers, writer)
+
+	return &kafkaCore{
+		LevelEnabler: lvl,
+		enc:          zapcore.NewJSONEncoder(encoderCfg),
+		writer:       writer,
+		key:          cfg.key,
+	}
+}
+
+// kafkaCore кодирует записи и передаёт их продюсеру Kafka.
+type kafkaCore struct {
+	zapcore.LevelEnabler
+	enc    zapcore.Encoder
+	writer kafkaWriter
+	key    KafkaKeyFunc
+	fields []zapcore.Field
+}
+
+func (c *kafkaCore) With(fields []zapcore.Field) zapcore.Core {
+	enc := c.enc.Clone()
+	for _, field := range fields {
+		field.AddTo(enc)
+	}
+
+	return &kafkaCore{
+		LevelEnabler: c.LevelEnabler,
+		enc:          enc,
+		writer:       c.writer,
+		key:          c.key,
+		fields:       append(c.fields[:len(c.fields):len(c.fields)], fields...),
+	}
+}
+
+func (c *kafkaCore) Check(entry zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return ce.AddCore(entry, c)
+	}
+
+	return ce
+}
+
+func (c *kafkaCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	buf, err := c.enc.EncodeEntry(entry, fields)
+	if err != nil {
+		return err
+	}
+
+	msg := kafka.Message{
+		Value: bytes.Clone(bytes.TrimSuffix(buf.Bytes(), []byte("\n"))),
+		Time:  entry.Time,
+	}
+	buf.Free()
+
+	if c.key != nil {
+		msg.Key = c.key(entry, append(c.fields[:len(c.fields):len(c.fields)], fields...))
+	}
+
+	return c.writer.WriteMessages(context.Background(), msg)
+}
+
+func (c *kafkaCore) Sync() error {
+	return nil
+}
@@ -0,0 +1,67 @@
+package logger
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+type fakeKafkaWriter struct {
+	mu       sync.Mutex
+	messages []kafka.Message
+}
+
+func (w *fakeKafkaWriter) WriteMessages(_ context.Context, msgs ...kafka.Message) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.messages = append(w.messages, msgs...)
+
+	return nil
+}
+
+func (w *fakeKafkaWriter) Close() error {
+	return nil
+}
+
+// TestKafkaCore проверяет сообщения и ключи, вычисленные по полям.
+func TestKafkaCore(t *testing.T) {
+	var cfg kafkaConfig
+	KafkaKeyField("tenant")(&cfg)
+
+	writer := &fakeKafkaWriter{}
+	core := &kafkaCore{
+		LevelEnabler: zapcore.InfoLevel,
+		enc:          zapcore.NewJSONEncoder(zapcore.EncoderConfig{MessageKey: "message"}),
+		writer:       writer,
+		key:          cfg.key,
+	}
+
+	logger := zap.New(core)
+	logger.With(zap.String("tenant", "t1")).Info("first", zap.Int("n", 1))
+	logger.Info("second", zap.Int("tenant", 42))
+	logger.Info("third")
+	logger.Debug("skipped")
+
+	require.Len(t, writer.messages, 3)
+	assert.Equal(t, []byte("t1"), writer.messages[0].Key)
+	assert.Equal(t, []byte("42"), writer.messages[1].Key)
+	assert.Nil(t, writer.messages[2].Key)
+
+	var record map[string]interface{}
+	require.NoError(t, json.Unmarshal(writer.messages[0].Value, &record))
+	assert.Equal(t, map[string]interface{}{"message": "first", "tenant": "t1", "n": float64(1)}, record)
+}
+
+// TestKafkaSinkInvalid проверяет ошибку Build без брокеров.
+func TestKafkaSinkInvalid(t *testing.T) {
+	_, err := Build(Path(t.TempDir()), KafkaSink(nil, "logs"))
+	assert.ErrorContains(t, err, "KafkaSink requires brokers and topic")
+}
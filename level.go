@@ -0,0 +1,116 @@
+package logger
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// SetLevel меняет уровень всех выводов работающего логгера и его копий
+// WithFields. Действующее временное повышение (EscalateLevel) при этом
+// отменяется. Назначения Outputs с собственным уровнем в URL не меняются.
+func (l *Logger) SetLevel(level string) error {
+	target, exist := loggerLevelMap[level]
+	if !exist {
+		return fmt.Errorf("logger: unknown level %q", level)
+	}
+
+	if l.escalation == nil {
+		return fmt.Errorf("logger: not initialized")
+	}
+
+	e := l.escalation
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.timer != nil {
+		e.timer.Stop()
+		e.timer = nil
+	}
+
+	// Уровень хранится только в atomicLevel: копии WithFields и Named
+	// читают поля логгера без блокировок.
+	l.atomicLevel.SetLevel(target)
+
+	return nil
+}
+
+// Enabled сообщает, пишется ли уровень level ("debug", "info" и т. д.)
+// хотя бы одним выводом, чтобы не готовить дорогие данные для отключённых
+// записей. Неизвестный уровень считается выключенным.
+func (l *Logger) Enabled(level string) bool {
+	target, exist := loggerLevelMap[level]
+	if !exist || l.baseLogger == nil {
+		return false
+	}
+
+	return l.baseLogger.Core().Enabled(target)
+}
+
+type levelPayload struct {
+	Level string `json:"level"`
+}
+
+type levelError struct {
+	Error string `json:"error"`
+}
+
+// LevelHandler возвращает обработчик HTTP для чтения и смены уровня,
+// совместимый с zap.AtomicLevel.ServeHTTP: GET возвращает {"level":"info"},
+// PUT принимает такой же JSON или поле формы level и вызывает SetLevel.
+func (l *Logger) LevelHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		enc := json.NewEncoder(w)
+
+		if l.escalation == nil {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_ = enc.Encode(levelError{Error: "logger: not initialized"})
+			return
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+		case http.MethodPut:
+			level, err := decodeLevelRequest(r)
+			if err == nil {
+				err = l.SetLevel(level)
+			}
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				_ = enc.Encode(levelError{Error: err.Error()})
+				return
+			}
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			_ = enc.Encode(levelError{Error: "Only GET and PUT are supported."})
+			return
+		}
+
+		_ = enc.Encode(levelPayload{Level: l.atomicLevel.Level().String()})
+	})
+}
+
+func decodeLevelRequest(r *http.Request) (string, error) {
+	if r.Header.Get("Content-Type") == "application/x-www-form-urlencoded" {
+		level := r.FormValue("level")
+		if level == "" {
+			return "", errors.New("logger: must specify logging level")
+		}
+
+		return level, nil
+	}
+
+	var payload levelPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		return "", fmt.Errorf("logger: malformed request body: %w", err)
+	}
+
+	if payload.Level == "" {
+		return "", errors.New("logger: must specify logging level")
+	}
+
+	return payload.Level, nil
+}
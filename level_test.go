@@ -0,0 +1,133 @@
+package logger
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+// TestSetLevel проверяет смену уровня работающего логгера.
+func TestSetLevel(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	logger := NewLogger(Path(tmpDir), Level("info"))
+	require.NoError(t, logger.Init(false))
+
+	child := logger.WithFields(map[string]interface{}{"request": "r1"})
+
+	assert.False(t, child.Enabled("debug"))
+	assert.True(t, child.Enabled("info"))
+	assert.False(t, child.Enabled("verbose"))
+
+	child.Debug("hidden debug")
+	require.NoError(t, logger.SetLevel("debug"))
+	assert.True(t, child.Enabled("debug"))
+	child.Debug("visible debug")
+
+	assert.Error(t, logger.SetLevel("verbose"))
+	require.NoError(t, logger.Close())
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, time.Now().Format(dateLayout)+".log"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(content), "hidden debug")
+	assert.Contains(t, string(content), "visible debug")
+}
+
+// TestSetLevelCancelsEscalation проверяет, что явный уровень не
+// перезаписывается окончанием повышения.
+func TestSetLevelCancelsEscalation(t *testing.T) {
+	logger := NewLogger(Path(t.TempDir()), Level("warn"))
+	require.NoError(t, logger.Init(false))
+	defer logger.Close()
+
+	restore, err := logger.EscalateLevel("debug", time.Hour)
+	require.NoError(t, err)
+
+	require.NoError(t, logger.SetLevel("info"))
+	restore()
+
+	assert.Equal(t, zapcore.InfoLevel, logger.atomicLevel.Level())
+}
+
+// TestSetLevelConcurrentCopies проверяет смену уровня одновременно с
+// созданием копий логгера (запускается с -race).
+func TestSetLevelConcurrentCopies(t *testing.T) {
+	logger := NewLogger(Path(t.TempDir()))
+	require.NoError(t, logger.Init(false))
+	defer logger.Close()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			assert.NoError(t, logger.SetLevel("debug"))
+		}
+	}()
+
+	for i := 0; i < 100; i++ {
+		logger.Named("api").WithFields(map[string]interface{}{"i": i}).Debug("copy")
+	}
+	<-done
+
+	assert.Equal(t, zapcore.DebugLevel, logger.atomicLevel.Level())
+}
+
+// TestSetLevelNotInitialized проверяет ошибку до инициализации.
+func TestSetLevelNotInitialized(t *testing.T) {
+	assert.Error(t, NewLogger().SetLevel("debug"))
+}
+
+// TestLevelHandler проверяет чтение и смену уровня по HTTP.
+func TestLevelHandler(t *testing.T) {
+	logger := NewLogger(Path(t.TempDir()), Level("info"))
+	require.NoError(t, logger.Init(false))
+	defer logger.Close()
+
+	server := httptest.NewServer(logger.LevelHandler())
+	defer server.Close()
+
+	do := func(method, contentType, body string) (int, string) {
+		req, err := http.NewRequest(method, server.URL, strings.NewReader(body))
+		require.NoError(t, err)
+		if contentType != "" {
+			req.Header.Set("Content-Type", contentType)
+		}
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		data, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+
+		return resp.StatusCode, strings.TrimSpace(string(data))
+	}
+
+	status, body := do(http.MethodGet, "", "")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, `{"level":"info"}`, body)
+
+	status, body = do(http.MethodPut, "application/json", `{"level":"debug"}`)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, `{"level":"debug"}`, body)
+	assert.Equal(t, zapcore.DebugLevel, logger.atomicLevel.Level())
+
+	status, body = do(http.MethodPut, "application/x-www-form-urlencoded", "level=warn")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, `{"level":"warn"}`, body)
+
+	status, _ = do(http.MethodPut, "application/json", `{"level":"verbose"}`)
+	assert.Equal(t, http.StatusBadRequest, status)
+
+	status, _ = do(http.MethodPost, "", "")
+	assert.Equal(t, http.StatusMethodNotAllowed, status)
+}
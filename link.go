@@ -0,0 +1,70 @@
+package logger
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// defaultCurrentLink — имя ссылки на текущий файл по умолчанию.
+const defaultCurrentLink = "current.log"
+
+// CurrentLink поддерживает в каталоге логов ссылку name (по умолчанию
+// "current.log") на текущий файл, обновляя её после каждой ротации, чтобы
+// "tail -F current.log" продолжал работать при смене файла. Создаётся
+// символическая ссылка, а где это недоступно (Windows без прав) — жёсткая.
+func CurrentLink(name string) Option {
+	return func(l *Logger) {
+		if name == "" {
+			name = defaultCurrentLink
+		}
+
+		if strings.ContainsAny(name, `/\`) {
+			l.optionErrs = append(l.optionErrs, fmt.Errorf("logger: current link %q must not contain path separators", name))
+			return
+		}
+
+		l.currentLink = name
+	}
+}
+
+// currentLinkName возвращает имя ссылки; ссылка поддерживается только
+// в файловой системе ОС.
+func (l *Logger) currentLinkName() string {
+	if l.fsys != nil {
+		return ""
+	}
+
+	return l.currentLink
+}
+
+// updateCurrentLink направляет ссылку на target. Новая ссылка создаётся
+// под временным именем и переименовывается поверх старой, поэтому
+// читатели не видят момента без ссылки.
+func (r *fileRotator) updateCurrentLink(target string) {
+	link := filepath.Join(r.dir(), r.currentLink)
+	tmp := link + tempExt
+
+	rel, err := filepath.Rel(r.dir(), target)
+	if err != nil {
+		rel = target
+	}
+
+	_ = os.Remove(tmp)
+
+	if err = os.Symlink(rel, tmp); err != nil {
+		err = os.Link(target, tmp)
+	}
+
+	if err == nil {
+		err = r.retry.do(func() error { return os.Rename(tmp, link) })
+	}
+
+	if err != nil {
+		_ = os.Remove(tmp)
+		r.diagnostics().Warn("cannot update current link", zap.String("link", link), zap.Error(err))
+	}
+}
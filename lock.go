@@ -0,0 +1,41 @@
+package logger
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+const lockFileName = ".lock"
+
+// fileLock — межпроцессная рекомендательная блокировка каталога логов.
+// Блокировка принадлежит открытому дескриптору, поэтому горутины, которым
+// нужна собственная блокировка, открывают отдельный fileLock.
+type fileLock struct {
+	file *os.File
+}
+
+func openFileLock(dir string, perm fs.FileMode) (*fileLock, error) {
+	file, err := os.OpenFile(filepath.Join(dir, lockFileName), os.O_CREATE|os.O_RDWR, perm)
+	if err != nil {
+		return nil, err
+	}
+
+	return &fileLock{file: file}, nil
+}
+
+func (l *fileLock) lock() error {
+	return lockFile(l.file, true)
+}
+
+func (l *fileLock) rlock() error {
+	return lockFile(l.file, false)
+}
+
+func (l *fileLock) unlock() error {
+	return unlockFile(l.file)
+}
+
+func (l *fileLock) close() error {
+	return l.file.Close()
+}
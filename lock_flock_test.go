@@ -0,0 +1,67 @@
+//go:build darwin || dragonfly || freebsd || linux || netbsd || openbsd
+
+package logger
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestFileLockExclusive проверяет, что эксклюзивная блокировка ждёт освобождения разделяемой.
+func TestFileLockExclusive(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "logger_test")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	shared, err := openFileLock(tmpDir, defaultFileMode)
+	require.NoError(t, err)
+	defer shared.close()
+
+	require.NoError(t, shared.rlock())
+
+	rotator := &fileRotator{path: tmpDir, locking: true}
+
+	acquired := make(chan struct{})
+	go func() {
+		unlock, err := rotator.lockExclusive()
+		if assert.NoError(t, err) {
+			unlock()
+		}
+		close(acquired)
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatal("Exclusive lock should wait for shared lock release")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	require.NoError(t, shared.unlock())
+
+	select {
+	case <-acquired:
+	case <-time.After(time.Second):
+		t.Fatal("Exclusive lock should be acquired after shared lock release")
+	}
+}
+
+// TestFileRotatorLockingWrite проверяет запись с включённой блокировкой.
+func TestFileRotatorLockingWrite(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "logger_test")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	rotator := &fileRotator{path: tmpDir, locking: true}
+
+	_, err = rotator.Write([]byte("test log"))
+	require.NoError(t, err)
+	require.NoError(t, rotator.Close())
+
+	_, err = os.Stat(filepath.Join(tmpDir, lockFileName))
+	assert.NoError(t, err, "Lock file should be created")
+}
@@ -0,0 +1,16 @@
+//go:build !(darwin || dragonfly || freebsd || linux || netbsd || openbsd || windows)
+
+package logger
+
+import "os"
+
+// На платформах без flock блокировка не выполняется: процессы, пишущие в
+// один каталог, полагаются только на O_APPEND.
+
+func lockFile(_ *os.File, _ bool) error {
+	return nil
+}
+
+func unlockFile(_ *os.File) error {
+	return nil
+}
@@ -0,0 +1,46 @@
+package logger
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+var (
+	modkernel32      = syscall.NewLazyDLL("kernel32.dll")
+	procLockFileEx   = modkernel32.NewProc("LockFileEx")
+	procUnlockFileEx = modkernel32.NewProc("UnlockFileEx")
+)
+
+const (
+	lockfileExclusiveLock = 0x2
+	// Блокируется весь диапазон файла, как у flock.
+	lockRange = 0xffffffff
+)
+
+func lockFile(file *os.File, exclusive bool) error {
+	var flags uintptr
+	if exclusive {
+		flags = lockfileExclusiveLock
+	}
+
+	overlapped := new(syscall.Overlapped)
+
+	r1, _, err := procLockFileEx.Call(file.Fd(), flags, 0, lockRange, lockRange, uintptr(unsafe.Pointer(overlapped)))
+	if r1 == 0 {
+		return err
+	}
+
+	return nil
+}
+
+func unlockFile(file *os.File) error {
+	overlapped := new(syscall.Overlapped)
+
+	r1, _, err := procUnlockFileEx.Call(file.Fd(), 0, lockRange, lockRange, uintptr(unsafe.Pointer(overlapped)))
+	if r1 == 0 {
+		return err
+	}
+
+	return nil
+}
@@ -1,7 +1,14 @@
 package logger
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
 	"os"
+	"regexp"
+	"sync/atomic"
 	"time"
 
 	"go.uber.org/zap"
@@ -12,13 +19,135 @@ type Logger struct {
 	path        string
 	level       string
 	structured  bool
+	format      string
+	dateDirs    bool
+	appName     string
+	filename    string
+	maxSize     int64
+	maxSegments int
+	maxAge      time.Duration
+	maxBackups  int
+	noCompress  bool
+	segmentMode SegmentLimitPolicy
+	rotateAt    string
+	interval    time.Duration
+	fileLock    bool
+	fileMode    fs.FileMode
+	dirMode     fs.FileMode
+	currentLink string
+	socketPath  string
+	outputs     []string
+	retry       retryPolicy
+	failTestAt  string
+	sanitize    SanitizeMode
+	schemaMode  SchemaMode
+
+	schemaVersion string
+	sampling      map[zapcore.Level]samplingRate
+	verbose       VerboseFunc
+	overrides     map[string]zapcore.Level
+	parallelQueue int
+	extractors    []ContextExtractor
+	traceIDKey    *string
+	spanIDKey     *string
+	kafka         []kafkaConfig
+	sentry        *sentryTarget
+	otelSeverity  bool
+
+	redactFields   map[string]bool
+	redactPatterns []*regexp.Regexp
+	hooks          []Hook
+	siem           *siemConfig
+
+	// redaction и sampler держат правила маскирования и сэмплирования,
+	// которые ReloadConfig меняет на ходу.
+	redaction *atomic.Pointer[redactor]
+	sampler   *samplingSwitch
+	reload    *reloadState
+	config    *Config
+
+	// redactBase — правила маскирования из опций NewFromConfig без правил
+	// конфигурации.
+	redactBase *redactor
+
+	syslogFields   bool
+	syslogFacility SyslogFacility
+
+	stripFileANSI    bool
+	uploader         ArchiveUploader
+	removeUploaded   bool
+	onRotate         func(oldPath, newPath string)
+	onCompressError  func(path string, err error)
+	splitByLevel     bool
+	maxTotalSize     int64
+	auditPath        string
+	rateLimit        float64
+	rateBurst        int
+	rateLimitKey     RateLimitKeyFunc
+	asyncSize        int
+	asyncPolicy      DropPolicy
+	fallbacks        []string
+	onWriteError     func(err error)
+	structuredErrors bool
+	runtimeInfo      bool
+
+	clock Clock
+	fsys  FS
+
+	diagOutput io.Writer
+	diag       *zap.Logger
+
+	metrics Metrics
+	stats   *loggerStats
+
+	consoleEncoderCfg    []func(*zapcore.EncoderConfig)
+	fileEncoderCfg       []func(*zapcore.EncoderConfig)
+	baseEncoderCfg       *zapcore.EncoderConfig
+	encoderKeys          []EncoderKeys
+	timeLayout           string
+	utc                  bool
+	consoleMode          ConsoleMode
+	journaldMode         JournaldMode
+	consoleBadges        BadgeStyle
+	consoleBufferSize    int
+	fileBufferSize       int
+	fileFlushInterval    time.Duration
+	stderrLevel          *zapcore.Level
+	stacktraceLevel      *zapcore.Level
+	noStacktrace         bool
+	development          bool
+	consoleFlushInterval time.Duration
+	callerSkip           int
+	noCaller             bool
+	callerPrefixes       []string
+
 	baseLogger  *zap.Logger
 	sugarLogger *zap.SugaredLogger
 	rotator     *fileRotator
+	closers     []io.Closer
+
+	// errorRotator принимает записи уровня error и выше при SplitByLevel.
+	errorRotator *fileRotator
+
+	audit        *zap.Logger
+	asyncWriters []*asyncWriter
+
+	optionErrs []error
+
+	atomicLevel zap.AtomicLevel
+	escalation  *levelEscalation
+	gate        *levelGate
+
+	fileEncoder zapcore.Encoder
+	fileWriter  zapcore.WriteSyncer
+	fileLevel   zapcore.LevelEnabler
+	fields      []zap.Field
 }
 
 type Option func(*Logger)
 
+// Path задаёт каталог логов. Путь может содержать токены {exe_dir},
+// {home} и {tmp}, которые раскрываются при инициализации.
 func Path(path string) Option {
 	return func(l *Logger) {
 		l.path = path
@@ -28,6 +157,7 @@ func Path(path string) Option {
 func Level(level string) Option {
 	return func(l *Logger) {
 		if _, exist := loggerLevelMap[level]; !exist {
+			l.optionErrs = append(l.optionErrs, fmt.Errorf("logger: unknown level %q", level))
 			level = "info"
 		}
 		l.level = level
@@ -40,6 +170,396 @@ func Structured(enable bool) Option {
 	}
 }
 
+// DateDirs раскладывает файлы логов по подкаталогам ГГГГ/ММ, например
+// logs/2024/05/2024_05_28.log.
+func DateDirs(enable bool) Option {
+	return func(l *Logger) {
+		l.dateDirs = enable
+	}
+}
+
+// AppName задаёт имя приложения для токена {app} в шаблоне имени файла.
+// По умолчанию используется имя исполняемого файла.
+func AppName(name string) Option {
+	return func(l *Logger) {
+		l.appName = name
+	}
+}
+
+// FilenamePattern задаёт шаблон имени файла логов. Шаблон обязан содержать
+// {date} и может содержать {app}, {host} (или {hostname}) и {pid}, например
+// "{app}-{host}-{date}.log". Формат даты задаётся элементами времени Go:
+// "app-{date:2006-01-02T15}.log". Файлы с {pid} других процессов логгер
+// не дописывает, не сжимает и не загружает; прошлые из них удаляет только
+// очистка (MaxAge, MaxBackups, MaxTotalSize).
+func FilenamePattern(pattern string) Option {
+	return func(l *Logger) {
+		l.filename = pattern
+	}
+}
+
+// Console задаёт формат консольного вывода. По умолчанию (ConsoleAuto) в
+// терминал выводится цветной текст, а при перенаправлении — простой.
+func Console(mode ConsoleMode) Option {
+	return func(l *Logger) {
+		l.consoleMode = mode
+	}
+}
+
+// Color включает (Console(ConsoleColor)) или отключает (Console(ConsolePlain))
+// цветной текстовый консольный вывод: уровни выделяются цветом, имена
+// логгеров Named — постоянным для каждого имени цветом. Файловый вывод не
+// меняется.
+func Color(enable bool) Option {
+	if enable {
+		return Console(ConsoleColor)
+	}
+
+	return Console(ConsolePlain)
+}
+
+// LevelBadges включает в текстовом консольном выводе компактные
+// обозначения уровней и выравнивание колонки caller.
+func LevelBadges(style BadgeStyle) Option {
+	return func(l *Logger) {
+		l.consoleBadges = style
+	}
+}
+
+// ConsoleStderrLevel направляет консольные записи уровня level и выше в
+// stderr, а остальные — в stdout. Например, с "error" предупреждения
+// выводятся в stdout, а ошибки — в stderr, как ожидают платформы
+// контейнеров. Неизвестный уровень игнорируется (Build возвращает ошибку).
+func ConsoleStderrLevel(level string) Option {
+	return func(l *Logger) {
+		lvl, exist := loggerLevelMap[level]
+		if !exist {
+			l.optionErrs = append(l.optionErrs, fmt.Errorf("logger: unknown stderr level %q", level))
+			return
+		}
+
+		l.stderrLevel = &lvl
+	}
+}
+
+// defaultConsoleFlushInterval — период сброса буфера консоли, если
+// ConsoleBuffer получил нулевой interval.
+const defaultConsoleFlushInterval = time.Second
+
+// ConsoleBuffer включает буферизацию консольного вывода: записи
+// накапливаются до size байт и сбрасываются не реже чем раз в interval
+// (interval 0 — раз в секунду), что сокращает число системных вызовов при
+// выводе через среду выполнения контейнеров. Неположительный size или
+// отрицательный interval — ошибка (Build и Init её возвращают).
+func ConsoleBuffer(size int, interval time.Duration) Option {
+	return func(l *Logger) {
+		if size <= 0 || interval < 0 {
+			l.optionErrs = append(l.optionErrs, fmt.Errorf("logger: invalid console buffer: size %d, interval %s", size, interval))
+			return
+		}
+
+		if interval == 0 {
+			interval = defaultConsoleFlushInterval
+		}
+
+		l.consoleBufferSize = size
+		l.consoleFlushInterval = interval
+	}
+}
+
+// FileBuffer включает буферизацию записи в файл: записи накапливаются до
+// size байт и сбрасываются не реже чем раз в interval, а также при Sync и
+// Close (interval 0 — раз в 30 секунд). Сокращает число системных вызовов
+// при частых мелких записях; при аварийном завершении процесса теряется
+// не больше содержимого буфера.
+func FileBuffer(size int, interval time.Duration) Option {
+	return func(l *Logger) {
+		l.fileBufferSize = size
+		l.fileFlushInterval = interval
+	}
+}
+
+// RotateAt задаёт время суток ротации в формате "ЧЧ:ММ" вместо полуночи.
+// Файл получает дату начала своих суток.
+func RotateAt(clock string) Option {
+	return func(l *Logger) {
+		l.rotateAt = clock
+	}
+}
+
+// RotationInterval включает ротацию чаще раза в сутки: каждый час или
+// каждые N минут, например 15*time.Minute. Интервал должен делить сутки
+// на равные части; отсчёт ведётся от полуночи (или от RotateAt). В {date}
+// добавляются час и при необходимости минуты: 2024_05_28_13.log.
+func RotationInterval(interval time.Duration) Option {
+	return func(l *Logger) {
+		l.interval = interval
+	}
+}
+
+// MaxSize включает ротацию по размеру: когда файл превышает size байт,
+// открывается следующий сегмент того же дня (2024_05_01.1.log и т.д.).
+func MaxSize(size int64) Option {
+	return func(l *Logger) {
+		l.maxSize = size
+	}
+}
+
+// MaxSegmentsPerDay ограничивает число файлов за день при ротации по
+// размеру. После исчерпания лимита запись продолжается в последний файл,
+// а policy определяет, отбрасываются ли записи уровня debug.
+func MaxSegmentsPerDay(n int, policy SegmentLimitPolicy) Option {
+	return func(l *Logger) {
+		l.maxSegments = n
+		l.segmentMode = policy
+	}
+}
+
+// SplitByLevel записывает записи уровня error и выше в отдельный файл с
+// суффиксом ".error" перед расширением (2024_05_01.error.log), а
+// остальные — в обычный файл. У файла ошибок собственная ротация.
+// С SocketOutput не действует.
+func SplitByLevel(enable bool) Option {
+	return func(l *Logger) {
+		l.splitByLevel = enable
+	}
+}
+
+// OnRotate вызывает fn после каждой ротации с путями закрытого и нового
+// файлов. fn выполняется в отдельной горутине, поэтому может писать в
+// этот же логгер; при включённом сжатии закрытый файл вскоре заменяется
+// архивом oldPath+".zip".
+func OnRotate(fn func(oldPath, newPath string)) Option {
+	return func(l *Logger) {
+		l.onRotate = fn
+	}
+}
+
+// OnCompressError вызывает fn при ошибке фонового сжатия файла path.
+// Файл при этом остаётся несжатым и досжимается при следующем запуске.
+func OnCompressError(fn func(path string, err error)) Option {
+	return func(l *Logger) {
+		l.onCompressError = fn
+	}
+}
+
+// FileLock включает межпроцессную блокировку каталога логов (flock), чтобы
+// несколько процессов с одним путём не ротировали и не сжимали файлы
+// одновременно. Запись в файл всегда выполняется с O_APPEND.
+func FileLock(enable bool) Option {
+	return func(l *Logger) {
+		l.fileLock = enable
+	}
+}
+
+// FileMode задаёт права создаваемых файлов логов и архивов, по умолчанию
+// 0666. DirMode задаёт права создаваемых каталогов, по умолчанию 0777.
+// Как и в os.OpenFile, права ограничиваются umask процесса; права
+// существующих файлов и каталогов не меняются.
+func FileMode(mode fs.FileMode) Option {
+	return func(l *Logger) {
+		l.fileMode = mode
+	}
+}
+
+func DirMode(mode fs.FileMode) Option {
+	return func(l *Logger) {
+		l.dirMode = mode
+	}
+}
+
+const (
+	defaultFileMode fs.FileMode = 0666
+	defaultDirMode  fs.FileMode = 0777
+)
+
+func (l *Logger) filePerm() fs.FileMode {
+	if l.fileMode == 0 {
+		return defaultFileMode
+	}
+
+	return l.fileMode
+}
+
+func (l *Logger) dirPerm() fs.FileMode {
+	if l.dirMode == 0 {
+		return defaultDirMode
+	}
+
+	return l.dirMode
+}
+
+// SocketOutput направляет файловый вывод в Unix-сокет процесса, который
+// владеет файлом и ротацией (см. ListenSocket), вместо собственного файла.
+func SocketOutput(path string) Option {
+	return func(l *Logger) {
+		l.socketPath = path
+	}
+}
+
+// SharingViolationRetry задаёт число повторов и паузу между ними для
+// открытия, переименования и удаления файлов логов, завершившихся
+// нарушением совместного доступа в Windows. На других платформах такие
+// ошибки не возникают и опция ни на что не влияет.
+func SharingViolationRetry(attempts int, delay time.Duration) Option {
+	return func(l *Logger) {
+		l.retry = retryPolicy{attempts: attempts, delay: delay}
+	}
+}
+
+// Format выбирает формат файлового вывода по имени: встроенные "console",
+// "json", "gelf", "ecs", "cef" и "leef" или зарегистрированный через
+// RegisterEncoder. Имеет приоритет над Structured.
+func Format(name string) Option {
+	return func(l *Logger) {
+		l.format = name
+	}
+}
+
+const defaultTimeLayout = "2006-01-02 15:04:05"
+
+// TimeLayout задаёт формат времени записей консоли, файла и Outputs в
+// нотации пакета time, например time.RFC3339Nano для ISO 8601 со смещением
+// часового пояса. По умолчанию "2006-01-02 15:04:05".
+func TimeLayout(layout string) Option {
+	return func(l *Logger) {
+		if layout == "" {
+			l.optionErrs = append(l.optionErrs, fmt.Errorf("logger: time layout must not be empty"))
+			return
+		}
+
+		l.timeLayout = layout
+	}
+}
+
+// UTC включает запись времени в UTC вместо местного часового пояса.
+func UTC(enable bool) Option {
+	return func(l *Logger) {
+		l.utc = enable
+	}
+}
+
+// ConsoleEncoderConfig изменяет настройки кодировщика консольного вывода,
+// не затрагивая файл, например формат времени или имена ключей JSON при
+// Console(ConsoleJSON). Цвет и бейджи уровней применяются поверх.
+func ConsoleEncoderConfig(fn func(cfg *zapcore.EncoderConfig)) Option {
+	return func(l *Logger) {
+		l.consoleEncoderCfg = append(l.consoleEncoderCfg, fn)
+	}
+}
+
+// FileEncoderConfig изменяет настройки кодировщика файла и Outputs, не
+// затрагивая консоль.
+func FileEncoderConfig(fn func(cfg *zapcore.EncoderConfig)) Option {
+	return func(l *Logger) {
+		l.fileEncoderCfg = append(l.fileEncoderCfg, fn)
+	}
+}
+
+// Development включает режим разработки zap: DPanic вызывает панику.
+// Как пресет для локального запуска Development(true) также задаёт
+// уровень debug, цветной консольный вывод, caller и стек начиная с warn;
+// опции после неё могут изменить эти настройки.
+func Development(enable bool) Option {
+	return func(l *Logger) {
+		l.development = enable
+		if !enable {
+			return
+		}
+
+		warn := zapcore.WarnLevel
+		l.level = "debug"
+		l.consoleMode = ConsoleColor
+		l.noCaller = false
+		l.stacktraceLevel = &warn
+	}
+}
+
+// StacktraceLevel добавляет стек вызовов к записям уровня level и выше,
+// например "error" при разработке и "panic" в продакшене. По умолчанию
+// стек не записывается.
+func StacktraceLevel(level string) Option {
+	return func(l *Logger) {
+		lvl, exist := loggerLevelMap[level]
+		if !exist {
+			l.optionErrs = append(l.optionErrs, fmt.Errorf("logger: unknown stacktrace level %q", level))
+			return
+		}
+
+		l.stacktraceLevel = &lvl
+	}
+}
+
+// DisableStacktrace отключает запись стека вызовов независимо от
+// StacktraceLevel.
+func DisableStacktrace() Option {
+	return func(l *Logger) {
+		l.noStacktrace = true
+	}
+}
+
+// Outputs добавляет назначения записей в виде URL: "stdout", "stderr",
+// "file:///var/log/app", "tcp://collector:5000" или схемы, добавленные
+// через RegisterSink. Параметры level и format в строке запроса задают
+// уровень и формат назначения, например "stderr?level=warn"; по умолчанию
+// используются уровень и формат файлового вывода. Назначения закрываются
+// в Close.
+func Outputs(urls ...string) Option {
+	return func(l *Logger) {
+		l.outputs = append(l.outputs, urls...)
+	}
+}
+
+// NetworkSink добавляет назначение proto://addr, где proto — "tcp" или
+// "udp". Пока сборщик недоступен, записи копятся в буфере и отправляются
+// после переподключения, не задерживая логирование.
+func NetworkSink(proto, addr string) Option {
+	return func(l *Logger) {
+		if proto != "tcp" && proto != "udp" {
+			l.optionErrs = append(l.optionErrs, fmt.Errorf("logger: unsupported network %q", proto))
+			return
+		}
+
+		l.outputs = append(l.outputs, proto+"://"+addr)
+	}
+}
+
+// SanitizeControl включает удаление или экранирование ANSI-последовательностей
+// и управляющих символов (включая переводы строк) в сообщениях и строковых
+// полях, защищая от подделки записей через пользовательский ввод.
+func SanitizeControl(mode SanitizeMode) Option {
+	return func(l *Logger) {
+		l.sanitize = mode
+	}
+}
+
+// StripFileANSI удаляет ANSI-последовательности из сообщений и строковых
+// полей во всех выводах, кроме консоли: цвета в терминале сохраняются, а
+// файлы остаются чистым текстом, даже если сообщения уже содержат цвета
+// вывода дочерних процессов.
+func StripFileANSI(enable bool) Option {
+	return func(l *Logger) {
+		l.stripFileANSI = enable
+	}
+}
+
+// WithClock задаёт источник времени для ротации файлов и меток времени
+// записей.
+func WithClock(clock Clock) Option {
+	return func(l *Logger) {
+		l.clock = clock
+	}
+}
+
+// WithFS задаёт файловую систему для ротации, сжатия и очистки файлов.
+// Межпроцессная блокировка (FileLock) работает только с файловой системой
+// ОС и с другой FS отключается.
+func WithFS(fsys FS) Option {
+	return func(l *Logger) {
+		l.fsys = fsys
+	}
+}
+
 func BaseLogger(baseLogger *zap.Logger) Option {
 	return func(l *Logger) {
 		l.baseLogger = baseLogger
@@ -52,6 +572,8 @@ func NewLogger(options ...Option) *Logger {
 		path:       "",
 		level:      "info",
 		structured: false,
+		retry:      defaultRetryPolicy,
+		stats:      &loggerStats{},
 	}
 
 	for _, option := range options {
@@ -71,6 +593,31 @@ var loggerLevelMap = map[string]zapcore.Level{
 	"fatal":  zapcore.FatalLevel,
 }
 
+func (l *Logger) fileFormat() string {
+	switch {
+	case l.format != "":
+		return l.format
+	case l.structured:
+		return "json"
+	default:
+		return "console"
+	}
+}
+
+// encodeTime записывает время в формате TimeLayout с учётом UTC.
+func (l *Logger) encodeTime(t time.Time, pae zapcore.PrimitiveArrayEncoder) {
+	layout := l.timeLayout
+	if layout == "" {
+		layout = defaultTimeLayout
+	}
+
+	if l.utc {
+		t = t.UTC()
+	}
+
+	pae.AppendString(t.Format(layout))
+}
+
 func (l *Logger) getLoggerLevel() zapcore.Level {
 	level, exist := loggerLevelMap[l.level]
 	if !exist {
@@ -80,77 +627,550 @@ func (l *Logger) getLoggerLevel() zapcore.Level {
 	return level
 }
 
+// Init проверяет опции и путь к логам, инициализирует логгер и сразу
+// открывает файл логов. В отличие от InitLogger, неизвестный уровень и
+// проблемы с каталогом или файлом возвращаются сразу, а не теряются при
+// первой записи внутри zap. Пустой путь означает текущий каталог.
+func (l *Logger) Init(consoleOutputEnable bool) error {
+	if len(l.optionErrs) > 0 {
+		return errors.Join(l.optionErrs...)
+	}
+
+	path, err := expandPath(l.path)
+	if err != nil {
+		return err
+	}
+
+	l.path = path
+
+	if _, err := lookupEncoder(l.fileFormat()); err != nil {
+		return err
+	}
+
+	for _, output := range l.outputs {
+		if err := validateSinkURL(output); err != nil {
+			return err
+		}
+	}
+
+	for _, fallback := range l.fallbacks {
+		if err := validateSinkURL(fallback); err != nil {
+			return err
+		}
+	}
+
+	if l.syslogFields {
+		if err := l.syslogFacility.validate(); err != nil {
+			return err
+		}
+	}
+
+	if l.socketPath == "" {
+		if l.fsys == nil {
+			if err := validatePath(l.path, l.dirPerm()); err != nil {
+				return err
+			}
+		}
+
+		pattern, err := newFilenamePattern(l.filename, l.appName)
+		if err != nil {
+			return err
+		}
+
+		if err := pattern.validateInterval(l.interval); err != nil {
+			return err
+		}
+
+		if _, err := parseRotateAt(l.rotateAt); err != nil {
+			return err
+		}
+
+		if err := validateInterval(l.interval); err != nil {
+			return err
+		}
+	}
+
+	err = l.init(consoleOutputEnable)
+
+	if l.rotator != nil {
+		if openErr := l.rotator.open(); openErr != nil {
+			err = errors.Join(err, fmt.Errorf("logger: cannot open log file: %w", openErr))
+		}
+	}
+
+	return err
+}
+
 func (l *Logger) InitLogger(consoleOutputEnable bool) {
-	encoderCfg := zap.NewProductionEncoderConfig()
+	_ = l.init(consoleOutputEnable)
+}
+
+// init строит ядра логгера. Ошибки открытия дополнительных назначений не
+// мешают работе остальных выводов и возвращаются вместе.
+func (l *Logger) init(consoleOutputEnable bool) error {
+	path, pathErr := expandPath(l.path)
+	if pathErr == nil {
+		l.path = path
+	}
+
+	l.diag = newDiagnostics(l.diagOutput)
+	l.redaction = newRedactorRef(l.redactor().merge(&redactor{}))
+	l.reload = &reloadState{config: l.config, base: l.redactBase}
+	if l.reload.base == nil {
+		l.reload.base = l.redactor()
+	}
+
+	encoderCfg := l.encoderConfig()
 
-	encoderCfg.EncodeTime = func(t time.Time, pae zapcore.PrimitiveArrayEncoder) {
-		pae.AppendString(t.Format("2006-01-02 15:04:05"))
+	consoleCfg, fileCfg := encoderCfg, encoderCfg
+	for _, fn := range l.consoleEncoderCfg {
+		fn(&consoleCfg)
+	}
+	for _, fn := range l.fileEncoderCfg {
+		fn(&fileCfg)
 	}
-	encoderCfg.LevelKey = "level"
-	encoderCfg.CallerKey = "caller"
-	encoderCfg.TimeKey = "time"
-	encoderCfg.NameKey = "name"
-	encoderCfg.MessageKey = "message"
-	encoderCfg.StacktraceKey = "stacktrace"
 
 	var encoder zapcore.Encoder
 
 	cores := make([]zapcore.Core, 0)
 
+	lvl := zap.NewAtomicLevel()
+	lvl.SetLevel(l.getLoggerLevel())
+
+	l.atomicLevel = lvl
+	l.escalation = &levelEscalation{}
+	l.gate = newLevelGate(lvl, l.overrides)
+
+	// Уровень логгера и переопределения проверяет gateCore, поэтому ядра
+	// выводов учитывают только собственные ограничения.
+	var allLevels zapcore.LevelEnabler = zapcore.DebugLevel
+
 	if consoleOutputEnable {
-		lvl := zap.NewAtomicLevel()
-		lvl.SetLevel(l.getLoggerLevel())
-		writer := zapcore.Lock(os.Stdout)
-		encoder = zapcore.NewConsoleEncoder(encoderCfg)
-		core := zapcore.NewCore(encoder, writer, lvl)
-		cores = append(cores, core)
+		if l.stderrLevel != nil {
+			// Фильтр уровней нужен и в Write: Tee пишет во все ядра без
+			// проверки, и запись в обход уровня попала бы в оба потока.
+			split := *l.stderrLevel
+			stdout := &levelFilterCore{
+				Core:    l.newConsoleCore(os.Stdout, consoleCfg, allLevels),
+				enabled: func(level zapcore.Level) bool { return level < split },
+			}
+			stderr := &levelFilterCore{
+				Core:    l.newConsoleCore(os.Stderr, consoleCfg, allLevels),
+				enabled: func(level zapcore.Level) bool { return level >= split },
+			}
+			cores = append(cores, stdout, stderr)
+		} else {
+			cores = append(cores, l.newConsoleCore(os.Stdout, consoleCfg, allLevels))
+		}
 	}
 
-	lvl := zap.NewAtomicLevel()
-	lvl.SetLevel(l.getLoggerLevel())
+	consoleCores := len(cores)
+
+	var fileLevel, fileCoreLevel zapcore.LevelEnabler = lvl, allLevels
 
-	fileRotator := &fileRotator{
-		path:     l.path,
-		compress: true,
+	var writer zapcore.WriteSyncer
+
+	if l.socketPath != "" {
+		socketWriter := &socketWriter{path: l.socketPath, diag: l.diag}
+		writer = zapcore.AddSync(socketWriter)
+		l.closers = append(l.closers, socketWriter)
+	} else if pathErr != nil {
+		// Каталог с неподставленным токеном не создаётся: файловый вывод
+		// отключается, а ошибка возвращается.
+		writer = zapcore.AddSync(io.Discard)
+	} else {
+		fileRotator := l.newFileRotator(l.path)
+
+		writer = zapcore.AddSync(fileRotator)
+
+		l.rotator = fileRotator
+
+		fileLevel = rotatorLevel(lvl, fileRotator)
+		fileCoreLevel = rotatorLevel(allLevels, fileRotator)
 	}
 
-	writer := zapcore.AddSync(fileRotator)
+	newEncoder, err := l.encoderFactory(l.fileFormat())
+	if err != nil {
+		newEncoder = zapcore.NewConsoleEncoder
+	}
+
+	encoder = newEncoder(fileCfg)
+
+	fallbacks, errs := l.openFallbacks()
+	if pathErr != nil {
+		errs = append([]error{pathErr}, errs...)
+	}
+
+	writer = l.withAsync(l.withFileBuffer(l.withFallback(l.withStats(writer), fallbacks)))
+
+	l.fileEncoder = encoder.Clone()
+	l.fileWriter = writer
+	l.fileLevel = fileLevel
 
-	l.rotator = fileRotator
+	core := zapcore.NewCore(encoder, writer, fileCoreLevel)
 
-	if l.structured {
-		encoder = zapcore.NewJSONEncoder(encoderCfg)
+	if l.splitByLevel && l.rotator != nil {
+		// Квоту MaxTotalSize для обоих файлов ведёт основной ротатор.
+		settings := *l
+		settings.maxTotalSize = 0
+
+		errorRotator := settings.newFileRotatorFor(l.path, errorFilename(l.filename), "")
+		l.rotator.addQuotaPeer(errorRotator, false)
+		l.errorRotator = errorRotator
+		l.closers = append(l.closers, errorRotator)
+
+		errorWriter := l.withAsync(l.withFileBuffer(l.withFallback(l.withStats(zapcore.AddSync(errorRotator)), fallbacks)))
+		errorCore := zapcore.NewCore(encoder.Clone(), errorWriter, rotatorLevel(allLevels, errorRotator))
+		cores = append(cores,
+			&levelFilterCore{Core: core, enabled: func(level zapcore.Level) bool { return level < zapcore.ErrorLevel }},
+			&levelFilterCore{Core: errorCore, enabled: func(level zapcore.Level) bool { return level >= zapcore.ErrorLevel }},
+		)
 	} else {
-		encoder = zapcore.NewConsoleEncoder(encoderCfg)
+		cores = append(cores, core)
 	}
 
-	core := zapcore.NewCore(encoder, writer, lvl)
-	cores = append(cores, core)
+	l.audit = l.newAuditLogger(newEncoder(fileCfg))
 
-	combinedCore := zapcore.NewTee(cores...)
+	if l.parallelQueue > 0 && len(l.outputs) > 0 {
+		fanoutCores, outputErrs := l.newFanoutCores(fileCfg, allLevels)
+		cores = append(cores, fanoutCores...)
+		errs = append(errs, outputErrs...)
+	} else {
+		for _, output := range l.outputs {
+			core, err := l.newOutputCore(output, fileCfg, allLevels)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
 
-	l.baseLogger = zap.New(combinedCore,
-		//	zap.AddStacktrace(zap.ErrorLevel),
-		zap.AddCaller(), zap.AddCallerSkip(1),
-	)
+			cores = append(cores, core)
+		}
+	}
+
+	for _, cfg := range l.kafka {
+		cores = append(cores, l.newKafkaCore(cfg, fileCfg, allLevels))
+	}
+
+	// Обёртки применяются к каждому ядру отдельно: Tee пишет во все
+	// вложенные ядра без проверки их уровней. Проверка уровня логгера
+	// должна идти первой, до обёрток, которые проверяют только Enabled.
+	for i, core := range cores {
+		own, isOwn := core.(ownLevelCore)
+		if isOwn {
+			core = own.Core
+		}
+
+		cores[i] = &gateCore{Core: l.wrapCore(core, i >= consoleCores), gate: l.gate, own: isOwn}
+	}
+
+	if l.sentry != nil {
+		var core zapcore.Core = l.newSentryCore(l.sentry)
+		cores = append(cores, &gateCore{Core: &redactCore{Core: core, rules: l.redaction}, gate: l.gate, own: true})
+	}
+
+	if l.schemaMode != SchemaOff {
+		// Без Diagnostics нарушения схемы пишутся в stderr, иначе режим
+		// SchemaReport ничего бы не сообщал.
+		diag := l.diag
+		if l.diagOutput == nil {
+			diag = newDiagnostics(os.Stderr)
+		}
+
+		core := &schemaCore{LevelEnabler: allLevels, mode: l.schemaMode, diag: diag}
+		cores = append(cores, &gateCore{Core: core, gate: l.gate})
+	}
+
+	l.sampler = newSamplingSwitch(zapcore.NewTee(cores...), l.sampling)
+
+	var combinedCore zapcore.Core = l.sampler
+	if l.verbose != nil {
+		combinedCore = &verboseCore{Core: combinedCore, fn: l.verbose, gate: l.gate}
+	}
+	if l.rateLimit > 0 {
+		limiter := newRateLimiter(l.rateLimit, l.rateBurst, l.rateLimitKey, l.clock, combinedCore)
+		limiter.start(rateLimitSummaryInterval)
+		combinedCore = &rateLimitCore{Core: combinedCore, limiter: limiter}
+		// Итоговая сводка пишется до закрытия файлов.
+		l.closers = append([]io.Closer{limiter}, l.closers...)
+	}
+	if len(l.hooks) > 0 {
+		combinedCore = &hookCore{Core: combinedCore, hooks: l.hooks, diag: l.diag}
+	}
+
+	l.baseLogger = zap.New(l.withEntryStats(combinedCore), l.zapOptions()...)
+
+	if l.runtimeInfo {
+		l.fields = runtimeFields()
+		l.baseLogger = l.baseLogger.With(l.fields...)
+	}
 
 	l.sugarLogger = l.baseLogger.Sugar()
+
+	l.diag.Info("initialized",
+		zap.String("path", l.path),
+		zap.String("level", l.getLoggerLevel().String()),
+		zap.String("format", l.fileFormat()),
+		zap.Strings("outputs", l.outputs),
+		zap.Bool("console", consoleOutputEnable),
+		zap.Errors("errors", errs),
+	)
+
+	return errors.Join(errs...)
 }
 
-func (l *Logger) Close() error {
-	err := l.sugarLogger.Sync()
+// newConsoleCore создаёт ядро консольного вывода в out.
+func (l *Logger) newConsoleCore(out *os.File, cfg zapcore.EncoderConfig, lvl zapcore.LevelEnabler) zapcore.Core {
+	var writer zapcore.WriteSyncer = consoleSyncer{out}
+	if l.consoleBufferSize > 0 && l.consoleFlushInterval > 0 {
+		console := newBufferedConsole(out, l.consoleBufferSize, l.consoleFlushInterval)
+		console.metrics = l.metrics
+		writer = console
+		l.closers = append(l.closers, console)
+	} else {
+		writer = zapcore.Lock(writer)
+	}
+
+	encoder := newConsoleEncoder(l.consoleMode.resolve(isTerminal(out)), l.consoleBadges, cfg)
+	if l.journaldMode.enabled() {
+		encoder = priorityEncoder{Encoder: encoder}
+	}
+
+	return zapcore.NewCore(encoder, writer, lvl)
+}
+
+// withFileBuffer оборачивает файловый writer буфером, если он включён.
+// Буфер сбрасывается раньше закрытия файлов.
+func (l *Logger) withFileBuffer(writer zapcore.WriteSyncer) zapcore.WriteSyncer {
+	if l.fileBufferSize <= 0 {
+		return writer
+	}
+
+	buffered := &zapcore.BufferedWriteSyncer{
+		WS:            writer,
+		Size:          l.fileBufferSize,
+		FlushInterval: l.fileFlushInterval,
+		Clock:         l.clock,
+	}
+	l.closers = append([]io.Closer{bufferedCloser{buffered}}, l.closers...)
+
+	return buffered
+}
+
+// bufferedCloser останавливает BufferedWriteSyncer со сбросом буфера.
+type bufferedCloser struct {
+	ws *zapcore.BufferedWriteSyncer
+}
+
+func (c bufferedCloser) Close() error {
+	return c.ws.Stop()
+}
+
+// wrapCore добавляет к ядру служебные поля и очистку управляющих
+// символов; file отмечает не консольные ядра.
+func (l *Logger) wrapCore(core zapcore.Core, file bool) zapcore.Core {
+	if file && l.structuredErrors {
+		core = &errorFieldsCore{Core: core}
+	}
+
+	if file && l.schemaVersion != "" {
+		core = newVersionCore(core, l.schemaVersion)
+	}
+
+	if file && l.otelSeverity {
+		core = &levelFieldsCore{Core: core, fields: otelSeverityFields}
+	}
+
+	if file && l.syslogFields {
+		core = &levelFieldsCore{Core: core, fields: syslogFields(l.syslogFacility)}
+	}
+
+	if file && l.stripFileANSI {
+		core = &sanitizeCore{Core: core, mode: sanitizeANSI}
+	}
+
+	if l.sanitize != SanitizeNone {
+		core = &sanitizeCore{Core: core, mode: l.sanitize}
+	}
+
+	return &redactCore{Core: core, rules: l.redaction}
+}
+
+func (l *Logger) zapOptions() []zap.Option {
+	zapOptions := l.callerOptions()
+
+	if l.development {
+		zapOptions = append(zapOptions, zap.Development())
+	}
+
+	if l.stacktraceLevel != nil && !l.noStacktrace {
+		zapOptions = append(zapOptions, zap.AddStacktrace(*l.stacktraceLevel))
+	}
+
+	if l.clock != nil {
+		zapOptions = append(zapOptions, zap.WithClock(l.clock))
+	}
+
+	return zapOptions
+}
+
+// newFileRotator создаёт ротатор для каталога path с настройками логгера
+// и запускает досжатие оставшихся файлов.
+func (l *Logger) newFileRotator(path string) *fileRotator {
+	return l.newFileRotatorFor(path, l.filename, l.currentLinkName())
+}
+
+// newFileRotatorFor создаёт ротатор с шаблоном имени filename и ссылкой
+// на текущий файл currentLink (пустая — без ссылки).
+func (l *Logger) newFileRotatorFor(path, filename, currentLink string) *fileRotator {
+	pattern, err := newFilenamePattern(filename, l.appName)
 	if err != nil {
-		return err
+		pattern = defaultPattern
+	}
+
+	rotateAt, _ := parseRotateAt(l.rotateAt)
+
+	interval := l.interval
+	if validateInterval(interval) != nil {
+		interval = 0
+	}
+
+	rotator := &fileRotator{
+		path:     path,
+		pattern:  pattern.withLayout(intervalLayout(interval)),
+		compress: !l.noCompress,
+		dateDirs: l.dateDirs,
+		locking:  l.fileLock && l.fsys == nil,
+		fileMode: l.filePerm(),
+		dirMode:  l.dirPerm(),
+		retry:    l.retry,
+
+		currentLink: currentLink,
+
+		maxSize:       l.maxSize,
+		maxSegments:   l.maxSegments,
+		segmentPolicy: l.segmentMode,
+
+		rotateAt: rotateAt,
+		interval: interval,
+
+		maxAge:       l.maxAge,
+		maxBackups:   l.maxBackups,
+		maxTotalSize: l.maxTotalSize,
+
+		uploader:       l.uploader,
+		removeUploaded: l.removeUploaded,
+		onRotate:       l.onRotate,
+
+		onCompressError: l.onCompressError,
+
+		clock: l.clock,
+		fsys:  l.fsys,
+
+		diag:    l.diag,
+		metrics: l.metrics,
+		stats:   l.stats,
+	}
+
+	if rotator.uploader != nil {
+		rotator.uploads, rotator.cancelUploads = context.WithCancel(context.Background())
+	}
+
+	if rotator.compress || rotator.hasRetention() || rotator.uploader != nil && rotator.removeUploaded {
+		rotator.goBackground("cleanup", rotator.cleanupLeftovers)
+	}
+
+	return rotator
+}
+
+// rotatorLevel учитывает отбрасывание debug-записей при исчерпании лимита
+// сегментов или квоты MaxTotalSize.
+func rotatorLevel(lvl zapcore.LevelEnabler, rotator *fileRotator) zapcore.LevelEnabler {
+	if rotator.segmentPolicy != SegmentLimitDropDebug && rotator.maxTotalSize <= 0 {
+		return lvl
+	}
+
+	return zap.LevelEnablerFunc(func(level zapcore.Level) bool {
+		return lvl.Enabled(level) && !rotator.dropsLevel(level)
+	})
+}
+
+// Close сбрасывает буферы, закрывает назначения и файл и дожидается
+// фонового сжатия ротированных файлов.
+func (l *Logger) Close() error {
+	return l.CloseContext(context.Background())
+}
+
+// CloseContext работает как Close, но ждёт фоновое сжатие и отправку
+// остатка в сетевые назначения не дольше ctx. Прерванное сжатие
+// завершится при следующем запуске. Ошибка одного назначения не мешает
+// закрыть остальные; ошибки возвращаются вместе.
+func (l *Logger) CloseContext(ctx context.Context) error {
+	errs := []error{syncContext(ctx, l.sugarLogger.Sync)}
+
+	// Сначала закрываем приём записей от других процессов, затем файл.
+	for _, closer := range l.closers {
+		errs = append(errs, closeContext(ctx, closer))
 	}
 
 	if l.rotator != nil {
-		err = l.rotator.Close()
-		if err != nil {
+		errs = append(errs, l.rotator.closeContext(ctx))
+	}
+
+	return errors.Join(errs...)
+}
+
+// syncContext вызывает sync, но ждёт его не дольше ctx: зависшее
+// назначение не должно задерживать закрытие остальных.
+func syncContext(ctx context.Context, sync func() error) error {
+	if ctx.Done() == nil {
+		return sync()
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- sync() }()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return fmt.Errorf("logger: sync: %w", ctx.Err())
+	}
+}
+
+// contextCloser — назначение, закрытие которого ограничивается ctx.
+type contextCloser interface {
+	closeContext(ctx context.Context) error
+}
+
+func closeContext(ctx context.Context, closer io.Closer) error {
+	if c, ok := closer.(contextCloser); ok {
+		return c.closeContext(ctx)
+	}
+
+	return closer.Close()
+}
+
+// Sync дописывает буферы и очереди записи и сбрасывает файлы на диск.
+func (l *Logger) Sync() error {
+	return l.sugarLogger.Sync()
+}
+
+// Rotate принудительно начинает новый файл логов, например перед деплоем
+// или сбором диагностики.
+func (l *Logger) Rotate() error {
+	if l.rotator == nil {
+		return errors.New("logger: rotation requires file output")
+	}
+
+	_ = l.sugarLogger.Sync()
+
+	if l.errorRotator != nil {
+		if err := l.errorRotator.Rotate(); err != nil {
 			return err
 		}
 	}
 
-	return nil
+	return l.rotator.Rotate()
 }
 
 func (l *Logger) Debug(args ...interface{}) {
@@ -217,12 +1237,10 @@ func (l *Logger) WithFields(fields map[string]interface{}) *Logger {
 
 	newBaseLogger := l.baseLogger.With(zapFields...)
 
-	return &Logger{
-		path:        l.path,
-		level:       l.level,
-		structured:  l.structured,
-		baseLogger:  newBaseLogger,
-		sugarLogger: newBaseLogger.Sugar(),
-		rotator:     l.rotator,
-	}
+	child := *l
+	child.baseLogger = newBaseLogger
+	child.sugarLogger = newBaseLogger.Sugar()
+	child.fields = append(l.fields[:len(l.fields):len(l.fields)], zapFields...)
+
+	return &child
 }
@@ -1,6 +1,7 @@
 package logger
 
 import (
+	"archive/zip"
 	"bytes"
 	"encoding/json"
 	"io"
@@ -280,7 +281,8 @@ func TestFileRotatorCompress(t *testing.T) {
 	tmpFile.Close()
 
 	// Выполняем сжатие файла
-	compressFile(tmpFile.Name())
+	err = compressFile(osFS{}, tmpFile.Name(), defaultFileMode, retryPolicy{})
+	require.NoError(t, err)
 
 	// Проверяем, что сжатый файл был создан
 	zipFilePath := tmpFile.Name() + ".zip"
@@ -450,3 +452,508 @@ func TestInvalidLogLevel(t *testing.T) {
 
 	assert.Equal(t, "info", logger.level)
 }
+
+// TestInitInvalidLogLevel проверяет, что Init сообщает о неизвестном уровне.
+func TestInitInvalidLogLevel(t *testing.T) {
+	logger := NewLogger(Path(t.TempDir()), Level("invalid_level"))
+
+	assert.ErrorContains(t, logger.Init(false), `unknown level "invalid_level"`)
+}
+
+// TestInitOpensFile проверяет, что Init сразу создаёт файл логов.
+func TestInitOpensFile(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	logger := NewLogger(Path(tmpDir))
+	require.NoError(t, logger.Init(false))
+	defer logger.Close()
+
+	_, err := os.Stat(filepath.Join(tmpDir, time.Now().Format(dateLayout)+".log"))
+	assert.NoError(t, err)
+}
+
+// TestFileRotatorCompressLeftovers проверяет сжатие несжатых файлов прошлых дней при старте.
+func TestFileRotatorCompressLeftovers(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "logger_test")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	oldFile := filepath.Join(tmpDir, time.Now().AddDate(0, 0, -1).Format(dateLayout)+".log")
+	todayFile := filepath.Join(tmpDir, time.Now().Format(dateLayout)+".log")
+	otherFile := filepath.Join(tmpDir, "other.log")
+
+	for _, name := range []string{oldFile, todayFile, otherFile} {
+		require.NoError(t, os.WriteFile(name, []byte("test log data"), 0666))
+	}
+
+	rotator := &fileRotator{path: tmpDir, compress: true}
+	rotator.compressLeftovers()
+
+	_, err = os.Stat(oldFile + ".zip")
+	assert.NoError(t, err, "Leftover file should be compressed")
+
+	_, err = os.Stat(oldFile)
+	assert.True(t, os.IsNotExist(err), "Leftover file should be deleted")
+
+	_, err = os.Stat(todayFile)
+	assert.NoError(t, err, "Today's file should be kept")
+
+	_, err = os.Stat(otherFile)
+	assert.NoError(t, err, "Foreign file should be kept")
+}
+
+// TestFileRotatorCleanupLeftovers проверяет пересжатие файла, сжатие которого было прервано.
+func TestFileRotatorCleanupLeftovers(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "logger_test")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	srcFile := filepath.Join(tmpDir, time.Now().AddDate(0, 0, -1).Format(dateLayout)+".log")
+	orphanZip := filepath.Join(tmpDir, time.Now().AddDate(0, 0, -2).Format(dateLayout)+".log.zip")
+
+	require.NoError(t, os.WriteFile(srcFile, []byte("test log data"), 0666))
+	require.NoError(t, os.WriteFile(srcFile+".zip", []byte("partial"), 0666))
+	require.NoError(t, os.WriteFile(orphanZip, []byte("archive"), 0666))
+	require.NoError(t, os.WriteFile(orphanZip+tempExt, []byte("partial"), 0666))
+
+	rotator := &fileRotator{path: tmpDir, compress: true}
+	rotator.cleanupLeftovers()
+
+	_, err = os.Stat(srcFile)
+	assert.True(t, os.IsNotExist(err), "Source file should be deleted after compression")
+
+	reader, err := zip.OpenReader(srcFile + ".zip")
+	require.NoError(t, err, "Archive should be valid")
+	defer reader.Close()
+	require.Len(t, reader.File, 1)
+
+	_, err = os.Stat(orphanZip)
+	assert.NoError(t, err, "Archive without source should be kept")
+
+	_, err = os.Stat(orphanZip + tempExt)
+	assert.True(t, os.IsNotExist(err), "Temporary archive should be deleted")
+}
+
+// TestCompressFileMissingSource проверяет, что неудачное сжатие не оставляет временных файлов.
+func TestCompressFileMissingSource(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "logger_test")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	err = compressFile(osFS{}, filepath.Join(tmpDir, "missing.log"), defaultFileMode, retryPolicy{})
+	assert.Error(t, err)
+
+	files, err := os.ReadDir(tmpDir)
+	require.NoError(t, err)
+	assert.Empty(t, files, "No artifacts should be left after failed compression")
+}
+
+// TestFileRotatorDateDirs проверяет раскладку файлов по подкаталогам ГГГГ/ММ.
+func TestFileRotatorDateDirs(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "logger_test")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	rotator := &fileRotator{path: tmpDir, dateDirs: true, compress: true}
+
+	_, err = rotator.Write([]byte("test log"))
+	require.NoError(t, err)
+	require.NoError(t, rotator.Close())
+
+	now := time.Now()
+	_, err = os.Stat(filepath.Join(tmpDir, now.Format("2006"), now.Format("01"), now.Format(dateLayout)+".log"))
+	assert.NoError(t, err, "Log file should be created in date subdirectory")
+
+	old := now.AddDate(0, -1, 0)
+	oldDir := filepath.Join(tmpDir, old.Format("2006"), old.Format("01"))
+	oldFile := filepath.Join(oldDir, old.Format(dateLayout)+".log")
+	require.NoError(t, os.MkdirAll(oldDir, 0777))
+	require.NoError(t, os.WriteFile(oldFile, []byte("test log data"), 0666))
+
+	rotator.cleanupLeftovers()
+
+	_, err = os.Stat(oldFile + archiveExt)
+	assert.NoError(t, err, "Leftover file in date subdirectory should be compressed")
+}
+
+// TestFileRotatorMaxSize проверяет ротацию по размеру с нумерованными сегментами.
+func TestFileRotatorMaxSize(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "logger_test")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	rotator := &fileRotator{path: tmpDir, maxSize: 10}
+
+	for i := 0; i < 3; i++ {
+		_, err = rotator.Write([]byte("test log\n"))
+		require.NoError(t, err)
+	}
+	require.NoError(t, rotator.Close())
+
+	today := time.Now().Format(dateLayout)
+	for _, name := range []string{today + ".log", today + ".1.log", today + ".2.log"} {
+		_, err = os.Stat(filepath.Join(tmpDir, name))
+		assert.NoError(t, err, "Segment %s should be created", name)
+	}
+
+	// После перезапуска запись продолжается в последний сегмент.
+	rotator = &fileRotator{path: tmpDir, maxSize: 100}
+	_, err = rotator.Write([]byte("test log\n"))
+	require.NoError(t, err)
+	require.NoError(t, rotator.Close())
+	assert.Equal(t, 2, rotator.segment)
+}
+
+// TestFileRotatorMaxSegmentsPerDay проверяет поведение при исчерпании лимита сегментов.
+func TestFileRotatorMaxSegmentsPerDay(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "logger_test")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	rotator := &fileRotator{path: tmpDir, maxSize: 10, maxSegments: 2, segmentPolicy: SegmentLimitDropDebug}
+
+	for i := 0; i < 5; i++ {
+		_, err = rotator.Write([]byte("test log\n"))
+		require.NoError(t, err)
+	}
+	require.NoError(t, rotator.Close())
+
+	files, err := os.ReadDir(tmpDir)
+	require.NoError(t, err)
+	assert.Len(t, files, 2, "Segment count should be limited")
+
+	assert.True(t, rotator.dropsLevel(zapcore.DebugLevel))
+	assert.False(t, rotator.dropsLevel(zapcore.InfoLevel))
+}
+
+// TestFileRotatorRotateAt проверяет определение даты файла при ротации не в полночь.
+func TestFileRotatorRotateAt(t *testing.T) {
+	rotateAt, err := parseRotateAt("04:00")
+	require.NoError(t, err)
+	assert.Equal(t, 4*time.Hour, rotateAt)
+
+	_, err = parseRotateAt("25:00")
+	assert.Error(t, err)
+
+	rotator := &fileRotator{rotateAt: rotateAt}
+
+	beforeRotation := time.Date(2024, 5, 29, 3, 59, 0, 0, time.Local)
+	assert.Equal(t, "2024_05_28", rotator.periodDate(beforeRotation).Format(dateLayout))
+
+	afterRotation := time.Date(2024, 5, 29, 4, 0, 0, 0, time.Local)
+	assert.Equal(t, "2024_05_29", rotator.periodDate(afterRotation).Format(dateLayout))
+}
+
+// TestLoggerRotate проверяет принудительную ротацию.
+func TestLoggerRotate(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "logger_test")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	logger := NewLogger(Path(tmpDir), Compress(false))
+	logger.InitLogger(false)
+
+	logger.Info("before rotation")
+	require.NoError(t, logger.Rotate())
+	logger.Info("after rotation")
+	require.NoError(t, logger.Close())
+
+	today := time.Now().Format(dateLayout)
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, today+".log"))
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "before rotation")
+
+	content, err = os.ReadFile(filepath.Join(tmpDir, today+".1.log"))
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "after rotation")
+
+	assert.Error(t, NewLogger(SocketOutput("/tmp/none.sock")).Rotate())
+}
+
+type fakeClock struct {
+	now time.Time
+	mu  sync.Mutex
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+func (c *fakeClock) NewTicker(d time.Duration) *time.Ticker {
+	return time.NewTicker(d)
+}
+
+func (c *fakeClock) Add(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+}
+
+// TestFileRotatorClock проверяет ротацию через полночь с подменённым временем.
+func TestFileRotatorClock(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "logger_test")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	clock := &fakeClock{now: time.Date(2024, 5, 28, 23, 59, 0, 0, time.Local)}
+	rotator := &fileRotator{path: tmpDir, clock: clock}
+
+	_, err = rotator.Write([]byte("before midnight\n"))
+	require.NoError(t, err)
+
+	clock.Add(2 * time.Minute)
+
+	_, err = rotator.Write([]byte("after midnight\n"))
+	require.NoError(t, err)
+	require.NoError(t, rotator.Close())
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "2024_05_28.log"))
+	require.NoError(t, err)
+	assert.Equal(t, "before midnight\n", string(content))
+
+	content, err = os.ReadFile(filepath.Join(tmpDir, "2024_05_29.log"))
+	require.NoError(t, err)
+	assert.Equal(t, "after midnight\n", string(content))
+}
+
+// TestLoggerClock проверяет, что метки времени записей берутся из заданного источника времени.
+func TestLoggerClock(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "logger_test")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	clock := &fakeClock{now: time.Date(2024, 5, 28, 12, 30, 0, 0, time.Local)}
+
+	logger := NewLogger(Path(tmpDir), WithClock(clock))
+	require.NoError(t, logger.Init(false))
+
+	logger.Info("Test log message")
+	require.NoError(t, logger.Close())
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "2024_05_28.log"))
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "2024-05-28 12:30:00")
+}
+
+// TestFileRotatorInterval проверяет почасовую ротацию.
+func TestFileRotatorInterval(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	clock := &fakeClock{now: time.Date(2024, 5, 28, 13, 59, 0, 0, time.Local)}
+	rotator := &fileRotator{path: tmpDir, clock: clock, interval: time.Hour, pattern: defaultPattern.withLayout(hourLayout)}
+
+	_, err := rotator.Write([]byte("13h\n"))
+	require.NoError(t, err)
+
+	clock.Add(30 * time.Second)
+
+	_, err = rotator.Write([]byte("still 13h\n"))
+	require.NoError(t, err)
+
+	clock.Add(time.Minute)
+
+	_, err = rotator.Write([]byte("14h\n"))
+	require.NoError(t, err)
+	require.NoError(t, rotator.Close())
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "2024_05_28_13.log"))
+	require.NoError(t, err)
+	assert.Equal(t, "13h\nstill 13h\n", string(content))
+
+	content, err = os.ReadFile(filepath.Join(tmpDir, "2024_05_28_14.log"))
+	require.NoError(t, err)
+	assert.Equal(t, "14h\n", string(content))
+}
+
+// TestValidateInterval проверяет допустимые интервалы ротации.
+func TestValidateInterval(t *testing.T) {
+	for _, interval := range []time.Duration{0, time.Hour, 15 * time.Minute, 6 * time.Hour, 24 * time.Hour} {
+		assert.NoError(t, validateInterval(interval), interval.String())
+	}
+
+	for _, interval := range []time.Duration{-time.Hour, 30 * time.Second, 7 * time.Minute, 5 * time.Hour, 48 * time.Hour} {
+		assert.Error(t, validateInterval(interval), interval.String())
+	}
+}
+
+// TestSeparateEncoderConfig проверяет независимые настройки кодировщиков
+// консоли и файла.
+func TestSeparateEncoderConfig(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	oldStdout := os.Stdout
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	os.Stdout = w
+
+	logger := NewLogger(Path(tmpDir), Structured(true), Console(ConsoleJSON),
+		ConsoleEncoderConfig(func(cfg *zapcore.EncoderConfig) {
+			cfg.MessageKey = "msg"
+			cfg.TimeKey = ""
+		}),
+		FileEncoderConfig(func(cfg *zapcore.EncoderConfig) {
+			cfg.TimeKey = "ts"
+			cfg.EncodeTime = zapcore.EpochTimeEncoder
+		}),
+	)
+	require.NoError(t, logger.Init(true))
+
+	logger.Info("hello")
+	require.NoError(t, logger.Close())
+
+	w.Close()
+	os.Stdout = oldStdout
+
+	console, err := io.ReadAll(r)
+	require.NoError(t, err)
+
+	var consoleLine map[string]interface{}
+	require.NoError(t, json.Unmarshal(console, &consoleLine))
+	assert.Equal(t, "hello", consoleLine["msg"])
+	assert.NotContains(t, consoleLine, "time")
+
+	lines := readJSONLines(t, filepath.Join(tmpDir, time.Now().Format(dateLayout)+".log"))
+	require.Len(t, lines, 1)
+	assert.Equal(t, "hello", lines[0]["message"])
+	assert.IsType(t, float64(0), lines[0]["ts"])
+}
+
+func TestSplitByLevel(t *testing.T) {
+	tmpDir := t.TempDir()
+	today := time.Now().Format(dateLayout)
+
+	logger := NewLogger(Path(tmpDir), Structured(true), SplitByLevel(true), Compress(false))
+	require.NoError(t, logger.Init(true))
+
+	logger.Info("info message")
+	logger.Warn("warn message")
+	logger.Error("error message")
+	require.NoError(t, logger.Rotate())
+	logger.Error("after rotate")
+	require.NoError(t, logger.Close())
+
+	lines := readJSONLines(t, filepath.Join(tmpDir, today+".log"))
+	require.Len(t, lines, 2)
+	assert.Equal(t, "info message", lines[0]["message"])
+	assert.Equal(t, "warn message", lines[1]["message"])
+
+	lines = readJSONLines(t, filepath.Join(tmpDir, today+".error.log"))
+	require.Len(t, lines, 1)
+	assert.Equal(t, "error message", lines[0]["message"])
+
+	lines = readJSONLines(t, filepath.Join(tmpDir, today+".error.1.log"))
+	require.Len(t, lines, 1)
+	assert.Equal(t, "after rotate", lines[0]["message"])
+}
+
+func TestFileBuffer(t *testing.T) {
+	tmpDir := t.TempDir()
+	file := filepath.Join(tmpDir, time.Now().Format(dateLayout)+".log")
+
+	logger := NewLogger(Path(tmpDir), Structured(true), FileBuffer(1<<20, time.Hour))
+	require.NoError(t, logger.Init(false))
+
+	logger.Info("buffered")
+	content, err := os.ReadFile(file)
+	require.NoError(t, err)
+	assert.Empty(t, content)
+
+	require.NoError(t, logger.Sync())
+	assert.Len(t, readJSONLines(t, file), 1)
+
+	logger.Info("flushed on close")
+	require.NoError(t, logger.Close())
+	assert.Len(t, readJSONLines(t, file), 2)
+}
+
+// TestTimeLayout проверяет формат времени и запись в UTC.
+func TestTimeLayout(t *testing.T) {
+	tmpDir := t.TempDir()
+	zone := time.FixedZone("MSK", 3*3600)
+	clock := &fakeClock{now: time.Date(2024, 5, 28, 12, 30, 0, 123456789, zone)}
+
+	logger := NewLogger(Path(tmpDir), Structured(true), WithClock(clock), TimeLayout(time.RFC3339Nano))
+	require.NoError(t, logger.Init(false))
+	logger.Info("local")
+	require.NoError(t, logger.Close())
+
+	lines := readJSONLines(t, filepath.Join(tmpDir, "2024_05_28.log"))
+	require.Len(t, lines, 1)
+	assert.Equal(t, "2024-05-28T12:30:00.123456789+03:00", lines[0]["time"])
+
+	tmpDir = t.TempDir()
+	logger = NewLogger(Path(tmpDir), Structured(true), WithClock(clock), UTC(true))
+	require.NoError(t, logger.Init(false))
+	logger.Info("utc")
+	require.NoError(t, logger.Close())
+
+	lines = readJSONLines(t, filepath.Join(tmpDir, "2024_05_28.log"))
+	require.Len(t, lines, 1)
+	assert.Equal(t, "2024-05-28 09:30:00", lines[0]["time"])
+
+	assert.Error(t, NewLogger(Path(t.TempDir()), TimeLayout("")).Init(false))
+}
+
+// TestStacktraceLevel проверяет запись стека начиная с заданного уровня.
+func TestStacktraceLevel(t *testing.T) {
+	tests := []struct {
+		name    string
+		options []Option
+		want    map[string]bool
+	}{
+		{name: "default", want: map[string]bool{"warn": false, "error": false}},
+		{name: "error", options: []Option{StacktraceLevel("error")}, want: map[string]bool{"warn": false, "error": true}},
+		{name: "disabled", options: []Option{DisableStacktrace(), StacktraceLevel("warn")}, want: map[string]bool{"warn": false, "error": false}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			logger := NewLogger(append([]Option{Path(tmpDir), Structured(true)}, tt.options...)...)
+			require.NoError(t, logger.Init(false))
+
+			logger.Warn("warn")
+			logger.Error("error")
+			require.NoError(t, logger.Close())
+
+			lines := readJSONLines(t, filepath.Join(tmpDir, time.Now().Format(dateLayout)+".log"))
+			require.Len(t, lines, 2)
+			for _, line := range lines {
+				_, has := line["stacktrace"]
+				assert.Equal(t, tt.want[line["message"].(string)], has, line["message"])
+			}
+		})
+	}
+
+	assert.Error(t, NewLogger(Path(t.TempDir()), StacktraceLevel("trace")).Init(false))
+}
+
+// TestDevelopment проверяет пресет режима разработки.
+func TestDevelopment(t *testing.T) {
+	tmpDir := t.TempDir()
+	logger := NewLogger(Path(tmpDir), Structured(true), Development(true))
+	assert.Equal(t, "debug", logger.level)
+	assert.Equal(t, ConsoleColor, logger.consoleMode)
+	require.NoError(t, logger.Init(false))
+
+	logger.Debug("debug")
+	assert.Panics(t, func() { logger.DPanic("dpanic") })
+	require.NoError(t, logger.Close())
+
+	lines := readJSONLines(t, filepath.Join(tmpDir, time.Now().Format(dateLayout)+".log"))
+	require.Len(t, lines, 2)
+	assert.Equal(t, "debug", lines[0]["message"])
+	assert.Contains(t, lines[1], "stacktrace")
+
+	production := NewLogger(Path(t.TempDir()), Development(true), Level("warn"), Development(false))
+	require.NoError(t, production.Init(false))
+	defer production.Close()
+
+	assert.Equal(t, "warn", production.level)
+	assert.NotPanics(t, func() { production.DPanic("dpanic") })
+}
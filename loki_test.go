@@ -0,0 +1,154 @@
+package logger
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type lokiPush struct {
+	Streams []struct {
+		Stream map[string]string `json:"stream"`
+		Values [][2]string       `json:"values"`
+	} `json:"streams"`
+}
+
+// lokiServer принимает push-запросы; первые failures запросов получают 503.
+type lokiServer struct {
+	*httptest.Server
+	mu       sync.Mutex
+	failures int
+	requests int
+	pushes   []lokiPush
+}
+
+func newLokiServer(t *testing.T, failures int) *lokiServer {
+	s := &lokiServer{failures: failures}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		assert.Equal(t, lokiPushPath, r.URL.Path)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+		s.requests++
+		if s.requests <= s.failures {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		var push lokiPush
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&push))
+		s.pushes = append(s.pushes, push)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+// TestLokiSink проверяет отправку записей с метками из опции.
+func TestLokiSink(t *testing.T) {
+	server := newLokiServer(t, 0)
+
+	logger := NewLogger(Path(t.TempDir()), Structured(true), AppName("app"),
+		LokiSink(server.URL, map[string]string{"app": "api", "env": "prod"}))
+	require.NoError(t, logger.Init(false))
+
+	logger.Info("first")
+	logger.Warn("second")
+	require.NoError(t, logger.Close())
+
+	server.mu.Lock()
+	defer server.mu.Unlock()
+
+	require.Len(t, server.pushes, 1)
+	require.Len(t, server.pushes[0].Streams, 1)
+
+	stream := server.pushes[0].Streams[0]
+	assert.Equal(t, map[string]string{"app": "api", "env": "prod"}, stream.Stream)
+	require.Len(t, stream.Values, 2)
+
+	var record map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(stream.Values[0][1]), &record))
+	assert.Equal(t, "first", record["message"])
+	assert.NotEmpty(t, stream.Values[0][0])
+}
+
+// TestLokiSinkRetry проверяет повтор отправки после ответа 503.
+func TestLokiSinkRetry(t *testing.T) {
+	server := newLokiServer(t, 1)
+
+	u, err := parseSinkURL("loki+" + server.URL + "?app=api")
+	require.NoError(t, err)
+
+	sink, err := newLokiSink(u)
+	require.NoError(t, err)
+
+	_, err = sink.Write([]byte("line\n"))
+	require.NoError(t, err)
+	require.NoError(t, sink.(*batchSink).push(context.Background(), true))
+	require.NoError(t, sink.Close())
+
+	server.mu.Lock()
+	defer server.mu.Unlock()
+
+	assert.Equal(t, 2, server.requests)
+	require.Len(t, server.pushes, 1)
+	assert.Equal(t, [][2]string{{server.pushes[0].Streams[0].Values[0][0], "line"}}, server.pushes[0].Streams[0].Values)
+}
+
+// TestLokiSinkInvalidURL проверяет ошибку Build для неверного адреса и
+// без меток.
+func TestLokiSinkInvalidURL(t *testing.T) {
+	_, err := Build(Path(t.TempDir()), LokiSink("loki:3100", nil))
+	assert.ErrorContains(t, err, `invalid Loki URL "loki:3100"`)
+
+	_, err = Build(Path(t.TempDir()), LokiSink("http://loki:3100", map[string]string{"app": ""}))
+	assert.ErrorContains(t, err, "requires at least one label")
+}
+
+// TestLokiSinkCloseUnreachable проверяет, что недоступный Loki не
+// задерживает Close повторами и не мешает закрыть файл логов.
+func TestLokiSinkCloseUnreachable(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := listener.Addr().String()
+	require.NoError(t, listener.Close())
+
+	logger := NewLogger(Path(t.TempDir()), LokiSink("http://"+addr, map[string]string{"app": "api"}))
+	require.NoError(t, logger.Init(false))
+
+	logger.Info("message")
+
+	start := time.Now()
+	err = logger.Close()
+	assert.ErrorContains(t, err, "loki push")
+	assert.Less(t, time.Since(start), batchRetryDelay)
+	assert.Nil(t, logger.rotator.file, "File should be closed despite the sink error")
+}
+
+// TestBatchSinkCloseContext проверяет, что отправка остатка при закрытии
+// прерывается по ctx.
+func TestBatchSinkCloseContext(t *testing.T) {
+	sink := newBatchSink("test", 10, time.Hour, func(ctx context.Context, _ []batchEntry) (bool, error) {
+		<-ctx.Done()
+		return true, ctx.Err()
+	})
+
+	_, err := sink.Write([]byte("line\n"))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	assert.ErrorIs(t, sink.closeContext(ctx), context.DeadlineExceeded)
+}
@@ -0,0 +1,64 @@
+package logger
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// lumberjackDefaultMaxSize — размер файла в мегабайтах, который lumberjack
+// использует при нулевом MaxSize.
+const lumberjackDefaultMaxSize = 100
+
+// LumberjackConfig повторяет настройки lumberjack.Logger, включая теги
+// для разбора существующих конфигураций.
+type LumberjackConfig struct {
+	// Filename — путь к файлу логов. По умолчанию
+	// <имя процесса>-lumberjack.log во временном каталоге.
+	Filename string `json:"filename" yaml:"filename"`
+	// MaxSize — размер файла в мегабайтах до ротации, по умолчанию 100.
+	MaxSize int `json:"maxsize" yaml:"maxsize"`
+	// MaxAge — число дней хранения прошлых файлов.
+	MaxAge int `json:"maxage" yaml:"maxage"`
+	// MaxBackups — число хранимых прошлых файлов.
+	MaxBackups int `json:"maxbackups" yaml:"maxbackups"`
+	// LocalTime не используется: даты в именах файлов всегда местные.
+	LocalTime bool `json:"localtime" yaml:"localtime"`
+	// Compress включает сжатие прошлых файлов.
+	Compress bool `json:"compress" yaml:"compress"`
+}
+
+// NewLumberjackLogger создаёт логгер по настройкам lumberjack для перехода
+// с zap+lumberjack. Файл "/var/log/app/app.log" превращается в каталог
+// /var/log/app и шаблон "app-{date}.log": кроме ротации по размеру файлы
+// ротируются ежедневно. options применяются после настроек cfg.
+func NewLumberjackLogger(cfg LumberjackConfig, options ...Option) *Logger {
+	return NewLogger(append(lumberjackOptions(cfg), options...)...)
+}
+
+func lumberjackOptions(cfg LumberjackConfig) []Option {
+	dir := os.TempDir()
+	pattern := "{app}-lumberjack-{date}.log"
+
+	if cfg.Filename != "" {
+		dir = filepath.Dir(cfg.Filename)
+
+		name := filepath.Base(cfg.Filename)
+		ext := filepath.Ext(name)
+		pattern = strings.TrimSuffix(name, ext) + "-{date}" + ext
+	}
+
+	maxSize := cfg.MaxSize
+	if maxSize == 0 {
+		maxSize = lumberjackDefaultMaxSize
+	}
+
+	return []Option{
+		Path(dir),
+		FilenamePattern(pattern),
+		MaxSize(int64(maxSize) * 1024 * 1024),
+		MaxAge(cfg.MaxAge),
+		MaxBackups(cfg.MaxBackups),
+		Compress(cfg.Compress),
+	}
+}
@@ -0,0 +1,33 @@
+package logger
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestLumberjackOptions проверяет перенос настроек lumberjack.
+func TestLumberjackOptions(t *testing.T) {
+	logger := NewLumberjackLogger(LumberjackConfig{
+		Filename:   filepath.Join("var", "log", "app", "app.log"),
+		MaxAge:     7,
+		MaxBackups: 3,
+		Compress:   true,
+	}, Level("debug"))
+
+	assert.Equal(t, filepath.Join("var", "log", "app"), logger.path)
+	assert.Equal(t, "app-{date}.log", logger.filename)
+	assert.Equal(t, int64(100*1024*1024), logger.maxSize)
+	assert.Equal(t, 7*24*time.Hour, logger.maxAge)
+	assert.Equal(t, 3, logger.maxBackups)
+	assert.False(t, logger.noCompress)
+	assert.Equal(t, "debug", logger.level)
+
+	logger = NewLumberjackLogger(LumberjackConfig{MaxSize: 5})
+
+	assert.Equal(t, "{app}-lumberjack-{date}.log", logger.filename)
+	assert.Equal(t, int64(5*1024*1024), logger.maxSize)
+	assert.True(t, logger.noCompress)
+}
@@ -0,0 +1,23 @@
+package logger
+
+import "time"
+
+// Metrics получает измерения работы логгера. Реализация переносит их в
+// гистограммы своей системы метрик, чтобы по ним можно было проверить,
+// связаны ли всплески задержек с записью логов.
+type Metrics interface {
+	// ObserveWriteLatency вызывается после каждой записи в файл с её
+	// длительностью, включая ожидание блокировок и ротацию.
+	ObserveWriteLatency(d time.Duration)
+	// ObserveQueueDepth вызывается после каждой записи в буфер консоли с
+	// объёмом ожидающих сброса данных в байтах, а также при постановке в
+	// очередь Async и выборке из неё с числом ожидающих записей.
+	ObserveQueueDepth(depth int)
+}
+
+// WithMetrics передаёт измерения записи в m.
+func WithMetrics(m Metrics) Option {
+	return func(l *Logger) {
+		l.metrics = m
+	}
+}
@@ -0,0 +1,95 @@
+package logger
+
+import (
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingMetrics struct {
+	latencies []time.Duration
+	depths    []int
+	mu        sync.Mutex
+}
+
+func (m *recordingMetrics) ObserveWriteLatency(d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.latencies = append(m.latencies, d)
+}
+
+func (m *recordingMetrics) ObserveQueueDepth(depth int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.depths = append(m.depths, depth)
+}
+
+// TestMetricsWriteLatency проверяет измерение каждой записи в файл.
+func TestMetricsWriteLatency(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "logger_test")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	metrics := &recordingMetrics{}
+
+	logger := NewLogger(Path(tmpDir), WithMetrics(metrics))
+	require.NoError(t, logger.Init(false))
+
+	logger.Info("first")
+	logger.Info("second")
+	require.NoError(t, logger.Close())
+
+	metrics.mu.Lock()
+	defer metrics.mu.Unlock()
+
+	assert.Len(t, metrics.latencies, 2)
+}
+
+// TestMetricsQueueDepth проверяет, что глубина буфера консоли растёт до
+// сброса и обнуляется после него.
+func TestMetricsQueueDepth(t *testing.T) {
+	metrics := &recordingMetrics{}
+
+	console := newBufferedConsole(&syncBuffer{}, 10, time.Hour)
+	console.metrics = metrics
+
+	_, err := console.Write([]byte("1234\n"))
+	require.NoError(t, err)
+	_, err = console.Write([]byte("5678\n"))
+	require.NoError(t, err)
+	_, err = console.Write([]byte("9\n"))
+	require.NoError(t, err)
+	require.NoError(t, console.Close())
+
+	metrics.mu.Lock()
+	defer metrics.mu.Unlock()
+
+	assert.Equal(t, []int{5, 10, 2}, metrics.depths)
+}
+
+// TestMetricsAsyncQueueDepth проверяет, что очередь Async сообщает число
+// записей при постановке и выборке.
+func TestMetricsAsyncQueueDepth(t *testing.T) {
+	metrics := &recordingMetrics{}
+
+	logger := NewLogger(Path(t.TempDir()), Async(16, DropNone), WithMetrics(metrics))
+	require.NoError(t, logger.Init(false))
+
+	logger.Info("first")
+	logger.Info("second")
+	logger.Info("third")
+	require.NoError(t, logger.Close())
+
+	metrics.mu.Lock()
+	defer metrics.mu.Unlock()
+
+	require.Len(t, metrics.depths, 6)
+	assert.Equal(t, 0, metrics.depths[len(metrics.depths)-1])
+	assert.Greater(t, metrics.depths[0], 0)
+}
@@ -0,0 +1,193 @@
+package logger
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// Named возвращает дочерний логгер с именем подсистемы в поле "logger".
+// Имена вложенных логгеров соединяются точкой: logger.Named("api").Named("auth")
+// пишет "api.auth".
+func (l *Logger) Named(name string) *Logger {
+	newBaseLogger := l.baseLogger.Named(name)
+
+	child := *l
+	child.baseLogger = newBaseLogger
+	child.sugarLogger = newBaseLogger.Sugar()
+
+	return &child
+}
+
+// LevelOverrides задаёт уровни для именованных логгеров: например,
+// {"db": "debug"} выводит отладку подсистемы db и её потомков ("db.pool"),
+// оставляя остальным общий уровень. Действует самое длинное совпавшее
+// имя. Назначения Outputs с собственным уровнем в URL не получают
+// записей ниже своего уровня. Неизвестный уровень игнорируется (Build
+// возвращает ошибку).
+func LevelOverrides(overrides map[string]string) Option {
+	return func(l *Logger) {
+		for name, level := range overrides {
+			lvl, exist := loggerLevelMap[level]
+			if !exist {
+				l.optionErrs = append(l.optionErrs, fmt.Errorf("logger: unknown level %q for %q", level, name))
+				continue
+			}
+
+			if l.overrides == nil {
+				l.overrides = make(map[string]zapcore.Level)
+			}
+
+			l.overrides[name] = lvl
+		}
+	}
+}
+
+// levelGate решает, проходит ли запись уровень логгера с учётом
+// переопределений для именованных логгеров (LevelOverrides). Общий для
+// логгера и его копий. Переопределения заменяются целиком, поэтому
+// проверка записи читает их без блокировок.
+type levelGate struct {
+	level     zapcore.LevelEnabler
+	overrides atomic.Pointer[levelOverrides]
+	mu        sync.Mutex
+}
+
+// levelOverrides — уровни по именам и самый подробный из них.
+type levelOverrides struct {
+	levels map[string]zapcore.Level
+	min    zapcore.Level
+}
+
+func newLevelGate(level zapcore.LevelEnabler, levels map[string]zapcore.Level) *levelGate {
+	g := &levelGate{level: level}
+	g.overrides.Store(newLevelOverrides(levels))
+
+	return g
+}
+
+func newLevelOverrides(levels map[string]zapcore.Level) *levelOverrides {
+	o := &levelOverrides{levels: levels, min: zapcore.InvalidLevel}
+	for _, lvl := range levels {
+		if o.min == zapcore.InvalidLevel || lvl < o.min {
+			o.min = lvl
+		}
+	}
+
+	return o
+}
+
+// lookup возвращает уровень для имени name или его ближайшего предка.
+func (g *levelGate) lookup(name string) (zapcore.Level, bool) {
+	levels := g.overrides.Load().levels
+
+	for {
+		if level, exist := levels[name]; exist {
+			return level, true
+		}
+
+		i := strings.LastIndexByte(name, '.')
+		if i < 0 {
+			return 0, false
+		}
+		name = name[:i]
+	}
+}
+
+// set задаёт уровень для имени name; remove удаляет переопределение.
+// Возвращает прежний уровень имени и признак его наличия.
+func (g *levelGate) set(name string, level zapcore.Level, remove bool) (zapcore.Level, bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	current := g.overrides.Load().levels
+	prev, exist := current[name]
+
+	levels := make(map[string]zapcore.Level, len(current)+1)
+	for n, lvl := range current {
+		levels[n] = lvl
+	}
+
+	if remove {
+		delete(levels, name)
+	} else {
+		levels[name] = level
+	}
+
+	g.overrides.Store(newLevelOverrides(levels))
+
+	return prev, exist
+}
+
+// Enabled сообщает, может ли запись уровня level пройти проверку
+// хотя бы для одного имени логгера.
+func (g *levelGate) Enabled(level zapcore.Level) bool {
+	o := g.overrides.Load()
+
+	return g.level.Enabled(level) || (o.min != zapcore.InvalidLevel && level >= o.min)
+}
+
+// allows проверяет запись по уровню её логгера. Выводы с собственным
+// уровнем (own) не зависят от общего уровня, но переопределения для
+// имени логгера действуют и на них.
+func (g *levelGate) allows(entry zapcore.Entry, own bool) bool {
+	if entry.LoggerName != "" && len(g.overrides.Load().levels) > 0 {
+		if level, exist := g.lookup(entry.LoggerName); exist {
+			return level.Enabled(entry.Level)
+		}
+	}
+
+	return own || g.level.Enabled(entry.Level)
+}
+
+// gateCore проверяет уровень логгера для одного вывода. Вложенное ядро
+// создаётся без общего уровня и учитывает только собственные ограничения
+// вывода: уровень из URL, квоту диска, разделение по уровням.
+//
+// Write вызывается только в обход Check, для записей ниже уровня
+// логгера, которые одобрили VerboseWhen или WithDebugRing. Такие записи
+// получают выводы с общим уровнем, если запись проходит их собственные
+// ограничения. Выводы с собственным уровнем получают записи только через
+// Check.
+type gateCore struct {
+	zapcore.Core
+	gate *levelGate
+	own  bool
+}
+
+func (c *gateCore) Enabled(level zapcore.Level) bool {
+	if !c.own && !c.gate.Enabled(level) {
+		return false
+	}
+
+	return c.Core.Enabled(level)
+}
+
+func (c *gateCore) With(fields []zapcore.Field) zapcore.Core {
+	return &gateCore{Core: c.Core.With(fields), gate: c.gate, own: c.own}
+}
+
+func (c *gateCore) Check(entry zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.gate.allows(entry, c.own) {
+		return ce
+	}
+
+	return c.Core.Check(entry, ce)
+}
+
+func (c *gateCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	if c.own || !c.Core.Enabled(entry.Level) {
+		return nil
+	}
+
+	return c.Core.Write(entry, fields)
+}
+
+// ownLevelCore отмечает ядро вывода с собственным уровнем, на который не
+// действует общий уровень логгера.
+type ownLevelCore struct {
+	zapcore.Core
+}
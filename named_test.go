@@ -0,0 +1,106 @@
+package logger
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestNamed проверяет иерархические имена дочерних логгеров.
+func TestNamed(t *testing.T) {
+	dir := t.TempDir()
+
+	logger := NewLogger(Path(dir), Structured(true), AppName("app"))
+	require.NoError(t, logger.Init(false))
+
+	logger.Info("root")
+	auth := logger.Named("api").Named("auth")
+	auth.WithFields(map[string]interface{}{"user": "u1"}).Info("login")
+	logger.Named("db").Info("query")
+	require.NoError(t, logger.Close())
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.log"))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+
+	lines := readJSONLines(t, files[0])
+	require.Len(t, lines, 3)
+	assert.NotContains(t, lines[0], "logger")
+	assert.Equal(t, "api.auth", lines[1]["logger"])
+	assert.Equal(t, "u1", lines[1]["user"])
+	assert.Equal(t, "db", lines[2]["logger"])
+}
+
+// TestLevelOverrides проверяет уровни именованных логгеров.
+func TestLevelOverrides(t *testing.T) {
+	dir := t.TempDir()
+
+	logger := NewLogger(Path(dir), Structured(true), AppName("app"), Level("info"),
+		LevelOverrides(map[string]string{"db": "debug", "noisy": "error"}))
+	require.NoError(t, logger.Init(false))
+
+	logger.Debug("root debug")
+	logger.Info("root info")
+	logger.Named("db").Debug("db debug")
+	logger.Named("db").Named("pool").WithFields(map[string]interface{}{"conn": 1}).Debug("pool debug")
+	logger.Named("dbx").Debug("dbx debug")
+	logger.Named("noisy").Warn("noisy warn")
+	logger.Named("noisy").Error("noisy error")
+	require.NoError(t, logger.Close())
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.log"))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+
+	var messages []interface{}
+	for _, line := range readJSONLines(t, files[0]) {
+		messages = append(messages, line["message"])
+	}
+	assert.Equal(t, []interface{}{"root info", "db debug", "pool debug", "noisy error"}, messages)
+}
+
+// TestLevelOverridesUnknownLevel проверяет ошибку Build для неизвестного
+// уровня.
+func TestLevelOverridesUnknownLevel(t *testing.T) {
+	_, err := Build(Path(t.TempDir()), LevelOverrides(map[string]string{"db": "verbose"}))
+	assert.ErrorContains(t, err, `unknown level "verbose" for "db"`)
+}
+
+// TestLevelOverridesOutputLevel проверяет, что переопределение не
+// снимает собственный уровень назначения Outputs.
+func TestLevelOverridesOutputLevel(t *testing.T) {
+	for _, parallel := range []int{0, 16} {
+		dir, outputDir := t.TempDir(), t.TempDir()
+
+		logger := NewLogger(Path(dir), Structured(true), AppName("app"), Level("info"),
+			Outputs("file://"+outputDir+"?format=json&level=error"), ParallelOutputs(parallel),
+			LevelOverrides(map[string]string{"db": "debug", "noisy": "fatal"}))
+		require.NoError(t, logger.Init(false))
+
+		logger.Named("db").Debug("db debug")
+		logger.Named("noisy").Error("noisy error")
+		logger.Error("root error")
+		require.NoError(t, logger.Close())
+
+		assert.Equal(t, []interface{}{"db debug", "root error"}, logMessages(t, dir))
+		assert.Equal(t, []interface{}{"root error"}, logMessages(t, outputDir), "parallel %d", parallel)
+	}
+}
+
+// logMessages возвращает сообщения единственного файла логов в dir.
+func logMessages(t *testing.T, dir string) []interface{} {
+	t.Helper()
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.log"))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+
+	var messages []interface{}
+	for _, line := range readJSONLines(t, files[0]) {
+		messages = append(messages, line["message"])
+	}
+
+	return messages
+}
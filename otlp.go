@@ -0,0 +1,297 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	otlpLogsPath  = "/v1/logs"
+	otlpScopeName = "github.com/restfront/logger"
+)
+
+// OTLPSink добавляет экспорт записей в коллектор OpenTelemetry по
+// OTLP/HTTP в кодировке JSON. rawURL — адрес коллектора
+// ("http://otel-collector:4318"), путь по умолчанию /v1/logs. resource
+// задаёт атрибуты ресурса, например service.name. Записи кодируются в JSON
+// независимо от формата файла: сообщение становится телом записи, уровень —
+// уровнем, время — временем записи, поля TraceFields — идентификаторами
+// трассировки, остальные поля — атрибутами; имена полей берутся из
+// настроек кодировщика (RenameKeys, EncoderConfig). Тот же приёмник
+// доступен в Outputs по схемам "otlp+http" и "otlp+https" с форматом
+// "otlp", где атрибуты ресурса задаются параметрами запроса. OTLP/gRPC не
+// поддерживается: используйте HTTP-приёмник коллектора (порт 4318).
+func OTLPSink(rawURL string, resource map[string]string) Option {
+	return func(l *Logger) {
+		u, err := url.Parse(rawURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			l.optionErrs = append(l.optionErrs, fmt.Errorf("logger: invalid OTLP URL %q", rawURL))
+			return
+		}
+
+		query := u.Query()
+		for name, value := range resource {
+			if name == "level" || name == "format" {
+				l.optionErrs = append(l.optionErrs, fmt.Errorf("logger: OTLP resource attribute %q is reserved", name))
+				return
+			}
+			query.Set(name, value)
+		}
+		query.Set("format", "otlp")
+
+		u.Scheme = "otlp+" + u.Scheme
+		u.RawQuery = query.Encode()
+
+		l.outputs = append(l.outputs, u.String())
+	}
+}
+
+// otlpValue — AnyValue модели OTLP.
+type otlpValue struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	BoolValue   *bool    `json:"boolValue,omitempty"`
+	IntValue    *string  `json:"intValue,omitempty"`
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+}
+
+type otlpAttribute struct {
+	Key   string    `json:"key"`
+	Value otlpValue `json:"value"`
+}
+
+type otlpLogRecord struct {
+	TimeUnixNano         string          `json:"timeUnixNano"`
+	ObservedTimeUnixNano string          `json:"observedTimeUnixNano"`
+	SeverityNumber       int             `json:"severityNumber,omitempty"`
+	SeverityText         string          `json:"severityText,omitempty"`
+	Body                 otlpValue       `json:"body"`
+	Attributes           []otlpAttribute `json:"attributes,omitempty"`
+	TraceID              string          `json:"traceId,omitempty"`
+	SpanID               string          `json:"spanId,omitempty"`
+}
+
+// otlpString возвращает строковое AnyValue.
+func otlpString(s string) otlpValue {
+	return otlpValue{StringValue: &s}
+}
+
+// otlpAnyValue преобразует значение JSON в AnyValue; объекты и массивы
+// передаются строкой JSON.
+func otlpAnyValue(v interface{}) otlpValue {
+	switch v := v.(type) {
+	case string:
+		return otlpString(v)
+	case bool:
+		return otlpValue{BoolValue: &v}
+	case json.Number:
+		if _, err := strconv.ParseInt(string(v), 10, 64); err == nil {
+			s := string(v)
+			return otlpValue{IntValue: &s}
+		}
+		f, _ := v.Float64()
+		return otlpValue{DoubleValue: &f}
+	default:
+		data, _ := json.Marshal(v)
+		return otlpString(string(data))
+	}
+}
+
+// newOTLPEncoder кодирует записи в JSON со временем в наносекундах Unix,
+// чтобы коллектор получал точное время записи при любом TimeLayout.
+func newOTLPEncoder(cfg zapcore.EncoderConfig) zapcore.Encoder {
+	cfg.EncodeTime = zapcore.EpochNanosTimeEncoder
+
+	return zapcore.NewJSONEncoder(cfg)
+}
+
+// otlpKeys — имена полей записи, которые становятся полями модели OTLP,
+// а не атрибутами.
+type otlpKeys struct {
+	message string
+	level   string
+	time    string
+	traceID string
+	spanID  string
+}
+
+var defaultOTLPKeys = otlpKeys{
+	message: "message",
+	level:   "level",
+	time:    "time",
+	traceID: defaultTraceIDKey,
+	spanID:  defaultSpanIDKey,
+}
+
+// otlpRecord разбирает JSON-запись логгера в запись OTLP. Время
+// наблюдения — время получения записи назначением.
+func otlpRecord(entry batchEntry, keys otlpKeys) otlpLogRecord {
+	observed := strconv.FormatInt(entry.ts.UnixNano(), 10)
+	record := otlpLogRecord{TimeUnixNano: observed, ObservedTimeUnixNano: observed}
+
+	decoder := json.NewDecoder(strings.NewReader(entry.line))
+	decoder.UseNumber()
+
+	var fields map[string]interface{}
+	if err := decoder.Decode(&fields); err != nil {
+		record.Body = otlpString(entry.line)
+		return record
+	}
+
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, key := range names {
+		value := fields[key]
+		s, isString := value.(string)
+
+		switch {
+		case key == keys.message:
+			record.Body = otlpAnyValue(value)
+		case key == keys.level && isString:
+			var level zapcore.Level
+			if level.UnmarshalText([]byte(s)) == nil {
+				record.SeverityNumber = otelSeverityNumbers[level]
+			}
+			record.SeverityText = strings.ToUpper(s)
+		case key == keys.time:
+			if ts, ok := otlpTime(value); ok {
+				record.TimeUnixNano = strconv.FormatInt(ts.UnixNano(), 10)
+			}
+		case key == keys.traceID && isString:
+			record.TraceID = s
+		case key == keys.spanID && isString:
+			record.SpanID = s
+		default:
+			record.Attributes = append(record.Attributes, otlpAttribute{Key: key, Value: otlpAnyValue(value)})
+		}
+	}
+
+	return record
+}
+
+// otlpTime разбирает время записи: число — время Unix в секундах,
+// миллисекундах или наносекундах (кодировщики Epoch* в zap), строка —
+// RFC 3339 или ISO 8601.
+func otlpTime(value interface{}) (time.Time, bool) {
+	switch v := value.(type) {
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			switch {
+			case n >= 1e17:
+				return time.Unix(0, n), true
+			case n >= 1e11:
+				return time.UnixMilli(n), true
+			}
+		}
+
+		f, err := v.Float64()
+		if err != nil {
+			return time.Time{}, false
+		}
+
+		return time.Unix(0, int64(f*float64(time.Second))), true
+	case string:
+		for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05.000Z0700"} {
+			if ts, err := time.Parse(layout, v); err == nil {
+				return ts, true
+			}
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// otlpSink отправляет записи пачками, разбирая их по именам полей
+// кодировщика логгера.
+type otlpSink struct {
+	*batchSink
+	keys otlpKeys
+}
+
+func (s *otlpSink) setRecordKeys(cfg zapcore.EncoderConfig, traceIDKey, spanIDKey string) {
+	s.keys = otlpKeys{
+		message: cfg.MessageKey,
+		level:   cfg.LevelKey,
+		time:    cfg.TimeKey,
+		traceID: traceIDKey,
+		spanID:  spanIDKey,
+	}
+}
+
+func newOTLPSink(u *url.URL) (Sink, error) {
+	if u.Host == "" {
+		return nil, fmt.Errorf("logger: %s sink requires host", u.Scheme)
+	}
+
+	endpoint := *u
+	endpoint.Scheme = strings.TrimPrefix(u.Scheme, "otlp+")
+	endpoint.RawQuery = ""
+	if endpoint.Path == "" || endpoint.Path == "/" {
+		endpoint.Path = otlpLogsPath
+	}
+
+	var resource []otlpAttribute
+	for name, values := range u.Query() {
+		if name == "level" || name == "format" {
+			continue
+		}
+		resource = append(resource, otlpAttribute{Key: name, Value: otlpString(values[len(values)-1])})
+	}
+	sort.Slice(resource, func(i, j int) bool { return resource[i].Key < resource[j].Key })
+
+	client := &http.Client{Timeout: 10 * time.Second}
+
+	sink := &otlpSink{keys: defaultOTLPKeys}
+
+	sink.batchSink = newBatchSink("otlp", batchSize, batchFlushInterval, func(ctx context.Context, batch []batchEntry) (bool, error) {
+		records := make([]otlpLogRecord, len(batch))
+		for i, entry := range batch {
+			records[i] = otlpRecord(entry, sink.keys)
+		}
+
+		body, err := json.Marshal(map[string]interface{}{
+			"resourceLogs": []interface{}{
+				map[string]interface{}{
+					"resource": map[string]interface{}{"attributes": resource},
+					"scopeLogs": []interface{}{
+						map[string]interface{}{
+							"scope":      map[string]string{"name": otlpScopeName},
+							"logRecords": records,
+						},
+					},
+				},
+			},
+		})
+		if err != nil {
+			return false, err
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.String(), bytes.NewReader(body))
+		if err != nil {
+			return false, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+
+		_, retry, err := postBatch(client, req)
+		if err != nil {
+			return retry, fmt.Errorf("logger: otlp export: %w", err)
+		}
+
+		return false, nil
+	})
+
+	return sink, nil
+}
@@ -0,0 +1,140 @@
+package logger
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestOTLPSink проверяет экспорт записей с атрибутами ресурса и
+// идентификаторами трассировки при переименованных ключах.
+func TestOTLPSink(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		requests []map[string]interface{}
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, otlpLogsPath, r.URL.Path)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+		var body map[string]interface{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+
+		mu.Lock()
+		requests = append(requests, body)
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	logger := NewLogger(Path(t.TempDir()),
+		RenameKeys(EncoderKeys{Message: "msg", Level: "severity"}),
+		TraceFields("trace", "span"),
+		OTLPSink(server.URL, map[string]string{"service.name": "billing"}))
+	require.NoError(t, logger.Init(false))
+
+	logger.InfoCtx(spanContext(t), "charged")
+	logger.ErrorW("declined", Int("amount", 100), Bool("retry", false), Float64("ratio", 0.5))
+	require.NoError(t, logger.Close())
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	require.Len(t, requests, 1)
+
+	var export struct {
+		ResourceLogs []struct {
+			Resource struct {
+				Attributes []otlpAttribute `json:"attributes"`
+			} `json:"resource"`
+			ScopeLogs []struct {
+				LogRecords []otlpLogRecord `json:"logRecords"`
+			} `json:"scopeLogs"`
+		} `json:"resourceLogs"`
+	}
+	data, err := json.Marshal(requests[0])
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(data, &export))
+
+	require.Len(t, export.ResourceLogs, 1)
+	assert.Equal(t, []otlpAttribute{{Key: "service.name", Value: otlpString("billing")}}, export.ResourceLogs[0].Resource.Attributes)
+
+	records := export.ResourceLogs[0].ScopeLogs[0].LogRecords
+	require.Len(t, records, 2)
+
+	assert.Equal(t, otlpString("charged"), records[0].Body)
+	assert.Equal(t, 9, records[0].SeverityNumber)
+	assert.Equal(t, "INFO", records[0].SeverityText)
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", records[0].TraceID)
+	assert.Equal(t, "00f067aa0ba902b7", records[0].SpanID)
+	assert.NotEmpty(t, records[0].TimeUnixNano)
+
+	attributes := map[string]otlpValue{}
+	for _, attr := range records[1].Attributes {
+		attributes[attr.Key] = attr.Value
+	}
+	assert.Equal(t, 17, records[1].SeverityNumber)
+	require.NotNil(t, attributes["amount"].IntValue)
+	assert.Equal(t, "100", *attributes["amount"].IntValue)
+	require.NotNil(t, attributes["retry"].BoolValue)
+	assert.False(t, *attributes["retry"].BoolValue)
+	require.NotNil(t, attributes["ratio"].DoubleValue)
+	assert.Equal(t, 0.5, *attributes["ratio"].DoubleValue)
+	assert.Contains(t, attributes, "caller")
+	assert.NotContains(t, attributes, "msg")
+	assert.NotContains(t, attributes, "time")
+}
+
+// TestOTLPRecordPlainLine проверяет, что строка не в JSON передаётся телом.
+func TestOTLPRecordPlainLine(t *testing.T) {
+	record := otlpRecord(batchEntry{line: "plain text"}, defaultOTLPKeys)
+	assert.Equal(t, otlpString("plain text"), record.Body)
+	assert.Empty(t, record.Attributes)
+}
+
+// TestOTLPRecordKeys проверяет разбор записи с переименованными ключами и
+// время записи из самой записи, а не из времени получения.
+func TestOTLPRecordKeys(t *testing.T) {
+	keys := otlpKeys{message: "msg", level: "severity", time: "ts", traceID: "trace", spanID: "span"}
+	written := time.Date(2024, 5, 28, 12, 0, 0, 123456789, time.UTC)
+
+	record := otlpRecord(batchEntry{
+		ts: written.Add(time.Second),
+		line: `{"severity":"warn","ts":` + strconv.FormatInt(written.UnixNano(), 10) +
+			`,"msg":"disk full","trace":"4bf92f3577b34da6a3ce929d0e0e4736","span":"00f067aa0ba902b7","message":"field"}`,
+	}, keys)
+
+	assert.Equal(t, otlpString("disk full"), record.Body)
+	assert.Equal(t, 13, record.SeverityNumber)
+	assert.Equal(t, strconv.FormatInt(written.UnixNano(), 10), record.TimeUnixNano)
+	assert.Equal(t, strconv.FormatInt(written.Add(time.Second).UnixNano(), 10), record.ObservedTimeUnixNano)
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", record.TraceID)
+	assert.Equal(t, "00f067aa0ba902b7", record.SpanID)
+	assert.Equal(t, []otlpAttribute{{Key: "message", Value: otlpString("field")}}, record.Attributes)
+
+	for value, want := range map[string]time.Time{
+		`1716897600.5`:                   time.Unix(1716897600, 5e8),
+		`1716897600500`:                  time.UnixMilli(1716897600500),
+		`"2024-05-28T12:00:00.5Z"`:       time.Date(2024, 5, 28, 12, 0, 0, 5e8, time.UTC),
+		`"2024-05-28T15:00:00.500+0300"`: time.Date(2024, 5, 28, 12, 0, 0, 5e8, time.UTC),
+	} {
+		record := otlpRecord(batchEntry{line: `{"time":` + value + `}`}, defaultOTLPKeys)
+		assert.Equal(t, strconv.FormatInt(want.UnixNano(), 10), record.TimeUnixNano, value)
+	}
+}
+
+// TestOTLPSinkInvalidURL проверяет ошибку Build для неверного адреса.
+func TestOTLPSinkInvalidURL(t *testing.T) {
+	_, err := Build(Path(t.TempDir()), OTLPSink("collector:4318", nil))
+	assert.ErrorContains(t, err, `invalid OTLP URL "collector:4318"`)
+
+	_, err = Build(Path(t.TempDir()), OTLPSink("http://collector:4318", map[string]string{"level": "x"}))
+	assert.ErrorContains(t, err, "reserved")
+}
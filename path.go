@@ -0,0 +1,93 @@
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+)
+
+// pathTokenPattern находит в пути все фрагменты в фигурных скобках, чтобы
+// опечатка вроде {HOME} была ошибкой, а не каталогом с таким именем.
+var pathTokenPattern = regexp.MustCompile(`\{[^{}]*\}`)
+
+// pathTokens — подстановки, доступные в пути к логам, например
+// "{exe_dir}/logs" или "{home}/.myapp/logs".
+var pathTokens = map[string]func() (string, error){
+	"{exe_dir}": func() (string, error) {
+		exe, err := os.Executable()
+		if err != nil {
+			return "", err
+		}
+
+		return filepath.Dir(exe), nil
+	},
+	"{home}": os.UserHomeDir,
+	"{tmp}": func() (string, error) {
+		return os.TempDir(), nil
+	},
+}
+
+// expandPath подставляет в путь значения токенов {exe_dir}, {home} и {tmp};
+// неизвестный токен — ошибка.
+func expandPath(path string) (string, error) {
+	var expandErr error
+
+	expanded := pathTokenPattern.ReplaceAllStringFunc(path, func(token string) string {
+		resolve, ok := pathTokens[token]
+		if !ok {
+			if expandErr == nil {
+				expandErr = fmt.Errorf("logger: unknown token %s in log path %q", token, path)
+			}
+			return token
+		}
+
+		value, err := resolve()
+		if err != nil {
+			if expandErr == nil {
+				expandErr = fmt.Errorf("logger: cannot expand %s in log path %q: %w", token, path, err)
+			}
+			return token
+		}
+
+		return value
+	})
+	if expandErr != nil {
+		return "", expandErr
+	}
+
+	return expanded, nil
+}
+
+// validatePath проверяет, что каталог логов существует или может быть
+// создан, является каталогом и доступен для записи.
+func validatePath(path string, perm fs.FileMode) error {
+	dir := path
+	if dir == "" {
+		dir = "."
+	}
+
+	info, err := os.Stat(dir)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		if err := os.MkdirAll(dir, perm); err != nil {
+			return fmt.Errorf("logger: cannot create log directory %q: %w", dir, err)
+		}
+	case err != nil:
+		return fmt.Errorf("logger: cannot access log directory %q: %w", dir, err)
+	case !info.IsDir():
+		return fmt.Errorf("logger: log path %q is not a directory", dir)
+	}
+
+	probe, err := os.CreateTemp(dir, ".write-check-*")
+	if err != nil {
+		return fmt.Errorf("logger: log directory %q is not writable: %w", dir, err)
+	}
+
+	_ = probe.Close()
+	_ = os.Remove(probe.Name())
+
+	return nil
+}
@@ -0,0 +1,151 @@
+package logger
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestValidatePath проверяет проверку каталога логов.
+func TestValidatePath(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "logger_test")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	existingDir := filepath.Join(tmpDir, "existing")
+	require.NoError(t, os.Mkdir(existingDir, 0777))
+
+	regularFile := filepath.Join(tmpDir, "file")
+	require.NoError(t, os.WriteFile(regularFile, nil, 0666))
+
+	tests := []struct {
+		name    string
+		path    string
+		wantErr bool
+	}{
+		{
+			name: "Existing directory",
+			path: existingDir,
+		},
+		{
+			name: "Missing directory is created",
+			path: filepath.Join(tmpDir, "nested", "logs"),
+		},
+		{
+			name:    "Path is a file",
+			path:    regularFile,
+			wantErr: true,
+		},
+		{
+			name:    "Parent is a file",
+			path:    filepath.Join(regularFile, "logs"),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validatePath(tt.path, defaultDirMode)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+
+			entries, err := os.ReadDir(tt.path)
+			require.NoError(t, err)
+			assert.Empty(t, entries, "Write check file should be removed")
+		})
+	}
+}
+
+// TestLoggerInitInvalidPath проверяет, что Init возвращает ошибку для некорректного пути.
+func TestLoggerInitInvalidPath(t *testing.T) {
+	tmpFile, err := os.CreateTemp("", "logger_test")
+	require.NoError(t, err)
+	tmpFile.Close()
+	defer os.Remove(tmpFile.Name())
+
+	logger := NewLogger(Path(tmpFile.Name()))
+	err = logger.Init(false)
+	assert.ErrorContains(t, err, "is not a directory")
+}
+
+// TestExpandPath проверяет раскрытие токенов в пути к логам.
+func TestExpandPath(t *testing.T) {
+	home, err := os.UserHomeDir()
+	require.NoError(t, err)
+
+	exe, err := os.Executable()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		path     string
+		expected string
+		wantErr  bool
+	}{
+		{
+			name:     "Plain path",
+			path:     "/var/log/app",
+			expected: "/var/log/app",
+		},
+		{
+			name:     "Executable directory",
+			path:     "{exe_dir}/logs",
+			expected: filepath.Dir(exe) + "/logs",
+		},
+		{
+			name:     "Home directory",
+			path:     "{home}/.myapp/logs",
+			expected: home + "/.myapp/logs",
+		},
+		{
+			name:     "Temp directory",
+			path:     "{tmp}",
+			expected: os.TempDir(),
+		},
+		{
+			name:    "Unknown token",
+			path:    "{unknown}/logs",
+			wantErr: true,
+		},
+		{
+			name:    "Misspelled token",
+			path:    "{HOME}/logs",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path, err := expandPath(tt.path)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, path)
+		})
+	}
+}
+
+// TestInitLoggerUnknownPathToken проверяет, что InitLogger не создаёт
+// каталог с неизвестным токеном в имени и сообщает об ошибке.
+func TestInitLoggerUnknownPathToken(t *testing.T) {
+	tmpDir := t.TempDir()
+	diag := &syncBuffer{}
+
+	logger := NewLogger(Path(filepath.Join(tmpDir, "{unknown}", "logs")), Diagnostics(diag))
+	logger.InitLogger(false)
+	logger.Info("message")
+	require.NoError(t, logger.Close())
+
+	assert.NoDirExists(t, filepath.Join(tmpDir, "{unknown}"))
+	assert.Contains(t, diag.String(), "unknown token {unknown}")
+}
@@ -0,0 +1,35 @@
+package logger
+
+import (
+	"context"
+	"runtime/pprof"
+)
+
+// profileLabel — значение метки "logger" у фоновых горутин пакета.
+const profileLabel = "restfront/logger"
+
+// goLabeled запускает f в горутине с метками pprof, чтобы в профилях
+// CPU работа логгера отделялась от работы приложения.
+func goLabeled(task string, f func()) {
+	labels := pprof.Labels("logger", profileLabel, "task", task)
+
+	go pprof.Do(context.Background(), labels, func(context.Context) {
+		f()
+	})
+}
+
+// profileNameLabel — метка с именем логгера у горутин приложения. Она
+// отличается от "logger", чтобы не смешивать их с горутинами пакета.
+const profileNameLabel = "logger_name"
+
+// ProfileLabels выполняет f с меткой pprof "logger_name", равной имени
+// логгера из Named, а для логгера без имени — имени приложения. Метки
+// наследуются горутинами, запущенными из f.
+func (l *Logger) ProfileLabels(ctx context.Context, f func(ctx context.Context)) {
+	name := appName(l.appName)
+	if l.baseLogger != nil && l.baseLogger.Name() != "" {
+		name = l.baseLogger.Name()
+	}
+
+	pprof.Do(ctx, pprof.Labels(profileNameLabel, name), f)
+}
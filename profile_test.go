@@ -0,0 +1,37 @@
+package logger
+
+import (
+	"context"
+	"runtime/pprof"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestProfileLabels проверяет метку с именем логгера, а без него — с
+// именем приложения.
+func TestProfileLabels(t *testing.T) {
+	logger := NewLogger(Path(t.TempDir()), AppName("billing"))
+	require.NoError(t, logger.Init(false))
+	defer logger.Close()
+
+	for want, l := range map[string]*Logger{
+		"billing":  logger,
+		"api.auth": logger.Named("api").Named("auth"),
+	} {
+		called := false
+		l.ProfileLabels(context.Background(), func(ctx context.Context) {
+			called = true
+
+			value, ok := pprof.Label(ctx, "logger_name")
+			assert.True(t, ok)
+			assert.Equal(t, want, value)
+
+			_, ok = pprof.Label(ctx, "logger")
+			assert.False(t, ok)
+		})
+
+		assert.True(t, called)
+	}
+}
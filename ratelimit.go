@@ -0,0 +1,255 @@
+package logger
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// rateLimitSummaryInterval — период записи сводок о подавленных записях.
+const rateLimitSummaryInterval = 10 * time.Second
+
+// RateLimitKeyFunc вычисляет ключ ограничения частоты по записи; fields
+// содержит поля записи вместе с полями WithFields. Записи с одинаковым
+// ключом делят один лимит.
+type RateLimitKeyFunc func(entry zapcore.Entry, fields []zapcore.Field) string
+
+// RateLimit ограничивает частоту записей с одинаковым сообщением: в среднем
+// perSecond записей в секунду с всплесками до burst (token bucket).
+// Подавленные записи раз в 10 секунд подытоживаются записью
+// "suppressed N similar messages" с полями rate_limit_key и suppressed.
+func RateLimit(perSecond float64, burst int) Option {
+	return func(l *Logger) {
+		if perSecond <= 0 || burst < 1 {
+			l.optionErrs = append(l.optionErrs, fmt.Errorf("logger: invalid rate limit %v/s, burst %d", perSecond, burst))
+			return
+		}
+
+		l.rateLimit = perSecond
+		l.rateBurst = burst
+	}
+}
+
+// RateLimitKey задаёт ключ ограничения частоты вместо сообщения, например
+// имя подсистемы или идентификатор клиента. Действует вместе с RateLimit.
+func RateLimitKey(fn RateLimitKeyFunc) Option {
+	return func(l *Logger) {
+		l.rateLimitKey = fn
+	}
+}
+
+// rateBucket — состояние лимита одного ключа.
+type rateBucket struct {
+	tokens     float64
+	last       time.Time
+	suppressed int
+	level      zapcore.Level
+	loggerName string
+}
+
+// rateLimiter хранит лимиты по ключам и периодически пишет сводки о
+// подавленных записях в core.
+type rateLimiter struct {
+	rate  float64
+	burst float64
+	key   RateLimitKeyFunc
+	clock Clock
+	core  zapcore.Core
+
+	mu      sync.Mutex
+	buckets map[string]*rateBucket
+
+	stop chan struct{}
+	done chan struct{}
+	once sync.Once
+}
+
+func newRateLimiter(rate float64, burst int, key RateLimitKeyFunc, clock Clock, core zapcore.Core) *rateLimiter {
+	if key == nil {
+		key = func(entry zapcore.Entry, _ []zapcore.Field) string { return entry.Message }
+	}
+
+	if clock == nil {
+		clock = zapcore.DefaultClock
+	}
+
+	return &rateLimiter{
+		rate:    rate,
+		burst:   float64(burst),
+		key:     key,
+		clock:   clock,
+		core:    core,
+		buckets: make(map[string]*rateBucket),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+}
+
+// start запускает периодическую запись сводок.
+func (r *rateLimiter) start(interval time.Duration) {
+	goLabeled("rate-limit", func() { r.summaryLoop(interval) })
+}
+
+// allow расходует токен ключа key; без токенов запись считается
+// подавленной.
+func (r *rateLimiter) allow(key string, entry zapcore.Entry) bool {
+	now := r.clock.Now()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	b, ok := r.buckets[key]
+	if !ok {
+		b = &rateBucket{tokens: r.burst, last: now}
+		r.buckets[key] = b
+	}
+
+	b.tokens += now.Sub(b.last).Seconds() * r.rate
+	if b.tokens > r.burst {
+		b.tokens = r.burst
+	}
+	b.last = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return true
+	}
+
+	if b.suppressed == 0 || entry.Level > b.level {
+		b.level = entry.Level
+	}
+	b.loggerName = entry.LoggerName
+	b.suppressed++
+
+	return false
+}
+
+// summarize пишет сводки по ключам с подавленными записями и забывает
+// ключи, лимит которых полностью восстановился.
+func (r *rateLimiter) summarize() {
+	now := r.clock.Now()
+	refill := time.Duration(r.burst / r.rate * float64(time.Second))
+
+	type summary struct {
+		key string
+		rateBucket
+	}
+
+	var summaries []summary
+
+	r.mu.Lock()
+	for key, b := range r.buckets {
+		if b.suppressed > 0 {
+			summaries = append(summaries, summary{key: key, rateBucket: *b})
+			b.suppressed = 0
+			continue
+		}
+
+		if now.Sub(b.last) >= refill {
+			delete(r.buckets, key)
+		}
+	}
+	r.mu.Unlock()
+
+	for _, s := range summaries {
+		entry := zapcore.Entry{
+			Level:      s.level,
+			Time:       now,
+			LoggerName: s.loggerName,
+			Message:    fmt.Sprintf("suppressed %d similar messages", s.suppressed),
+		}
+
+		if ce := r.core.Check(entry, nil); ce != nil {
+			ce.Write(zap.String("rate_limit_key", s.key), zap.Int("suppressed", s.suppressed))
+		}
+	}
+}
+
+func (r *rateLimiter) summaryLoop(interval time.Duration) {
+	defer close(r.done)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			r.summarize()
+		case <-r.stop:
+			return
+		}
+	}
+}
+
+// Close останавливает периодические сводки и пишет последнюю.
+func (r *rateLimiter) Close() error {
+	r.once.Do(func() { close(r.stop) })
+	<-r.done
+
+	r.summarize()
+
+	return nil
+}
+
+// rateLimitCore пропускает во вложенное ядро только записи, уложившиеся
+// в лимит своего ключа. Решение принимается в Write, когда известны поля
+// записи.
+type rateLimitCore struct {
+	zapcore.Core
+	limiter *rateLimiter
+	fields  []zapcore.Field
+}
+
+func (c *rateLimitCore) With(fields []zapcore.Field) zapcore.Core {
+	return &rateLimitCore{
+		Core:    c.Core.With(fields),
+		limiter: c.limiter,
+		fields:  append(c.fields[:len(c.fields):len(c.fields)], fields...),
+	}
+}
+
+func (c *rateLimitCore) Check(entry zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Core.Enabled(entry.Level) {
+		return ce.AddCore(entry, rateLimitWrite{c})
+	}
+
+	return ce
+}
+
+// Write пишет запись в обход Check (VerboseWhen, WithDebugRing): её уровень
+// уже одобрен, поэтому проверяется только лимит.
+func (c *rateLimitCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	if !c.allow(entry, fields) {
+		return nil
+	}
+
+	return c.Core.Write(entry, fields)
+}
+
+func (c *rateLimitCore) allow(entry zapcore.Entry, fields []zapcore.Field) bool {
+	all := append(c.fields[:len(c.fields):len(c.fields)], fields...)
+
+	return c.limiter.allow(c.limiter.key(entry, all), entry)
+}
+
+// rateLimitWrite — запись, выбранная Check.
+type rateLimitWrite struct {
+	*rateLimitCore
+}
+
+func (w rateLimitWrite) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	if !w.allow(entry, fields) {
+		return nil
+	}
+
+	// Проверка вложенного ядра сохраняет сэмплирование и переопределения
+	// уровней.
+	if inner := w.Core.Check(entry, nil); inner != nil {
+		return writeChecked(inner, fields)
+	}
+
+	return nil
+}
@@ -0,0 +1,128 @@
+package logger
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestRateLimiter(t *testing.T) {
+	core, logs := observer.New(zap.DebugLevel)
+	clock := &fakeClock{now: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)}
+
+	limiter := newRateLimiter(1, 2, nil, clock, core)
+	base := zap.New(&rateLimitCore{Core: core, limiter: limiter}).Named("worker")
+
+	for i := 0; i < 5; i++ {
+		base.Error("connection refused")
+	}
+	base.Info("other message")
+
+	assert.Equal(t, 3, logs.Len())
+
+	clock.Add(time.Second)
+	base.Error("connection refused")
+	base.Error("connection refused")
+	assert.Equal(t, 4, logs.Len())
+
+	limiter.summarize()
+	require.Equal(t, 5, logs.Len())
+
+	summary := logs.All()[4]
+	assert.Equal(t, "suppressed 4 similar messages", summary.Message)
+	assert.Equal(t, zapcore.ErrorLevel, summary.Level)
+	assert.Equal(t, "worker", summary.LoggerName)
+	assert.Equal(t, "connection refused", summary.ContextMap()["rate_limit_key"])
+	assert.EqualValues(t, 4, summary.ContextMap()["suppressed"])
+
+	// Без новых подавлений сводка не повторяется, восстановившиеся ключи
+	// забываются.
+	clock.Add(time.Minute)
+	limiter.summarize()
+	assert.Equal(t, 5, logs.Len())
+	assert.Empty(t, limiter.buckets)
+}
+
+func TestRateLimitKey(t *testing.T) {
+	core, logs := observer.New(zap.DebugLevel)
+	key := func(_ zapcore.Entry, fields []zapcore.Field) string {
+		for _, field := range fields {
+			if field.Key == "subsystem" {
+				return field.String
+			}
+		}
+		return ""
+	}
+
+	limiter := newRateLimiter(1, 1, key, &fakeClock{now: time.Now()}, core)
+	base := zap.New(&rateLimitCore{Core: core, limiter: limiter})
+
+	db := base.With(zap.String("subsystem", "db"))
+	db.Warn("slow query")
+	db.Warn("pool exhausted")
+	base.Warn("slow query", zap.String("subsystem", "cache"))
+
+	require.Equal(t, 2, logs.Len())
+	assert.Equal(t, "slow query", logs.All()[0].Message)
+	assert.Equal(t, "cache", logs.All()[1].ContextMap()["subsystem"])
+}
+
+func TestRateLimitOption(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	logger := NewLogger(Path(tmpDir), Structured(true), RateLimit(0.001, 3))
+	require.NoError(t, logger.Init(false))
+
+	for i := 0; i < 10; i++ {
+		logger.Warn("disk full")
+	}
+	require.NoError(t, logger.Close())
+	assert.NotPanics(t, func() { _ = logger.Close() }, "Repeated Close should be safe")
+
+	lines := readJSONLines(t, filepath.Join(tmpDir, time.Now().Format(dateLayout)+".log"))
+	require.Len(t, lines, 4)
+	assert.Equal(t, "disk full", lines[2]["message"])
+	assert.Equal(t, "suppressed 7 similar messages", lines[3]["message"])
+	assert.EqualValues(t, 7, lines[3]["suppressed"])
+
+	_, err := Build(Path(t.TempDir()), RateLimit(0, 1))
+	assert.ErrorContains(t, err, "invalid rate limit")
+}
+
+// failingWriter отклоняет все записи.
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func (failingWriter) Sync() error {
+	return nil
+}
+
+// TestRateLimitWriteError проверяет, что ошибки вложенного ядра
+// возвращаются, а запись в обход Check проходит мимо проверки уровня.
+func TestRateLimitWriteError(t *testing.T) {
+	failing := zapcore.NewCore(zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()), failingWriter{}, zap.InfoLevel)
+	limiter := newRateLimiter(1, 1, nil, &fakeClock{now: time.Now()}, failing)
+	core := &rateLimitCore{Core: failing, limiter: limiter}
+
+	errOutput := &syncBuffer{}
+	base := zap.New(core, zap.ErrorOutput(zapcore.AddSync(errOutput)))
+	base.Info("first")
+	assert.Contains(t, errOutput.String(), "disk full")
+
+	assert.ErrorContains(t, core.Write(zapcore.Entry{Level: zapcore.InfoLevel, Message: "second"}, nil), "disk full")
+
+	observed, logs := observer.New(zap.InfoLevel)
+	core = &rateLimitCore{Core: observed, limiter: newRateLimiter(1, 1, nil, &fakeClock{now: time.Now()}, observed)}
+	require.NoError(t, core.Write(zapcore.Entry{Level: zapcore.DebugLevel, Message: "approved"}, nil))
+	assert.Equal(t, 1, logs.Len())
+}
@@ -0,0 +1,240 @@
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"sync/atomic"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// RedactedValue заменяет скрытые значения.
+const RedactedValue = "[REDACTED]"
+
+// Шаблоны часто скрываемых данных для RedactPatterns.
+const (
+	// PatternCreditCard — номера карт из 13–19 цифр, в том числе
+	// разделённые пробелами или дефисами.
+	PatternCreditCard = `\b(?:\d[ -]?){12,18}\d\b`
+	// PatternEmail — адреса электронной почты.
+	PatternEmail = `[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`
+)
+
+// RedactFields скрывает значения полей с именами names (без учёта
+// регистра), в том числе вложенных в объекты, например "password",
+// "token", "authorization".
+func RedactFields(names ...string) Option {
+	return func(l *Logger) {
+		if l.redactFields == nil {
+			l.redactFields = make(map[string]bool)
+		}
+
+		for _, name := range names {
+			l.redactFields[strings.ToLower(name)] = true
+		}
+	}
+}
+
+// RedactPatterns скрывает совпадения регулярных выражений в тексте
+// сообщений и строковых значениях полей, например PatternCreditCard и
+// PatternEmail. Неверное выражение игнорируется (Build возвращает ошибку).
+func RedactPatterns(patterns ...string) Option {
+	return func(l *Logger) {
+		for _, pattern := range patterns {
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				l.optionErrs = append(l.optionErrs, fmt.Errorf("logger: invalid redact pattern %q: %w", pattern, err))
+				continue
+			}
+
+			l.redactPatterns = append(l.redactPatterns, re)
+		}
+	}
+}
+
+// redactor скрывает значения по именам полей и шаблонам.
+type redactor struct {
+	fields   map[string]bool
+	patterns []*regexp.Regexp
+}
+
+func (l *Logger) redactor() *redactor {
+	return &redactor{fields: l.redactFields, patterns: l.redactPatterns}
+}
+
+func (r *redactor) enabled() bool {
+	return r != nil && (len(r.fields) > 0 || len(r.patterns) > 0)
+}
+
+func (r *redactor) redactString(s string) string {
+	for _, re := range r.patterns {
+		s = re.ReplaceAllString(s, RedactedValue)
+	}
+
+	return s
+}
+
+// redactField возвращает поля со скрытыми данными и признак изменения.
+// Составные значения раскладываются кодировщиком и заменяются
+// получившимися полями: ошибка, например, даёт поля error и errorVerbose.
+func (r *redactor) redactField(field zapcore.Field) ([]zapcore.Field, bool) {
+	if r.fields[strings.ToLower(field.Key)] {
+		return []zapcore.Field{zap.String(field.Key, RedactedValue)}, true
+	}
+
+	switch field.Type {
+	case zapcore.StringType:
+		value := r.redactString(field.String)
+		return []zapcore.Field{zap.String(field.Key, value)}, value != field.String
+
+	case zapcore.ObjectMarshalerType, zapcore.ArrayMarshalerType, zapcore.ReflectType,
+		zapcore.StringerType, zapcore.ErrorType:
+		enc := zapcore.NewMapObjectEncoder()
+		field.AddTo(enc)
+
+		keys := make([]string, 0, len(enc.Fields))
+		for key := range enc.Fields {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		changed := false
+		redacted := make([]zapcore.Field, 0, len(keys))
+		for _, key := range keys {
+			value, ok := r.redactValue(key, enc.Fields[key])
+			redacted = append(redacted, zap.Any(key, value))
+			changed = changed || ok
+		}
+
+		return redacted, changed
+	}
+
+	return nil, false
+}
+
+// redactValue скрывает данные в значении, полученном из MapObjectEncoder.
+func (r *redactor) redactValue(key string, value interface{}) (interface{}, bool) {
+	if r.fields[strings.ToLower(key)] {
+		return RedactedValue, true
+	}
+
+	switch v := value.(type) {
+	case string:
+		redacted := r.redactString(v)
+		return redacted, redacted != v
+	case map[string]interface{}:
+		changed := false
+		out := make(map[string]interface{}, len(v))
+		for k, item := range v {
+			redacted, ok := r.redactValue(k, item)
+			out[k] = redacted
+			changed = changed || ok
+		}
+		return out, changed
+	case []interface{}:
+		changed := false
+		out := make([]interface{}, len(v))
+		for i, item := range v {
+			redacted, ok := r.redactValue("", item)
+			out[i] = redacted
+			changed = changed || ok
+		}
+		return out, changed
+	}
+
+	// Значения zap.Any и zap.Reflect кодировщик хранит как есть; их
+	// содержимое проверяется в виде JSON.
+	switch reflect.ValueOf(value).Kind() {
+	case reflect.Struct, reflect.Pointer, reflect.Map, reflect.Slice, reflect.Array:
+		data, err := json.Marshal(value)
+		if err != nil {
+			return value, false
+		}
+
+		var decoded interface{}
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			return value, false
+		}
+
+		if redacted, changed := r.redactValue(key, decoded); changed {
+			return redacted, true
+		}
+	}
+
+	return value, false
+}
+
+func (r *redactor) redactFields(fields []zapcore.Field) []zapcore.Field {
+	var redacted []zapcore.Field
+
+	for i, field := range fields {
+		values, changed := r.redactField(field)
+		if !changed {
+			if redacted != nil {
+				redacted = append(redacted, field)
+			}
+			continue
+		}
+
+		if redacted == nil {
+			redacted = append([]zapcore.Field(nil), fields[:i]...)
+		}
+		redacted = append(redacted, values...)
+	}
+
+	if redacted == nil {
+		return fields
+	}
+
+	return redacted
+}
+
+// newRedactorRef возвращает изменяемую ссылку на правила маскирования,
+// общую для всех redactCore логгера.
+func newRedactorRef(r *redactor) *atomic.Pointer[redactor] {
+	ref := new(atomic.Pointer[redactor])
+	ref.Store(r)
+
+	return ref
+}
+
+// redactCore скрывает данные в сообщении и полях записей перед
+// кодированием. Правила берутся по ссылке и могут смениться на ходу
+// (ReloadConfig); поля, добавленные через With, маскируются по правилам,
+// действовавшим в момент вызова With.
+type redactCore struct {
+	zapcore.Core
+	rules *atomic.Pointer[redactor]
+}
+
+func (c *redactCore) With(fields []zapcore.Field) zapcore.Core {
+	if r := c.rules.Load(); r.enabled() {
+		fields = r.redactFields(fields)
+	}
+
+	return &redactCore{Core: c.Core.With(fields), rules: c.rules}
+}
+
+func (c *redactCore) Check(entry zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.rules.Load().enabled() {
+		return c.Core.Check(entry, ce)
+	}
+
+	if c.Enabled(entry.Level) {
+		return ce.AddCore(entry, c)
+	}
+
+	return ce
+}
+
+func (c *redactCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	r := c.rules.Load()
+	entry.Message = r.redactString(entry.Message)
+
+	return c.Core.Write(entry, r.redactFields(fields))
+}
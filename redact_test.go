@@ -0,0 +1,72 @@
+package logger
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+type credentials struct {
+	User     string `json:"user"`
+	Password string `json:"password"`
+}
+
+func TestRedactCore(t *testing.T) {
+	core, logs := observer.New(zap.DebugLevel)
+
+	logger := NewLogger(RedactFields("Password", "token"), RedactPatterns(PatternCreditCard, PatternEmail))
+	base := zap.New(&redactCore{Core: core, rules: newRedactorRef(logger.redactor())}).With(zap.String("token", "secret"))
+
+	base.Info("payment by alice@example.com with card 4111 1111 1111 1111",
+		zap.String("password", "hunter2"),
+		zap.String("note", "contact bob@example.com"),
+		zap.Int("amount", 100),
+		zap.Any("credentials", credentials{User: "alice", Password: "hunter2"}),
+		zap.Error(errors.New("card 4111-1111-1111-1111 declined")),
+	)
+
+	entries := logs.All()
+	require.Len(t, entries, 1)
+
+	assert.Equal(t, "payment by [REDACTED] with card [REDACTED]", entries[0].Message)
+	assert.Equal(t, map[string]interface{}{
+		"token":       RedactedValue,
+		"password":    RedactedValue,
+		"note":        "contact [REDACTED]",
+		"amount":      int64(100),
+		"credentials": map[string]interface{}{"user": "alice", "password": RedactedValue},
+		"error":       "card [REDACTED] declined",
+	}, entries[0].ContextMap())
+}
+
+func TestRedactUnchanged(t *testing.T) {
+	r := NewLogger(RedactFields("password")).redactor()
+
+	fields := []zapcore.Field{zap.String("user", "alice"), zap.Int("n", 1)}
+	assert.Equal(t, fields, r.redactFields(fields))
+	assert.False(t, NewLogger().redactor().enabled())
+}
+
+func TestRedactFile(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	logger := NewLogger(Path(tmpDir), Structured(true), RedactFields("authorization"))
+	require.NoError(t, logger.Init(false))
+
+	logger.WithFields(map[string]interface{}{"authorization": "Bearer abc"}).Info("request")
+	require.NoError(t, logger.Close())
+
+	lines := readJSONLines(t, filepath.Join(tmpDir, time.Now().Format(dateLayout)+".log"))
+	require.Len(t, lines, 1)
+	assert.Equal(t, RedactedValue, lines[0]["authorization"])
+
+	_, err := Build(Path(tmpDir), RedactPatterns("("))
+	assert.ErrorContains(t, err, "invalid redact pattern")
+}
@@ -0,0 +1,212 @@
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"reflect"
+	"regexp"
+	"sync"
+	"syscall"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// configWatchInterval — период проверки изменения файла настроек.
+const configWatchInterval = time.Second
+
+// reloadState — общее для логгера и его копий состояние ReloadConfig.
+// base — правила маскирования из опций, к которым ReloadConfig добавляет
+// правила новой конфигурации.
+type reloadState struct {
+	mu     sync.Mutex
+	config *Config
+	base   *redactor
+}
+
+// ReloadConfig применяет к работающему логгеру уровень, сэмплирование и
+// маскирование из cfg, не пересоздавая ядра и не переоткрывая файлы.
+// Пустой уровень оставляет текущий. Сэмплирование заменяется целиком.
+// Правила маскирования из прежней конфигурации заменяются новыми, а поля
+// и шаблоны, скрытые опциями при создании логгера, остаются скрытыми.
+// Остальные настройки
+// (путь, формат, ротация, назначения) требуют перезапуска; их изменение
+// отмечается в диагностике. При ошибке в cfg ничего не меняется.
+func (l *Logger) ReloadConfig(cfg Config) error {
+	if l.reload == nil {
+		return fmt.Errorf("logger: not initialized")
+	}
+
+	parsed := NewLogger(cfg.options()...)
+	if len(parsed.optionErrs) > 0 {
+		return errors.Join(parsed.optionErrs...)
+	}
+
+	l.reload.mu.Lock()
+	defer l.reload.mu.Unlock()
+
+	if cfg.Level != "" {
+		if err := l.SetLevel(cfg.Level); err != nil {
+			return err
+		}
+	}
+
+	l.sampler.setRates(parsed.sampling)
+	l.redaction.Store(l.reload.base.merge(parsed.redactor()))
+
+	if prev := l.reload.config; prev != nil && !reflect.DeepEqual(prev.static(), cfg.static()) {
+		diagLogger(l.diag).Warn("config changes require restart",
+			zap.String("applied", "level, sampling, redaction"))
+	}
+	l.reload.config = &cfg
+
+	return nil
+}
+
+// static возвращает настройки, которые нельзя сменить без перезапуска.
+func (cfg Config) static() Config {
+	cfg.Level = ""
+	cfg.Sampling = nil
+	cfg.Redaction = RedactionConfig{}
+
+	return cfg
+}
+
+// merge объединяет правила r и other без повторов.
+func (r *redactor) merge(other *redactor) *redactor {
+	fields := make(map[string]bool, len(r.fields)+len(other.fields))
+	for name := range r.fields {
+		fields[name] = true
+	}
+	for name := range other.fields {
+		fields[name] = true
+	}
+
+	var patterns []*regexp.Regexp
+	seen := make(map[string]bool, len(r.patterns)+len(other.patterns))
+	for _, re := range append(r.patterns[:len(r.patterns):len(r.patterns)], other.patterns...) {
+		if !seen[re.String()] {
+			seen[re.String()] = true
+			patterns = append(patterns, re)
+		}
+	}
+
+	return &redactor{fields: fields, patterns: patterns}
+}
+
+// WatchConfig применяет настройки из файла path (см. LoadConfigFile и
+// ReloadConfig) и перечитывает его при изменении и по сигналу SIGHUP.
+// Ошибки перечитывания пишутся в диагностику, прежние настройки при этом
+// сохраняются. Наблюдение останавливает Close. Вызывается после Init.
+func (l *Logger) WatchConfig(path string) error {
+	return l.watchConfig(path, configWatchInterval)
+}
+
+func (l *Logger) watchConfig(path string, interval time.Duration) error {
+	cfg, err := LoadConfigFile(path)
+	if err != nil {
+		return err
+	}
+
+	if err := l.ReloadConfig(cfg); err != nil {
+		return err
+	}
+
+	w := &configWatcher{
+		logger:  l,
+		path:    path,
+		signals: make(chan os.Signal, 1),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	w.changed()
+
+	signal.Notify(w.signals, syscall.SIGHUP)
+	go w.run(interval)
+
+	// Наблюдатель останавливается раньше, чем закрываются выводы.
+	l.closers = append([]io.Closer{w}, l.closers...)
+
+	return nil
+}
+
+// configWatcher перечитывает файл настроек логгера.
+type configWatcher struct {
+	logger  *Logger
+	path    string
+	modTime time.Time
+	size    int64
+
+	signals chan os.Signal
+	stop    chan struct{}
+	done    chan struct{}
+	once    sync.Once
+}
+
+func (w *configWatcher) run(interval time.Duration) {
+	defer close(w.done)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.stop:
+			return
+		case <-w.signals:
+			w.changed()
+			w.reload("signal")
+		case <-ticker.C:
+			if w.changed() {
+				w.reload("file change")
+			}
+		}
+	}
+}
+
+// changed запоминает время изменения и размер файла и сообщает, изменились
+// ли они с прошлой проверки.
+func (w *configWatcher) changed() bool {
+	info, err := os.Stat(w.path)
+	if err != nil {
+		return false
+	}
+
+	if info.ModTime().Equal(w.modTime) && info.Size() == w.size {
+		return false
+	}
+
+	w.modTime, w.size = info.ModTime(), info.Size()
+
+	return true
+}
+
+func (w *configWatcher) reload(reason string) {
+	diag := diagLogger(w.logger.diag)
+
+	cfg, err := LoadConfigFile(w.path)
+	if err == nil {
+		err = w.logger.ReloadConfig(cfg)
+	}
+
+	if err != nil {
+		diag.Warn("config reload failed", zap.String("path", w.path), zap.String("reason", reason), zap.Error(err))
+		return
+	}
+
+	diag.Info("config reloaded", zap.String("path", w.path), zap.String("reason", reason),
+		zap.Stringer("level", w.logger.atomicLevel.Level()))
+}
+
+func (w *configWatcher) Close() error {
+	w.once.Do(func() {
+		signal.Stop(w.signals)
+		close(w.stop)
+	})
+	<-w.done
+
+	return nil
+}
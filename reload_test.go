@@ -0,0 +1,131 @@
+package logger
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// TestReloadConfig проверяет смену уровня, сэмплирования и маскирования
+// на ходу.
+func TestReloadConfig(t *testing.T) {
+	tmpDir := t.TempDir()
+	diag := &syncBuffer{}
+
+	logger, err := NewFromConfig(Config{Path: tmpDir, Structured: true, Redaction: RedactionConfig{Fields: []string{"secret"}}},
+		Diagnostics(diag), RedactFields("password"))
+	require.NoError(t, err)
+	require.NoError(t, logger.Init(false))
+
+	child := logger.WithFields(map[string]interface{}{"component": "api"})
+	child.Debug("hidden")
+
+	require.NoError(t, logger.ReloadConfig(Config{
+		Path:       tmpDir,
+		Structured: true,
+		Level:      "debug",
+		Sampling:   map[string]SamplingConfig{"info": {First: 1, Thereafter: 1000}},
+		Redaction:  RedactionConfig{Fields: []string{"token"}},
+	}))
+	assert.NotContains(t, diag.String(), "require restart")
+
+	child.Debug("visible")
+	for i := 0; i < 3; i++ {
+		child.InfoW("sampled", zap.String("token", "t1"), zap.String("password", "p1"))
+	}
+
+	require.NoError(t, logger.ReloadConfig(Config{Path: tmpDir, Structured: true}))
+	for i := 0; i < 2; i++ {
+		child.InfoW("unsampled", zap.String("token", "t2"), zap.String("password", "p2"), zap.String("secret", "s2"))
+	}
+	require.NoError(t, logger.Close())
+
+	lines := readJSONLines(t, filepath.Join(tmpDir, time.Now().Format(dateLayout)+".log"))
+	require.Len(t, lines, 4)
+
+	assert.Equal(t, "visible", lines[0]["message"])
+	assert.Equal(t, "sampled", lines[1]["message"])
+	assert.Equal(t, RedactedValue, lines[1]["token"])
+	assert.Equal(t, RedactedValue, lines[1]["password"])
+
+	// Правила из конфигурации снимаются, правила из опций остаются.
+	assert.Equal(t, "unsampled", lines[3]["message"])
+	assert.Equal(t, "t2", lines[3]["token"])
+	assert.Equal(t, "s2", lines[3]["secret"])
+	assert.Equal(t, RedactedValue, lines[3]["password"])
+	assert.Equal(t, "api", lines[3]["component"])
+}
+
+// TestReloadConfigRedactPatterns проверяет, что повторная загрузка не
+// дублирует шаблоны маскирования.
+func TestReloadConfigRedactPatterns(t *testing.T) {
+	cfg := Config{Path: t.TempDir(), Redaction: RedactionConfig{Patterns: []string{PatternEmail}}}
+
+	logger, err := NewFromConfig(cfg, RedactPatterns(PatternEmail))
+	require.NoError(t, err)
+	require.NoError(t, logger.Init(false))
+	defer logger.Close()
+	assert.Len(t, logger.redaction.Load().patterns, 1)
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, logger.ReloadConfig(cfg))
+		assert.Len(t, logger.redaction.Load().patterns, 1)
+	}
+
+	require.NoError(t, logger.ReloadConfig(Config{Path: cfg.Path}))
+	assert.Len(t, logger.redaction.Load().patterns, 1)
+}
+
+// TestReloadConfigErrors проверяет, что неверная конфигурация не
+// применяется частично, а изменение статических настроек отмечается.
+func TestReloadConfigErrors(t *testing.T) {
+	assert.Error(t, NewLogger().ReloadConfig(Config{Level: "debug"}))
+
+	diag := &syncBuffer{}
+	logger := NewLogger(Path(t.TempDir()), Diagnostics(diag))
+	require.NoError(t, logger.Init(false))
+	defer logger.Close()
+
+	err := logger.ReloadConfig(Config{Level: "debug", Redaction: RedactionConfig{Patterns: []string{"("}}})
+	assert.ErrorContains(t, err, "invalid redact pattern")
+	assert.Equal(t, zapcore.InfoLevel, logger.atomicLevel.Level())
+
+	require.NoError(t, logger.ReloadConfig(Config{Path: "/var/log/app"}))
+	require.NoError(t, logger.ReloadConfig(Config{Path: "/var/log/other"}))
+	assert.Contains(t, diag.String(), "config changes require restart")
+}
+
+// TestWatchConfig проверяет перечитывание файла настроек при изменении.
+func TestWatchConfig(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "logger.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("level: warn\n"), 0o600))
+
+	diag := &syncBuffer{}
+	logger := NewLogger(Path(dir), Diagnostics(diag))
+	require.NoError(t, logger.Init(false))
+
+	require.NoError(t, logger.watchConfig(path, 10*time.Millisecond))
+	assert.Equal(t, zapcore.WarnLevel, logger.atomicLevel.Level())
+
+	require.NoError(t, os.WriteFile(path, []byte("level: error\nsampling: {info: {first: 1}}\n"), 0o600))
+	assert.Eventually(t, func() bool {
+		return logger.atomicLevel.Level() == zapcore.ErrorLevel
+	}, 5*time.Second, 10*time.Millisecond)
+
+	require.NoError(t, os.WriteFile(path, []byte("level: [debug]\n"), 0o600))
+	assert.Eventually(t, func() bool {
+		return strings.Contains(diag.String(), "config reload failed")
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, zapcore.ErrorLevel, logger.atomicLevel.Level())
+
+	assert.ErrorIs(t, logger.WatchConfig(filepath.Join(dir, "missing.yaml")), os.ErrNotExist)
+	require.NoError(t, logger.Close())
+}
@@ -0,0 +1,35 @@
+//go:build darwin || dragonfly || freebsd || linux || netbsd || openbsd
+
+package logger
+
+import (
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+// TestWatchConfigSIGHUP проверяет перечитывание настроек по SIGHUP.
+func TestWatchConfigSIGHUP(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "logger.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("level: warn\n"), 0o600))
+
+	logger := NewLogger(Path(dir))
+	require.NoError(t, logger.Init(false))
+	defer logger.Close()
+
+	require.NoError(t, logger.watchConfig(path, time.Hour))
+
+	require.NoError(t, os.WriteFile(path, []byte("level: info\n"), 0o600))
+	require.NoError(t, syscall.Kill(os.Getpid(), syscall.SIGHUP))
+
+	assert.Eventually(t, func() bool {
+		return logger.atomicLevel.Level() == zapcore.InfoLevel
+	}, 5*time.Second, 10*time.Millisecond)
+}
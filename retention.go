@@ -0,0 +1,285 @@
+package logger
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// MaxAge удаляет файлы и архивы логов, последняя запись в которые была
+// больше days суток назад. Ноль отключает удаление по возрасту.
+func MaxAge(days int) Option {
+	return func(l *Logger) {
+		l.maxAge = time.Duration(days) * 24 * time.Hour
+	}
+}
+
+// MaxBackups оставляет не больше n прошлых файлов и архивов логов, не
+// считая текущего. Ноль отключает ограничение.
+func MaxBackups(n int) Option {
+	return func(l *Logger) {
+		l.maxBackups = n
+	}
+}
+
+// MaxTotalSize ограничивает суммарный размер файлов и архивов логов в
+// каталоге bytes байтами, включая файлы ошибок SplitByLevel и журнал
+// аудита. При превышении удаляются самые старые прошлые файлы, кроме
+// файлов аудита; если квота превышена одними текущими файлами и аудитом,
+// записи уровня debug отбрасываются до освобождения места. Ноль отключает
+// ограничение.
+func MaxTotalSize(bytes int64) Option {
+	return func(l *Logger) {
+		l.maxTotalSize = bytes
+	}
+}
+
+// Compress включает или отключает сжатие прошлых файлов логов. По
+// умолчанию сжатие включено.
+func Compress(enable bool) Option {
+	return func(l *Logger) {
+		l.noCompress = !enable
+	}
+}
+
+// logBackup — прошлый файл или архив логов.
+type logBackup struct {
+	path    string
+	date    time.Time
+	segment int
+	archive bool
+	size    int64
+	// own отмечает файл этого процесса; файлы с {pid} других процессов
+	// только учитываются и удаляются очисткой.
+	own bool
+}
+
+func (r *fileRotator) hasRetention() bool {
+	return r.maxAge > 0 || r.maxBackups > 0 || r.maxTotalSize > 0
+}
+
+// applyRetention удаляет файлы сверх MaxBackups, старше MaxAge и самые
+// старые файлы сверх MaxTotalSize. Самый новый несжатый файл считается
+// текущим и не удаляется. В квоту входят и файлы ротаторов-участников
+// (addQuotaPeer).
+func (r *fileRotator) applyRetention() {
+	if !r.hasRetention() {
+		return
+	}
+
+	r.quotaMu.Lock()
+	defer r.quotaMu.Unlock()
+
+	backups, total := r.retentionBackups()
+
+	cutoff := r.periodDate(r.now()).Add(-r.maxAge)
+
+	var kept []logBackup
+
+	for i, backup := range backups {
+		expired := r.maxAge > 0 && backup.date.Add(r.periodLength()).Before(cutoff)
+		excess := r.maxBackups > 0 && i >= r.maxBackups
+
+		if !expired && !excess {
+			kept = append(kept, backup)
+			continue
+		}
+
+		if r.removeBackup(backup, zap.Bool("expired", expired)) {
+			total -= backup.size
+		} else {
+			kept = append(kept, backup)
+		}
+	}
+
+	if r.maxTotalSize > 0 {
+		for _, peer := range r.quotaPeers {
+			peerBackups, peerTotal := peer.rotator.retentionBackups()
+			total += peerTotal
+			if !peer.keep {
+				kept = append(kept, peerBackups...)
+			}
+		}
+
+		sortBackups(kept)
+
+		for i := len(kept) - 1; i >= 0 && total > r.maxTotalSize; i-- {
+			if r.removeBackup(kept[i], zap.Bool("quota", true)) {
+				total -= kept[i].size
+			}
+		}
+
+		r.updateUsage(total)
+	}
+}
+
+// retentionBackups возвращает прошлые файлы от новых к старым без
+// текущего и суммарный размер всех файлов вместе с текущим. Файлы других
+// процессов за текущий период считаются текущими и не возвращаются.
+func (r *fileRotator) retentionBackups() ([]logBackup, int64) {
+	backups := r.listBackups()
+
+	var total int64
+	for _, backup := range backups {
+		total += backup.size
+	}
+
+	sortBackups(backups)
+
+	layout := r.filenamePattern().layout()
+	current := r.periodDate(r.now()).Format(layout)
+	seenOwn := false
+	past := backups[:0]
+
+	for _, backup := range backups {
+		if !backup.own {
+			if backup.date.Format(layout) != current {
+				past = append(past, backup)
+			}
+			continue
+		}
+
+		// Самый новый несжатый файл процесса — текущий.
+		if !seenOwn && !backup.archive {
+			seenOwn = true
+			continue
+		}
+		seenOwn = true
+
+		past = append(past, backup)
+	}
+
+	return past, total
+}
+
+// sortBackups упорядочивает файлы от новых к старым.
+func sortBackups(backups []logBackup) {
+	sort.SliceStable(backups, func(i, j int) bool {
+		if !backups[i].date.Equal(backups[j].date) {
+			return backups[i].date.After(backups[j].date)
+		}
+		return backups[i].segment > backups[j].segment
+	})
+}
+
+// quotaPeer — ротатор, файлы которого входят в квоту MaxTotalSize другого
+// ротатора. Файлы участника с keep учитываются, но не удаляются.
+type quotaPeer struct {
+	rotator *fileRotator
+	keep    bool
+}
+
+// addQuotaPeer включает файлы peer в квоту r: записи peer учитываются в
+// занятом объёме r, а очистка r удаляет и прошлые файлы peer, если не
+// задан keep. Вызывается до первой записи в peer.
+func (r *fileRotator) addQuotaPeer(peer *fileRotator, keep bool) {
+	if r.maxTotalSize <= 0 {
+		return
+	}
+
+	r.quotaMu.Lock()
+	defer r.quotaMu.Unlock()
+
+	r.quotaPeers = append(r.quotaPeers, quotaPeer{rotator: peer, keep: keep})
+	peer.quotaOwner = r
+
+	_, total := peer.retentionBackups()
+	r.usage.Add(total)
+}
+
+// removeBackup удаляет прошлый файл и сообщает об успехе.
+func (r *fileRotator) removeBackup(backup logBackup, reason zap.Field) bool {
+	if err := r.fs().Remove(backup.path); err != nil {
+		r.diagnostics().Warn("cannot remove old log", zap.String("file", backup.path), zap.Error(err))
+		return false
+	}
+
+	r.diagnostics().Info("removed old log", zap.String("file", backup.path), reason)
+
+	return true
+}
+
+// updateUsage запоминает занятый логами объём после очистки. Если квоту
+// не удалось освободить, отбрасываются записи уровня debug.
+func (r *fileRotator) updateUsage(total int64) {
+	r.usage.Store(total)
+
+	exceeded := total > r.maxTotalSize
+	if r.quotaExceeded.Swap(exceeded) != exceeded && exceeded {
+		r.diagnostics().Warn("disk quota exceeded", zap.Int64("max_total_size", r.maxTotalSize), zap.Int64("usage", total))
+	}
+}
+
+// checkQuota учитывает записанные n байт и при превышении MaxTotalSize
+// запускает очистку в фоне. Вызывается под r.mu. Записи участника квоты
+// учитываются ротатором, который её ведёт.
+func (r *fileRotator) checkQuota(n int) {
+	if r.quotaOwner != nil {
+		r = r.quotaOwner
+	}
+
+	if r.maxTotalSize <= 0 {
+		return
+	}
+
+	if r.usage.Add(int64(n)) <= r.maxTotalSize || !r.quotaPending.CompareAndSwap(false, true) {
+		return
+	}
+
+	r.goBackground("quota", func() {
+		defer r.quotaPending.Store(false)
+
+		unlock, err := r.lockExclusive()
+		if err != nil {
+			r.compressFailed(r.dir(), err)
+			return
+		}
+		defer unlock()
+
+		r.applyRetention()
+	})
+}
+
+func (r *fileRotator) listBackups() []logBackup {
+	var backups []logBackup
+
+	for _, dir := range r.logDirs() {
+		entries, err := r.fs().ReadDir(dir)
+		if err != nil {
+			continue
+		}
+
+		for _, entry := range entries {
+			if entry.IsDir() {
+				continue
+			}
+
+			name := entry.Name()
+			archive := strings.HasSuffix(name, archiveExt)
+
+			date, segment, own, ok := r.filenamePattern().parseFile(strings.TrimSuffix(name, archiveExt))
+			if !ok {
+				continue
+			}
+
+			var size int64
+			if info, err := entry.Info(); err == nil {
+				size = info.Size()
+			}
+
+			backups = append(backups, logBackup{
+				path:    filepath.Join(dir, name),
+				date:    date,
+				segment: segment,
+				archive: archive,
+				size:    size,
+				own:     own,
+			})
+		}
+	}
+
+	return backups
+}
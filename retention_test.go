@@ -0,0 +1,221 @@
+package logger
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func writeLogFiles(t *testing.T, dir string, names ...string) {
+	t.Helper()
+
+	for _, name := range names {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte("log\n"), 0666))
+	}
+}
+
+func dirNames(t *testing.T, dir string) []string {
+	t.Helper()
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// TestRetentionMaxBackups проверяет, что остаются только новые файлы и текущий.
+func TestRetentionMaxBackups(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeLogFiles(t, tmpDir,
+		"2024_05_25.log.zip",
+		"2024_05_26.log.zip",
+		"2024_05_27.log.zip",
+		"2024_05_28.log.zip",
+		"2024_05_28.1.log",
+		"other.txt",
+	)
+
+	clock := &fakeClock{now: time.Date(2024, 5, 28, 12, 0, 0, 0, time.Local)}
+	rotator := &fileRotator{path: tmpDir, clock: clock, maxBackups: 2}
+	rotator.applyRetention()
+
+	assert.Equal(t, []string{"2024_05_27.log.zip", "2024_05_28.1.log", "2024_05_28.log.zip", "other.txt"}, dirNames(t, tmpDir))
+}
+
+// TestRetentionOtherProcesses проверяет, что очистка удаляет прошлые
+// файлы других процессов, но не их файлы за текущий период.
+func TestRetentionOtherProcesses(t *testing.T) {
+	tmpDir := t.TempDir()
+	pid := strconv.Itoa(os.Getpid())
+	writeLogFiles(t, tmpDir,
+		"app-1-2024_05_26.log",
+		"app-1-2024_05_28.log",
+		"app-"+pid+"-2024_05_27.log.zip",
+		"app-"+pid+"-2024_05_28.log",
+	)
+
+	pattern, err := newFilenamePattern("app-{pid}-{date}.log", "")
+	require.NoError(t, err)
+
+	clock := &fakeClock{now: time.Date(2024, 5, 28, 12, 0, 0, 0, time.Local)}
+	rotator := &fileRotator{path: tmpDir, clock: clock, pattern: pattern, maxBackups: 1}
+	rotator.applyRetention()
+
+	assert.Equal(t, []string{"app-1-2024_05_28.log", "app-" + pid + "-2024_05_27.log.zip", "app-" + pid + "-2024_05_28.log"}, dirNames(t, tmpDir))
+}
+
+// TestRetentionMaxAge проверяет удаление файлов старше заданного возраста.
+func TestRetentionMaxAge(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeLogFiles(t, tmpDir,
+		"2024_05_20.log.zip",
+		"2024_05_25.log.zip",
+		"2024_05_27.log",
+		"2024_05_28.log",
+	)
+
+	clock := &fakeClock{now: time.Date(2024, 5, 28, 12, 0, 0, 0, time.Local)}
+	rotator := &fileRotator{path: tmpDir, clock: clock, maxAge: 3 * 24 * time.Hour}
+	rotator.applyRetention()
+
+	assert.Equal(t, []string{"2024_05_25.log.zip", "2024_05_27.log", "2024_05_28.log"}, dirNames(t, tmpDir))
+}
+
+// TestRetentionMaxTotalSize проверяет удаление самых старых файлов сверх
+// квоты и отбрасывание debug, когда квоту занимает текущий файл.
+func TestRetentionMaxTotalSize(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeLogFiles(t, tmpDir,
+		"2024_05_25.log.zip",
+		"2024_05_26.log.zip",
+		"2024_05_27.log.zip",
+		"2024_05_28.log",
+	)
+
+	clock := &fakeClock{now: time.Date(2024, 5, 28, 12, 0, 0, 0, time.Local)}
+	rotator := &fileRotator{path: tmpDir, clock: clock, maxTotalSize: 8}
+	rotator.applyRetention()
+
+	assert.Equal(t, []string{"2024_05_27.log.zip", "2024_05_28.log"}, dirNames(t, tmpDir))
+	assert.EqualValues(t, 8, rotator.usage.Load())
+	assert.False(t, rotator.dropsLevel(zapcore.DebugLevel))
+
+	rotator.maxTotalSize = 2
+	rotator.applyRetention()
+
+	assert.Equal(t, []string{"2024_05_28.log"}, dirNames(t, tmpDir))
+	assert.True(t, rotator.dropsLevel(zapcore.DebugLevel))
+	assert.False(t, rotator.dropsLevel(zapcore.InfoLevel))
+}
+
+// TestMaxTotalSize проверяет очистку по квоте при записи.
+func TestMaxTotalSize(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeLogFiles(t, tmpDir, "2020_01_01.log.zip", "2020_01_02.log.zip")
+
+	logger := NewLogger(Path(tmpDir), Compress(false), MaxTotalSize(1024))
+	require.NoError(t, logger.Init(false))
+
+	for i := 0; i < 30; i++ {
+		logger.Info("Test log message")
+	}
+
+	assert.Eventually(t, func() bool {
+		return len(dirNames(t, tmpDir)) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	require.NoError(t, logger.Close())
+
+	_, err := Build(Path(tmpDir), MaxTotalSize(-1))
+	assert.ErrorContains(t, err, "invalid max total size")
+}
+
+// TestCompressDisabled проверяет, что без сжатия прошлые файлы остаются как есть.
+func TestCompressDisabled(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeLogFiles(t, tmpDir, "2020_01_01.log")
+
+	logger := NewLogger(Path(tmpDir), Compress(false))
+	require.NoError(t, logger.Init(false))
+	logger.Info("Test log message")
+	require.NoError(t, logger.Close())
+
+	assert.Contains(t, dirNames(t, tmpDir), "2020_01_01.log")
+}
+
+// TestLoggerRetention проверяет удаление старых файлов при инициализации логгера.
+func TestLoggerRetention(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeLogFiles(t, tmpDir,
+		"2020_01_01.log.zip",
+		"2020_01_02.log.zip",
+		"2020_01_03.log",
+	)
+
+	logger := NewLogger(Path(tmpDir), MaxAge(30), MaxBackups(5))
+	require.NoError(t, logger.Init(false))
+	logger.Info("Test log message")
+
+	today := time.Now().Format(dateLayout) + ".log"
+
+	assert.Eventually(t, func() bool {
+		names := dirNames(t, tmpDir)
+		return len(names) == 1 && names[0] == today
+	}, time.Second, 10*time.Millisecond)
+
+	require.NoError(t, logger.Close())
+}
+
+// TestRetentionMaxTotalSizePeers проверяет, что квота учитывает файлы
+// ошибок и аудита, удаляет старые файлы ошибок и не трогает аудит.
+func TestRetentionMaxTotalSizePeers(t *testing.T) {
+	tmpDir := t.TempDir()
+	auditDir := filepath.Join(tmpDir, defaultAuditDir)
+	require.NoError(t, os.Mkdir(auditDir, 0o755))
+
+	writeLogFiles(t, tmpDir,
+		"2024_05_25.log.zip",
+		"2024_05_26.error.log.zip",
+		"2024_05_28.log",
+		"2024_05_28.error.log",
+	)
+	writeLogFiles(t, auditDir,
+		"2024_05_27.log.zip",
+		"2024_05_28.log",
+	)
+
+	errorPattern, err := newFilenamePattern(errorFilename(""), "")
+	require.NoError(t, err)
+
+	clock := &fakeClock{now: time.Date(2024, 5, 28, 12, 0, 0, 0, time.Local)}
+	rotator := &fileRotator{path: tmpDir, clock: clock, maxTotalSize: 12}
+	errorRotator := &fileRotator{path: tmpDir, clock: clock, pattern: errorPattern}
+	auditRotator := &fileRotator{path: auditDir, clock: clock}
+
+	rotator.addQuotaPeer(errorRotator, false)
+	rotator.addQuotaPeer(auditRotator, true)
+	assert.EqualValues(t, 16, rotator.usage.Load())
+
+	// Запись в файл ошибок превышает общую квоту и запускает очистку.
+	errorRotator.checkQuota(8)
+	require.NoError(t, rotator.wait(context.Background()))
+
+	assert.Equal(t, []string{"2024_05_28.error.log", "2024_05_28.log", defaultAuditDir}, dirNames(t, tmpDir))
+	assert.Equal(t, []string{"2024_05_27.log.zip", "2024_05_28.log"}, dirNames(t, auditDir))
+	assert.EqualValues(t, 16, rotator.usage.Load())
+	assert.True(t, rotator.dropsLevel(zapcore.DebugLevel))
+}
@@ -0,0 +1,27 @@
+package logger
+
+import "time"
+
+// retryPolicy повторяет файловые операции, завершившиеся нарушением
+// совместного доступа. Такие ошибки возникают только в Windows, где файл,
+// открытый другим процессом (антивирусом, индексатором, сборщиком логов),
+// нельзя удалить или переименовать.
+type retryPolicy struct {
+	attempts int
+	delay    time.Duration
+}
+
+var defaultRetryPolicy = retryPolicy{
+	attempts: 5,
+	delay:    100 * time.Millisecond,
+}
+
+func (p retryPolicy) do(op func() error) error {
+	err := op()
+	for i := 0; i < p.attempts && err != nil && isSharingViolation(err); i++ {
+		time.Sleep(p.delay)
+		err = op()
+	}
+
+	return err
+}
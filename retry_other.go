@@ -0,0 +1,7 @@
+//go:build !windows
+
+package logger
+
+func isSharingViolation(_ error) bool {
+	return false
+}
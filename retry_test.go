@@ -0,0 +1,30 @@
+package logger
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestRetryPolicyDo проверяет, что повторяются только ошибки совместного доступа.
+func TestRetryPolicyDo(t *testing.T) {
+	policy := retryPolicy{attempts: 3, delay: time.Millisecond}
+
+	calls := 0
+	err := policy.do(func() error {
+		calls++
+		return errors.New("permanent error")
+	})
+	assert.Error(t, err)
+	assert.Equal(t, 1, calls, "Errors other than sharing violations should not be retried")
+
+	calls = 0
+	err = policy.do(func() error {
+		calls++
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, calls)
+}
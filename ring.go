@@ -0,0 +1,134 @@
+package logger
+
+import (
+	"sync"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// WithDebugRing возвращает логгер для одного запроса или горутины, который
+// не выводит записи ниже текущего уровня, а хранит последние size из них в
+// кольцевом буфере. Запись уровня error и выше сначала выводит содержимое
+// буфера, давая полный контекст ошибки без вывода debug в обычной работе.
+func (l *Logger) WithDebugRing(size int) *Logger {
+	if size <= 0 {
+		return l
+	}
+
+	ring := &debugRing{core: l.baseLogger.Core(), entries: make([]groupEntry, size)}
+
+	newBaseLogger := l.baseLogger.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return &ringCore{Core: core, ring: ring, gate: l.gate}
+	}))
+
+	child := *l
+	child.baseLogger = newBaseLogger
+	child.sugarLogger = newBaseLogger.Sugar()
+
+	return &child
+}
+
+// debugRing хранит последние записи в порядке поступления.
+type debugRing struct {
+	core    zapcore.Core
+	entries []groupEntry
+	next    int
+	full    bool
+	mu      sync.Mutex
+}
+
+func (r *debugRing) add(entry groupEntry) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.entries[r.next] = entry
+	r.next = (r.next + 1) % len(r.entries)
+	if r.next == 0 {
+		r.full = true
+	}
+}
+
+// dump выводит накопленные записи в исходное ядро, минуя проверку уровня
+// логгера, и очищает буфер. Записи получают только выводы, чьи
+// собственные ограничения их пропускают (см. gateCore).
+func (r *debugRing) dump() error {
+	r.mu.Lock()
+	var entries []groupEntry
+	if r.full {
+		entries = append(entries, r.entries[r.next:]...)
+	}
+	entries = append(entries, r.entries[:r.next]...)
+	clear(r.entries)
+	r.next = 0
+	r.full = false
+	r.mu.Unlock()
+
+	var err error
+	for _, e := range entries {
+		if writeErr := r.core.Write(e.entry, e.fields); writeErr != nil {
+			err = writeErr
+		}
+	}
+
+	return err
+}
+
+// ringCore откладывает в кольцевой буфер записи ниже уровня логгера.
+// Поля With хранятся отдельно, чтобы записи из буфера выводились через
+// исходное ядро с полным набором полей. gate пуст для логгера из
+// BaseLogger: тогда уровень берётся из вложенного ядра.
+type ringCore struct {
+	zapcore.Core
+	ring   *debugRing
+	gate   *levelGate
+	fields []zapcore.Field
+}
+
+func (c *ringCore) Enabled(zapcore.Level) bool {
+	return true
+}
+
+func (c *ringCore) With(fields []zapcore.Field) zapcore.Core {
+	return &ringCore{
+		Core:   c.Core.With(fields),
+		ring:   c.ring,
+		gate:   c.gate,
+		fields: append(c.fields[:len(c.fields):len(c.fields)], fields...),
+	}
+}
+
+// shown сообщает, проходит ли запись уровень логгера.
+func (c *ringCore) shown(entry zapcore.Entry) bool {
+	if c.gate == nil {
+		return c.Core.Enabled(entry.Level)
+	}
+
+	return c.gate.allows(entry, false)
+}
+
+func (c *ringCore) Check(entry zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.shown(entry) {
+		// Выводы с собственным уровнем получают запись сразу.
+		return c.Core.Check(entry, ce).AddCore(entry, c)
+	}
+
+	if entry.Level >= zapcore.ErrorLevel {
+		ce = ce.AddCore(entry, c)
+	}
+
+	return c.Core.Check(entry, ce)
+}
+
+func (c *ringCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	if c.shown(entry) {
+		return c.ring.dump()
+	}
+
+	c.ring.add(groupEntry{
+		entry:  entry,
+		fields: append(c.fields[:len(c.fields):len(c.fields)], fields...),
+	})
+
+	return nil
+}
@@ -0,0 +1,95 @@
+package logger
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// TestDebugRing проверяет вывод последних отложенных записей при ошибке.
+func TestDebugRing(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+
+	logger := NewLogger()
+	logger.baseLogger = zap.New(core)
+
+	ring := logger.WithDebugRing(3).WithFields(map[string]interface{}{"request": "r1"})
+
+	for i := 0; i < 5; i++ {
+		ring.baseLogger.Debug(fmt.Sprintf("debug %d", i))
+	}
+	ring.baseLogger.Info("info")
+
+	if assert.Equal(t, 1, logs.Len()) {
+		assert.Equal(t, "info", logs.All()[0].Message)
+	}
+
+	ring.baseLogger.Error("failed")
+
+	var messages []string
+	for _, entry := range logs.All() {
+		messages = append(messages, entry.Message)
+		assert.Equal(t, "r1", entry.ContextMap()["request"])
+	}
+
+	assert.Equal(t, []string{"info", "debug 2", "debug 3", "debug 4", "failed"}, messages)
+	assert.Equal(t, zapcore.DebugLevel, logs.All()[1].Level)
+
+	ring.baseLogger.Error("failed again")
+	assert.Equal(t, 6, logs.Len())
+}
+
+// TestDebugRingSampling проверяет, что вывод буфера не дублируется
+// ядрами сэмплирования.
+func TestDebugRingSampling(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+
+	logger := NewLogger()
+	logger.baseLogger = zap.New(newSamplingCore(core, map[zapcore.Level]samplingRate{zapcore.InfoLevel: {first: 1}}))
+
+	ring := logger.WithDebugRing(10)
+	ring.baseLogger.Debug("debug")
+	ring.baseLogger.Error("failed")
+
+	assert.Equal(t, 2, logs.Len())
+}
+
+// TestDebugRingOutputLevel проверяет, что буфер выводится только в
+// назначения, чей уровень пропускает записи, и не дублируется в них.
+func TestDebugRingOutputLevel(t *testing.T) {
+	dir, errorDir, debugDir := t.TempDir(), t.TempDir(), t.TempDir()
+
+	logger := NewLogger(Path(dir), Structured(true), AppName("app"), Level("info"),
+		Outputs("file://"+errorDir+"?format=json&level=error", "file://"+debugDir+"?format=json&level=debug"))
+	require.NoError(t, logger.Init(false))
+
+	ring := logger.WithDebugRing(10)
+	ring.Debug("debug")
+	ring.Error("failed")
+	require.NoError(t, logger.Close())
+
+	assert.Equal(t, []interface{}{"debug", "failed"}, logMessages(t, dir))
+	assert.Equal(t, []interface{}{"failed"}, logMessages(t, errorDir))
+	assert.Equal(t, []interface{}{"debug", "failed"}, logMessages(t, debugDir))
+}
+
+// TestDebugRingRateLimit проверяет, что выгрузка буфера проходит через
+// ограничение частоты, а не отбрасывается повторной проверкой уровня.
+func TestDebugRingRateLimit(t *testing.T) {
+	dir := t.TempDir()
+
+	logger := NewLogger(Path(dir), Structured(true), AppName("app"), Level("info"), RateLimit(100, 100))
+	require.NoError(t, logger.Init(false))
+
+	ring := logger.WithDebugRing(10)
+	ring.Debug("debug")
+	ring.Error("failed")
+	require.NoError(t, logger.Close())
+
+	assert.Equal(t, []interface{}{"debug", "failed"}, logMessages(t, dir))
+}
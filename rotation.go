@@ -1,56 +1,243 @@
 package logger
 
 import (
-	"archive/zip"
+	"context"
 	"errors"
+	"fmt"
 	"io"
 	"io/fs"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	dateLayout   = "2006_01_02"
+	hourLayout   = "2006_01_02_15"
+	minuteLayout = "2006_01_02_1504"
+)
+
+// intervalLayout возвращает формат {date} для интервала ротации: дата при
+// ежедневной ротации, с часом или с часом и минутами при более частой.
+func intervalLayout(interval time.Duration) string {
+	switch {
+	case interval <= 0 || interval >= 24*time.Hour:
+		return dateLayout
+	case interval%time.Hour == 0:
+		return hourLayout
+	default:
+		return minuteLayout
+	}
+}
+
+// validateInterval проверяет, что интервал ротации делит сутки на равные
+// части не короче минуты.
+func validateInterval(interval time.Duration) error {
+	if interval == 0 {
+		return nil
+	}
+
+	if interval < time.Minute || interval > 24*time.Hour || interval%time.Minute != 0 || (24*time.Hour)%interval != 0 {
+		return fmt.Errorf("logger: rotation interval %s must divide 24h into whole minutes", interval)
+	}
+
+	return nil
+}
+
+// Clock — источник времени для ротации и меток времени записей. Позволяет
+// детерминированно тестировать ротацию без ожидания и подмены системного
+// времени.
+type Clock = zapcore.Clock
+
+// SegmentLimitPolicy определяет поведение при исчерпании лимита сегментов
+// за день.
+type SegmentLimitPolicy int
+
+const (
+	// SegmentLimitAppend продолжает запись в последний сегмент.
+	SegmentLimitAppend SegmentLimitPolicy = iota
+	// SegmentLimitDropDebug продолжает запись в последний сегмент, но
+	// отбрасывает записи уровня debug.
+	SegmentLimitDropDebug
 )
 
 type fileRotator struct {
 	path     string
-	file     *os.File
+	file     File
 	date     time.Time
 	compress bool
+	dateDirs bool
+	pattern  *filenamePattern
+	locking  bool
+	fileMode fs.FileMode
+	dirMode  fs.FileMode
+	lock     *fileLock
+	retry    retryPolicy
 	mu       sync.Mutex
+
+	// currentLink — имя ссылки на текущий файл в каталоге логов.
+	currentLink string
+
+	// Ротация по размеру: при превышении maxSize открывается следующий
+	// сегмент того же дня, но не больше maxSegments файлов в день.
+	maxSize       int64
+	size          int64
+	segment       int
+	maxSegments   int
+	segmentPolicy SegmentLimitPolicy
+	limitReached  atomic.Bool
+
+	// rotateAt — смещение начала суток от полуночи: при 4h файл за 28 мая
+	// пишется с 04:00 28 мая до 04:00 29 мая.
+	rotateAt time.Duration
+
+	// interval — длительность файла при ротации чаще раза в сутки; ноль
+	// означает ежедневную ротацию.
+	interval time.Duration
+
+	maxAge     time.Duration
+	maxBackups int
+
+	// maxTotalSize — квота на суммарный размер логов; usage — оценка
+	// занятого объёма по последней очистке и записям после неё.
+	maxTotalSize  int64
+	usage         atomic.Int64
+	quotaPending  atomic.Bool
+	quotaExceeded atomic.Bool
+
+	// quotaPeers — ротаторы, файлы которых входят в квоту; quotaOwner —
+	// ротатор, ведущий квоту этого. quotaMu разделяет подсчёт занятого
+	// объёма и добавление участников.
+	quotaPeers []quotaPeer
+	quotaOwner *fileRotator
+	quotaMu    sync.Mutex
+
+	uploader       ArchiveUploader
+	removeUploaded bool
+	// uploads отменяется при закрытии, uploadMu выстраивает загрузки в
+	// очередь.
+	uploads       context.Context
+	cancelUploads context.CancelFunc
+	uploadMu      sync.Mutex
+	onRotate      func(oldPath, newPath string)
+
+	// background отслеживает фоновое сжатие и очистку, чтобы Close
+	// дожидался их завершения.
+	background      sync.WaitGroup
+	onCompressError func(path string, err error)
+
+	// maintenance не даёт очистке при запуске удалить временный архив
+	// идущего сжатия.
+	maintenance sync.Mutex
+
+	clock Clock
+
+	fsys FS
+
+	diag    *zap.Logger
+	metrics Metrics
+	stats   *loggerStats
 }
 
 var _ io.WriteCloser = (*fileRotator)(nil)
 
+// open открывает текущий файл, если он ещё не открыт.
+func (r *fileRotator) open() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.file != nil {
+		return nil
+	}
+
+	return r.openNew(r.now())
+}
+
 func (r *fileRotator) openNew(onDate time.Time) error {
+	date := r.periodDate(onDate)
+
+	return r.openSegment(date, r.lastSegment(date))
+}
+
+func (r *fileRotator) openSegment(onDate time.Time, segment int) error {
 	r.date = onDate
+	r.segment = segment
+
+	dir := r.fileDir(r.date)
+
+	if _, err := r.fs().Stat(dir); errors.Is(err, fs.ErrNotExist) {
+		err = r.fs().MkdirAll(dir, r.dirMode)
+		if err != nil {
+			return err
+		}
+	}
 
-	if _, err := os.Stat(r.path); errors.Is(err, fs.ErrNotExist) {
-		err = os.MkdirAll(r.path, 0777)
+	if r.locking && r.lock == nil {
+		lock, err := openFileLock(r.dir(), r.fileMode)
 		if err != nil {
 			return err
 		}
+
+		r.lock = lock
 	}
 
-	filename := filepath.Join(r.path, r.date.Format("2006_01_02")+".log")
+	filename := filepath.Join(dir, r.filenamePattern().formatSegment(r.date, r.segment))
 
-	file, err := os.OpenFile(filename, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
+	var file File
+	err := r.retry.do(func() (err error) {
+		file, err = r.fs().OpenFile(filename, os.O_CREATE|os.O_WRONLY|os.O_APPEND, r.fileMode)
+		return err
+	})
 	if err != nil {
 		return err
 	}
 
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return err
+	}
+
 	r.file = file
+	r.size = info.Size()
+
+	if r.currentLink != "" {
+		r.updateCurrentLink(filename)
+	}
 
 	return nil
 }
 
 func (r *fileRotator) Write(p []byte) (n int, err error) {
+	if r.metrics != nil {
+		start := time.Now()
+		defer func() {
+			r.metrics.ObserveWriteLatency(time.Since(start))
+		}()
+	}
+
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
 	if r.file == nil {
-		if err := r.openNew(time.Now()); err != nil {
+		if err := r.openNew(r.now()); err != nil {
+			return 0, err
+		}
+	}
+
+	// Разделяемая блокировка не даёт другому процессу сжать файл между
+	// проверкой даты и записью.
+	if r.lock != nil {
+		if err := r.lock.rlock(); err != nil {
 			return 0, err
 		}
+		defer r.lock.unlock()
 	}
 
 	if r.needRotate() {
@@ -59,10 +246,52 @@ func (r *fileRotator) Write(p []byte) (n int, err error) {
 		}
 	}
 
-	return r.file.Write(p)
+	if r.needSegment(len(p)) {
+		if err := r.rotateSegment(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err = r.file.Write(p)
+	r.size += int64(n)
+
+	r.checkQuota(n)
+
+	return n, err
 }
 
+func (r *fileRotator) Sync() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.file == nil {
+		return nil
+	}
+
+	return r.file.Sync()
+}
+
+// Close закрывает файл и дожидается фонового сжатия и очистки.
 func (r *fileRotator) Close() error {
+	return r.closeContext(context.Background())
+}
+
+// closeContext закрывает файл и ждёт фоновые задачи не дольше ctx, после
+// чего прерывает незавершённые загрузки.
+func (r *fileRotator) closeContext(ctx context.Context) error {
+	if err := r.closeFile(); err != nil {
+		return err
+	}
+
+	err := r.wait(ctx)
+	if r.cancelUploads != nil {
+		r.cancelUploads()
+	}
+
+	return err
+}
+
+func (r *fileRotator) closeFile() error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
@@ -78,71 +307,498 @@ func (r *fileRotator) Close() error {
 		return err
 	}
 
+	r.file = nil
+
+	if r.lock != nil {
+		if err := r.lock.close(); err != nil {
+			return err
+		}
+		r.lock = nil
+	}
+
 	return nil
 }
 
+// goBackground запускает фоновую задачу, завершения которой ждёт Close.
+func (r *fileRotator) goBackground(task string, f func()) {
+	r.background.Add(1)
+	goLabeled(task, func() {
+		defer r.background.Done()
+		f()
+	})
+}
+
+// wait ждёт завершения фоновых задач или отмены ctx.
+func (r *fileRotator) wait(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		r.background.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("logger: waiting for compression: %w", ctx.Err())
+	}
+}
+
+// Rotate принудительно закрывает текущий файл и открывает новый. В пределах
+// одного дня новый файл получает следующий номер сегмента.
+func (r *fileRotator) Rotate() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.file == nil {
+		return nil
+	}
+
+	if r.needRotate() {
+		return r.rotate()
+	}
+
+	return r.rotateSegment()
+}
+
 func (r *fileRotator) rotate() error {
-	if err := r.file.Sync(); err != nil {
+	old := r.file.Name()
+
+	if err := r.closeRotated(); err != nil {
 		return err
 	}
 
-	if err := r.file.Close(); err != nil {
+	r.limitReached.Store(false)
+
+	if err := r.openNew(r.now()); err != nil {
 		return err
 	}
 
-	if r.compress {
-		go compressFile(r.file.Name())
+	r.rotated(old)
+
+	return nil
+}
+
+func (r *fileRotator) rotateSegment() error {
+	old := r.file.Name()
+
+	if err := r.closeRotated(); err != nil {
+		return err
 	}
 
-	if err := r.openNew(time.Now()); err != nil {
+	if err := r.openSegment(r.date, r.segment+1); err != nil {
 		return err
 	}
 
+	r.rotated(old)
+
 	return nil
 }
 
+// rotated сообщает о ротации в Diagnostics и вызывает OnRotate.
+func (r *fileRotator) rotated(old string) {
+	r.stats.rotated()
+	name := r.file.Name()
+
+	r.diagnostics().Info("rotated", zap.String("old", old), zap.String("new", name))
+
+	if r.onRotate != nil {
+		goLabeled("on-rotate", func() { r.onRotate(old, name) })
+	}
+}
+
+func (r *fileRotator) closeRotated() error {
+	if err := r.file.Sync(); err != nil {
+		return err
+	}
+
+	if err := r.file.Close(); err != nil {
+		return err
+	}
+
+	if r.compress || r.hasRetention() || r.uploader != nil {
+		name := r.file.Name()
+		r.goBackground("compress", func() { r.compressRotated(name) })
+	}
+
+	return nil
+}
+
+// needSegment сообщает, что запись p переполнит текущий файл и нужно
+// открыть следующий сегмент. При исчерпании лимита сегментов запись
+// продолжается в последний файл.
+func (r *fileRotator) needSegment(n int) bool {
+	if r.maxSize <= 0 || r.size == 0 || r.size+int64(n) <= r.maxSize {
+		return false
+	}
+
+	if r.maxSegments > 0 && r.segment+1 >= r.maxSegments {
+		if r.limitReached.CompareAndSwap(false, true) {
+			r.diagnostics().Warn("segment limit reached",
+				zap.Int("segments", r.maxSegments),
+				zap.Bool("drop_debug", r.segmentPolicy == SegmentLimitDropDebug),
+			)
+		}
+		return false
+	}
+
+	return true
+}
+
+// dropsLevel сообщает, что записи уровня level отбрасываются из-за
+// исчерпания лимита сегментов за день или квоты MaxTotalSize.
+func (r *fileRotator) dropsLevel(level zapcore.Level) bool {
+	if level >= zapcore.InfoLevel {
+		return false
+	}
+
+	return r.segmentPolicy == SegmentLimitDropDebug && r.limitReached.Load() || r.quotaExceeded.Load()
+}
+
+// lastSegment возвращает номер последнего существующего сегмента за дату,
+// чтобы после перезапуска продолжить запись в него.
+func (r *fileRotator) lastSegment(date time.Time) int {
+	entries, err := r.fs().ReadDir(r.fileDir(date))
+	if err != nil {
+		return 0
+	}
+
+	layout := r.filenamePattern().layout()
+	day := date.Format(layout)
+	last := 0
+
+	for _, entry := range entries {
+		fileDate, segment, ok := r.filenamePattern().parseSegment(entry.Name())
+		if ok && !entry.IsDir() && fileDate.Format(layout) == day && segment > last {
+			last = segment
+		}
+	}
+
+	return last
+}
+
+func (r *fileRotator) dir() string {
+	if r.path == "" {
+		return "."
+	}
+
+	return r.path
+}
+
+func (r *fileRotator) filenamePattern() *filenamePattern {
+	if r.pattern == nil {
+		return defaultPattern
+	}
+
+	return r.pattern
+}
+
+// parseRotateAt разбирает время ротации "ЧЧ:ММ" в смещение от полуночи.
+func parseRotateAt(clock string) (time.Duration, error) {
+	if clock == "" {
+		return 0, nil
+	}
+
+	t, err := time.Parse("15:04", clock)
+	if err != nil {
+		return 0, fmt.Errorf("logger: invalid rotation time %q, expected HH:MM: %w", clock, err)
+	}
+
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// fileDir возвращает каталог файла за дату: корень или подкаталог
+// ГГГГ/ММ при включённой раскладке по датам.
+func (r *fileRotator) fileDir(date time.Time) string {
+	if r.dateDirs {
+		return filepath.Join(r.dir(), date.Format("2006"), date.Format("01"))
+	}
+
+	return r.dir()
+}
+
+// logDirs возвращает все каталоги, в которых могут лежать файлы логов.
+func (r *fileRotator) logDirs() []string {
+	root := r.dir()
+	dirs := []string{root}
+
+	if !r.dateDirs {
+		return dirs
+	}
+
+	years, err := r.fs().ReadDir(root)
+	if err != nil {
+		return dirs
+	}
+
+	for _, year := range years {
+		if !year.IsDir() || !isDateDir(year.Name(), "2006") {
+			continue
+		}
+
+		months, err := r.fs().ReadDir(filepath.Join(root, year.Name()))
+		if err != nil {
+			continue
+		}
+
+		for _, month := range months {
+			if month.IsDir() && isDateDir(month.Name(), "01") {
+				dirs = append(dirs, filepath.Join(root, year.Name(), month.Name()))
+			}
+		}
+	}
+
+	return dirs
+}
+
+func isDateDir(name, layout string) bool {
+	if len(name) != len(layout) {
+		return false
+	}
+
+	_, err := time.Parse(layout, name)
+
+	return err == nil
+}
+
 func (r *fileRotator) needRotate() bool {
-	return r.date.Day() != time.Now().Day() || r.date.Month() != time.Now().Month() || r.date.Year() != time.Now().Year()
+	layout := r.filenamePattern().layout()
+
+	return r.date.Format(layout) != r.periodDate(r.now()).Format(layout)
 }
 
-func compressFile(src string) {
-	file, err := os.Open(src)
+func (r *fileRotator) diagnostics() *zap.Logger {
+	return diagLogger(r.diag)
+}
+
+func (r *fileRotator) fs() FS {
+	if r.fsys == nil {
+		return osFS{}
+	}
+
+	return r.fsys
+}
+
+func (r *fileRotator) now() time.Time {
+	if r.clock == nil {
+		return time.Now()
+	}
+
+	return r.clock.Now()
+}
+
+// periodDate возвращает дату файла, в который попадает запись в момент t,
+// с учётом времени ротации.
+func (r *fileRotator) periodDate(t time.Time) time.Time {
+	t = t.Add(-r.rotateAt)
+
+	if r.interval <= 0 || r.interval >= 24*time.Hour {
+		return t
+	}
+
+	year, month, day := t.Date()
+	midnight := time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+
+	return midnight.Add(t.Sub(midnight) / r.interval * r.interval)
+}
+
+// periodLength возвращает длительность одного файла.
+func (r *fileRotator) periodLength() time.Duration {
+	if r.interval <= 0 || r.interval >= 24*time.Hour {
+		return 24 * time.Hour
+	}
+
+	return r.interval
+}
+
+// compressRotated сжимает файл после ротации, загружает его через
+// UploadArchives и удаляет устаревшие файлы.
+// При включённой блокировке сжатие и очистка выполняются под
+// эксклюзивной блокировкой, чтобы не удалить файл, в который ещё пишет
+// другой процесс, и не сжимать его дважды. Загрузка идёт без блокировки:
+// медленное хранилище не должно задерживать запись в других процессах.
+func (r *fileRotator) compressRotated(src string) {
+	unlock, err := r.lockExclusive()
 	if err != nil {
+		r.compressFailed(src, err)
 		return
 	}
-	defer file.Close()
 
-	zipFile, err := os.Create(src + ".zip")
-	if err != nil {
+	if _, err := r.fs().Stat(src); err == nil && r.compress {
+		r.compressFile(src)
+	}
+
+	if r.uploader == nil {
+		r.applyRetention()
+		unlock()
 		return
 	}
-	defer zipFile.Close()
+	unlock()
 
-	zipWriter := zip.NewWriter(zipFile)
-	defer zipWriter.Close()
+	r.uploadPending(src)
 
-	info, err := file.Stat()
-	if err != nil {
+	if unlock, err = r.lockExclusive(); err != nil {
+		r.compressFailed(src, err)
+		return
+	}
+	defer unlock()
+
+	r.applyRetention()
+}
+
+func (r *fileRotator) compressFile(src string) {
+	start := time.Now()
+
+	if err := compressFile(r.fs(), src, r.fileMode, r.retry); err != nil {
+		r.compressFailed(src, err)
 		return
 	}
 
-	header, err := zip.FileInfoHeader(info)
+	r.diagnostics().Info("compressed", zap.String("file", src), zap.Duration("duration", time.Since(start)))
+}
+
+// compressFailed сообщает об ошибке сжатия в Diagnostics и OnCompressError.
+func (r *fileRotator) compressFailed(src string, err error) {
+	r.stats.compressFailed()
+	r.diagnostics().Warn("compression failed", zap.String("file", src), zap.Error(err))
+
+	if r.onCompressError != nil {
+		r.onCompressError(src, err)
+	}
+}
+
+// lockExclusive исключает одновременные фоновые задачи ротатора и берёт
+// эксклюзивную блокировку каталога через отдельный дескриптор, если
+// блокировка включена.
+func (r *fileRotator) lockExclusive() (func(), error) {
+	r.maintenance.Lock()
+
+	if !r.locking {
+		return r.maintenance.Unlock, nil
+	}
+
+	lock, err := openFileLock(r.dir(), r.fileMode)
 	if err != nil {
+		r.maintenance.Unlock()
+		return nil, err
+	}
+
+	if err := lock.lock(); err != nil {
+		_ = lock.close()
+		r.maintenance.Unlock()
+		return nil, err
+	}
+
+	return func() {
+		_ = lock.close()
+		r.maintenance.Unlock()
+	}, nil
+}
+
+// cleanupLeftovers удаляет артефакты прерванного сжатия, досжимает
+// файлы прошлых дней, удаляет устаревшие файлы и загружает оставшиеся
+// после неудачных загрузок.
+func (r *fileRotator) cleanupLeftovers() {
+	unlock, err := r.lockExclusive()
+	if err != nil {
+		r.compressFailed(r.dir(), err)
 		return
 	}
 
-	header.Method = zip.Deflate
+	r.removePartialArchives()
+	if r.compress {
+		r.compressLeftovers()
+	}
+	r.applyRetention()
+	unlock()
 
-	writer, err := zipWriter.CreateHeader(header)
+	if r.uploader != nil && r.removeUploaded {
+		r.uploadPending("")
+	}
+}
+
+// removePartialArchives удаляет временные файлы прерванного сжатия и
+// архивы, исходный файл которых всё ещё существует.
+func (r *fileRotator) removePartialArchives() {
+	for _, dir := range r.logDirs() {
+		r.removePartialArchivesIn(dir)
+	}
+}
+
+func (r *fileRotator) removePartialArchivesIn(dir string) {
+	entries, err := r.fs().ReadDir(dir)
 	if err != nil {
 		return
 	}
 
-	_, err = io.Copy(writer, file)
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() {
+			continue
+		}
+
+		// Временный файл другого процесса может сжиматься прямо сейчас.
+		if base, ok := strings.CutSuffix(name, archiveExt+tempExt); ok {
+			if _, ok := r.filenamePattern().parse(base); ok {
+				r.removeArtifact(filepath.Join(dir, name))
+			}
+			continue
+		}
+
+		if !strings.HasSuffix(name, archiveExt) {
+			continue
+		}
+
+		if _, ok := r.filenamePattern().parse(strings.TrimSuffix(name, archiveExt)); !ok {
+			continue
+		}
+
+		src := filepath.Join(dir, strings.TrimSuffix(name, archiveExt))
+		if _, err := r.fs().Stat(src); err != nil {
+			continue
+		}
+
+		r.removeArtifact(filepath.Join(dir, name))
+	}
+}
+
+func (r *fileRotator) removeArtifact(path string) {
+	if err := r.fs().Remove(path); err != nil {
+		r.diagnostics().Warn("cannot remove partial archive", zap.String("file", path), zap.Error(err))
+		return
+	}
+
+	r.diagnostics().Info("removed partial archive", zap.String("file", path))
+}
+
+// compressLeftovers сжимает файлы прошлых дней, оставшиеся несжатыми
+// после аварийного завершения процесса.
+func (r *fileRotator) compressLeftovers() {
+	for _, dir := range r.logDirs() {
+		r.compressLeftoversIn(dir)
+	}
+}
+
+func (r *fileRotator) compressLeftoversIn(dir string) {
+	entries, err := r.fs().ReadDir(dir)
 	if err != nil {
 		return
 	}
 
-	_ = file.Close()
-	_ = os.Remove(src)
+	layout := r.filenamePattern().layout()
+	today := r.periodDate(r.now()).Format(layout)
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() {
+			continue
+		}
+
+		date, ok := r.filenamePattern().parse(name)
+		if !ok || date.Format(layout) == today {
+			continue
+		}
+
+		r.compressFile(filepath.Join(dir, name))
+	}
 }
@@ -0,0 +1,68 @@
+package logger
+
+import "io"
+
+// Rotator — файл логов с ежедневной ротацией, ротацией по размеру,
+// сжатием и удалением старых файлов, который можно использовать без
+// Logger: как io.Writer для slog, logrus или любого потока данных.
+//
+// Методы безопасны для одновременного вызова из нескольких горутин.
+// Каждый вызов Write целиком попадает в один файл и не перемежается с
+// другими вызовами этого процесса; для нескольких процессов с одним
+// каталогом нужна опция FileLock. Сжатие и удаление старых файлов
+// выполняются в фоне; Close дожидается их завершения.
+type Rotator struct {
+	rotator *fileRotator
+}
+
+var _ io.WriteCloser = (*Rotator)(nil)
+
+// NewRotator создаёт ротатор. Принимает те же опции, что и NewLogger;
+// учитываются опции файла: Path (обязательна), FilenamePattern,
+// DateDirs, RotateAt, MaxSize, MaxSegmentsPerDay, MaxAge, MaxBackups,
+// Compress, FileLock, SharingViolationRetry, WithClock, WithFS,
+// Diagnostics и WithMetrics. Остальные опции игнорируются.
+func NewRotator(options ...Option) (*Rotator, error) {
+	l := NewLogger(options...)
+
+	if err := l.validate(); err != nil {
+		return nil, err
+	}
+
+	path, err := expandPath(l.path)
+	if err != nil {
+		return nil, err
+	}
+
+	if l.fsys == nil {
+		if err := validatePath(path, l.dirPerm()); err != nil {
+			return nil, err
+		}
+	}
+
+	l.diag = newDiagnostics(l.diagOutput)
+
+	return &Rotator{rotator: l.newFileRotator(path)}, nil
+}
+
+// Write пишет p в текущий файл, предварительно выполняя ротацию, если
+// сменились сутки или файл превысит MaxSize.
+func (r *Rotator) Write(p []byte) (int, error) {
+	return r.rotator.Write(p)
+}
+
+// Sync сбрасывает текущий файл на диск.
+func (r *Rotator) Sync() error {
+	return r.rotator.Sync()
+}
+
+// Rotate закрывает текущий файл и начинает новый.
+func (r *Rotator) Rotate() error {
+	return r.rotator.Rotate()
+}
+
+// Close закрывает текущий файл и дожидается фонового сжатия и удаления
+// старых файлов. Следующий Write откроет файл снова.
+func (r *Rotator) Close() error {
+	return r.rotator.Close()
+}
@@ -0,0 +1,183 @@
+package logger
+
+import (
+	"log/slog"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRotatorSlog проверяет использование ротатора как вывода slog.
+func TestRotatorSlog(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	rotator, err := NewRotator(Path(tmpDir), FilenamePattern("app-{date}.log"))
+	require.NoError(t, err)
+
+	logger := slog.New(slog.NewTextHandler(rotator, nil))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			logger.Info("slog message", "n", i)
+		}(i)
+	}
+	wg.Wait()
+
+	require.NoError(t, rotator.Close())
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "app-"+time.Now().Format(dateLayout)+".log"))
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	assert.Len(t, lines, 10)
+	for _, line := range lines {
+		assert.Contains(t, line, "msg=\"slog message\"")
+	}
+}
+
+// TestNewRotatorErrors проверяет проверку опций ротатора.
+func TestNewRotatorErrors(t *testing.T) {
+	_, err := NewRotator()
+	assert.Error(t, err)
+
+	_, err = NewRotator(Path(t.TempDir()), RotateAt("25:00"), MaxBackups(-1))
+	assert.Error(t, err)
+}
+
+// TestOnRotate проверяет вызов OnRotate с путями файлов.
+func TestOnRotate(t *testing.T) {
+	tmpDir := t.TempDir()
+	rotations := make(chan [2]string, 1)
+
+	rotator, err := NewRotator(Path(tmpDir), FilenamePattern("app-{date}.log"), Compress(false),
+		OnRotate(func(oldPath, newPath string) { rotations <- [2]string{oldPath, newPath} }))
+	require.NoError(t, err)
+
+	_, err = rotator.Write([]byte("line\n"))
+	require.NoError(t, err)
+	require.NoError(t, rotator.Rotate())
+
+	today := time.Now().Format(dateLayout)
+
+	select {
+	case paths := <-rotations:
+		assert.Equal(t, [2]string{
+			filepath.Join(tmpDir, "app-"+today+".log"),
+			filepath.Join(tmpDir, "app-"+today+".1.log"),
+		}, paths)
+	case <-time.After(time.Second):
+		t.Fatal("OnRotate should be called")
+	}
+
+	require.NoError(t, rotator.Close())
+}
+
+// TestCloseWaitsForCompression проверяет, что после Close архив уже
+// создан, а ошибки сжатия передаются в OnCompressError.
+func TestCloseWaitsForCompression(t *testing.T) {
+	tmpDir := t.TempDir()
+	today := time.Now().Format(dateLayout)
+
+	var failed []string
+	rotator, err := NewRotator(Path(tmpDir), FilenamePattern("app-{date}.log"),
+		OnCompressError(func(path string, err error) { failed = append(failed, filepath.Base(path)) }))
+	require.NoError(t, err)
+
+	_, err = rotator.Write([]byte("first\n"))
+	require.NoError(t, err)
+	require.NoError(t, rotator.Rotate())
+
+	// Каталог на месте временного архива не даёт сжать второй сегмент.
+	blocked := filepath.Join(tmpDir, "app-"+today+".1.log.zip.tmp")
+	require.NoError(t, os.MkdirAll(filepath.Join(blocked, "x"), 0o755))
+
+	_, err = rotator.Write([]byte("second\n"))
+	require.NoError(t, err)
+	require.NoError(t, rotator.Rotate())
+
+	require.NoError(t, rotator.Close())
+
+	assert.FileExists(t, filepath.Join(tmpDir, "app-"+today+".log.zip"))
+	assert.FileExists(t, filepath.Join(tmpDir, "app-"+today+".1.log"))
+	assert.Equal(t, []string{"app-" + today + ".1.log"}, failed)
+}
+
+// TestFileAndDirMode проверяет права создаваемых файлов и каталогов.
+func TestFileAndDirMode(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("permission bits are not supported on Windows")
+	}
+
+	tmpDir := filepath.Join(t.TempDir(), "logs")
+
+	rotator, err := NewRotator(Path(tmpDir), FilenamePattern("app-{date}.log"), DateDirs(true),
+		FileMode(0o640), DirMode(0o750))
+	require.NoError(t, err)
+
+	_, err = rotator.Write([]byte("line\n"))
+	require.NoError(t, err)
+	require.NoError(t, rotator.Rotate())
+	require.NoError(t, rotator.Close())
+
+	dir := filepath.Join(tmpDir, time.Now().Format("2006"), time.Now().Format("01"))
+	for path, mode := range map[string]os.FileMode{
+		tmpDir:            0o750,
+		filepath.Dir(dir): 0o750,
+		dir:               0o750,
+		filepath.Join(dir, "app-"+time.Now().Format(dateLayout)+".log.zip"): 0o640,
+		filepath.Join(dir, "app-"+time.Now().Format(dateLayout)+".1.log"):   0o640,
+	} {
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		assert.Equal(t, mode, info.Mode().Perm(), path)
+	}
+
+	_, err = NewRotator(Path(t.TempDir()), FileMode(os.ModeDir|0o644))
+	assert.ErrorContains(t, err, "invalid permissions")
+}
+
+// TestCurrentLink проверяет, что ссылка указывает на текущий файл после
+// ротации.
+func TestCurrentLink(t *testing.T) {
+	tmpDir := t.TempDir()
+	today := time.Now().Format(dateLayout)
+
+	rotator, err := NewRotator(Path(tmpDir), FilenamePattern("app-{date}.log"), Compress(false), CurrentLink(""))
+	require.NoError(t, err)
+
+	_, err = rotator.Write([]byte("first\n"))
+	require.NoError(t, err)
+
+	link := filepath.Join(tmpDir, "current.log")
+	content, err := os.ReadFile(link)
+	require.NoError(t, err)
+	assert.Equal(t, "first\n", string(content))
+
+	require.NoError(t, rotator.Rotate())
+	_, err = rotator.Write([]byte("second\n"))
+	require.NoError(t, err)
+	require.NoError(t, rotator.Close())
+
+	content, err = os.ReadFile(link)
+	require.NoError(t, err)
+	assert.Equal(t, "second\n", string(content))
+
+	if target, err := os.Readlink(link); err == nil {
+		assert.Equal(t, "app-"+today+".1.log", target)
+	}
+
+	assert.NoFileExists(t, link+tempExt)
+
+	_, err = NewRotator(Path(tmpDir), CurrentLink("logs/current.log"))
+	assert.ErrorContains(t, err, "must not contain path separators")
+}
@@ -0,0 +1,200 @@
+package logger
+
+import (
+	"fmt"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// samplingTick — интервал, в пределах которого считаются записи для
+// сэмплирования.
+const samplingTick = time.Second
+
+type samplingRate struct {
+	first      int
+	thereafter int
+}
+
+// Sampling включает сэмплирование записей уровня level: каждую секунду
+// пишутся первые first записей с одинаковым сообщением, затем каждая
+// thereafter-я. Уровни без настройки не сэмплируются, поэтому можно
+// сильно проредить debug и info, не теряя предупреждений и ошибок.
+// Неизвестный уровень игнорируется (Build возвращает ошибку).
+func Sampling(level string, first, thereafter int) Option {
+	return func(l *Logger) {
+		lvl, exist := loggerLevelMap[level]
+		if !exist {
+			l.optionErrs = append(l.optionErrs, fmt.Errorf("logger: unknown sampling level %q", level))
+			return
+		}
+
+		if l.sampling == nil {
+			l.sampling = make(map[zapcore.Level]samplingRate)
+		}
+
+		l.sampling[lvl] = samplingRate{first: first, thereafter: thereafter}
+	}
+}
+
+// SamplingAll включает сэмплирование для уровней от debug до error с
+// одинаковыми first и thereafter, например чтобы ошибка в плотном цикле
+// не заполнила диск. Записи dpanic и выше не сэмплируются. Sampling,
+// указанный после, переопределяет настройку отдельного уровня.
+func SamplingAll(first, thereafter int) Option {
+	return func(l *Logger) {
+		if l.sampling == nil {
+			l.sampling = make(map[zapcore.Level]samplingRate)
+		}
+
+		for lvl := zapcore.DebugLevel; lvl <= zapcore.ErrorLevel; lvl++ {
+			l.sampling[lvl] = samplingRate{first: first, thereafter: thereafter}
+		}
+	}
+}
+
+// newSamplingCore сэмплирует записи настроенных уровней отдельными
+// сэмплерами и пропускает записи остальных уровней без изменений.
+func newSamplingCore(core zapcore.Core, rates map[zapcore.Level]samplingRate) zapcore.Core {
+	if len(rates) == 0 {
+		return core
+	}
+
+	levels := make([]zapcore.Level, 0, len(rates))
+	for level := range rates {
+		levels = append(levels, level)
+	}
+	sort.Slice(levels, func(i, j int) bool { return levels[i] < levels[j] })
+
+	cores := make([]zapcore.Core, 0, len(levels)+1)
+
+	for _, level := range levels {
+		rate := rates[level]
+		only := &levelFilterCore{Core: core, enabled: func(l zapcore.Level) bool { return l == level }}
+		cores = append(cores, zapcore.NewSamplerWithOptions(only, samplingTick, rate.first, rate.thereafter))
+	}
+
+	cores = append(cores, &levelFilterCore{Core: core, enabled: func(l zapcore.Level) bool {
+		_, sampled := rates[l]
+		return !sampled
+	}})
+
+	return zapcore.NewTee(cores...)
+}
+
+// samplingGeneration — ядро сэмплирования одного поколения настроек.
+type samplingGeneration struct {
+	gen  uint64
+	core zapcore.Core
+}
+
+// samplingRoot хранит текущее поколение ядра сэмплирования поверх base.
+type samplingRoot struct {
+	base    zapcore.Core
+	current atomic.Pointer[samplingGeneration]
+}
+
+// samplingSwitch сэмплирует записи по настройкам, которые можно сменить
+// на ходу (ReloadConfig). Копии With пересоздают своё ядро из нового
+// поколения при первой записи после смены, поэтому счётчики сэмплера
+// остаются общими для логгера и его копий.
+type samplingSwitch struct {
+	root   *samplingRoot
+	fields []zapcore.Field
+	cached atomic.Pointer[samplingGeneration]
+}
+
+func newSamplingSwitch(core zapcore.Core, rates map[zapcore.Level]samplingRate) *samplingSwitch {
+	s := &samplingSwitch{root: &samplingRoot{base: core}}
+	s.setRates(rates)
+
+	return s
+}
+
+// setRates заменяет настройки сэмплирования. Вызовы должны быть
+// последовательными.
+func (c *samplingSwitch) setRates(rates map[zapcore.Level]samplingRate) {
+	var gen uint64
+	if current := c.root.current.Load(); current != nil {
+		gen = current.gen + 1
+	}
+
+	c.root.current.Store(&samplingGeneration{gen: gen, core: newSamplingCore(c.root.base, rates)})
+}
+
+func (c *samplingSwitch) core() zapcore.Core {
+	current := c.root.current.Load()
+	if len(c.fields) == 0 {
+		return current.core
+	}
+
+	if cached := c.cached.Load(); cached != nil && cached.gen == current.gen {
+		return cached.core
+	}
+
+	core := current.core.With(c.fields)
+	c.cached.Store(&samplingGeneration{gen: current.gen, core: core})
+
+	return core
+}
+
+func (c *samplingSwitch) Enabled(level zapcore.Level) bool {
+	return c.core().Enabled(level)
+}
+
+func (c *samplingSwitch) With(fields []zapcore.Field) zapcore.Core {
+	current := c.root.current.Load()
+
+	child := &samplingSwitch{
+		root:   c.root,
+		fields: append(c.fields[:len(c.fields):len(c.fields)], fields...),
+	}
+	child.cached.Store(&samplingGeneration{gen: current.gen, core: c.core().With(fields)})
+
+	return child
+}
+
+func (c *samplingSwitch) Check(entry zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	return c.core().Check(entry, ce)
+}
+
+func (c *samplingSwitch) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	return c.core().Write(entry, fields)
+}
+
+func (c *samplingSwitch) Sync() error {
+	return c.core().Sync()
+}
+
+// levelFilterCore пропускает во вложенное ядро только записи выбранных
+// уровней.
+type levelFilterCore struct {
+	zapcore.Core
+	enabled func(zapcore.Level) bool
+}
+
+func (c *levelFilterCore) Enabled(level zapcore.Level) bool {
+	return c.enabled(level) && c.Core.Enabled(level)
+}
+
+func (c *levelFilterCore) With(fields []zapcore.Field) zapcore.Core {
+	return &levelFilterCore{Core: c.Core.With(fields), enabled: c.enabled}
+}
+
+func (c *levelFilterCore) Check(entry zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.enabled(entry.Level) {
+		return ce
+	}
+
+	return c.Core.Check(entry, ce)
+}
+
+func (c *levelFilterCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	if !c.enabled(entry.Level) {
+		return nil
+	}
+
+	return c.Core.Write(entry, fields)
+}
@@ -0,0 +1,71 @@
+package logger
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// TestSampling проверяет, что сэмплируются только настроенные уровни.
+func TestSampling(t *testing.T) {
+	core, logs := observer.New(zap.DebugLevel)
+
+	logger := NewLogger(Sampling("debug", 2, 0), Sampling("info", 1, 5), Sampling("unknown", 1, 1))
+	assert.Len(t, logger.sampling, 2)
+
+	base := zap.New(newSamplingCore(core, logger.sampling)).With(zap.String("request", "r1"))
+
+	for i := 0; i < 10; i++ {
+		base.Debug("debug")
+		base.Info("info")
+		base.Warn("warn")
+		base.Error("error")
+	}
+
+	counts := map[zapcore.Level]int{}
+	for _, entry := range logs.All() {
+		counts[entry.Level]++
+		assert.Equal(t, "r1", entry.ContextMap()["request"])
+	}
+
+	assert.Equal(t, 2, counts[zapcore.DebugLevel])
+	assert.Equal(t, 2, counts[zapcore.InfoLevel])
+	assert.Equal(t, 10, counts[zapcore.WarnLevel])
+	assert.Equal(t, 10, counts[zapcore.ErrorLevel])
+}
+
+// TestSamplingDisabled проверяет, что без настроек ядро не меняется.
+func TestSamplingDisabled(t *testing.T) {
+	core, _ := observer.New(zap.DebugLevel)
+
+	assert.Equal(t, core, newSamplingCore(core, nil))
+}
+
+func TestSamplingAll(t *testing.T) {
+	core, logs := observer.New(zap.DebugLevel)
+
+	logger := NewLogger(SamplingAll(3, 0), Sampling("warn", 1, 0))
+	assert.Len(t, logger.sampling, 4)
+
+	base := zap.New(newSamplingCore(core, logger.sampling))
+
+	for i := 0; i < 10; i++ {
+		base.Info("info")
+		base.Warn("warn")
+		base.Error("error")
+		base.DPanic("dpanic")
+	}
+
+	counts := map[zapcore.Level]int{}
+	for _, entry := range logs.All() {
+		counts[entry.Level]++
+	}
+
+	assert.Equal(t, 3, counts[zapcore.InfoLevel])
+	assert.Equal(t, 1, counts[zapcore.WarnLevel])
+	assert.Equal(t, 3, counts[zapcore.ErrorLevel])
+	assert.Equal(t, 10, counts[zapcore.DPanicLevel])
+}
@@ -0,0 +1,211 @@
+package logger
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// SanitizeMode определяет обработку управляющих символов в сообщениях и
+// строковых полях.
+type SanitizeMode int
+
+const (
+	// SanitizeNone оставляет строки без изменений.
+	SanitizeNone SanitizeMode = iota
+	// SanitizeStrip удаляет ANSI-последовательности и управляющие символы.
+	SanitizeStrip
+	// SanitizeEscape заменяет управляющие символы видимыми экранированными
+	// последовательностями вида \n и \x1b.
+	SanitizeEscape
+
+	// sanitizeANSI удаляет только ANSI-последовательности; используется
+	// для файлового вывода опцией StripFileANSI.
+	sanitizeANSI SanitizeMode = -1
+)
+
+var ansiSequence = regexp.MustCompile(`\x1b(?:\[[0-9;?]*[ -/]*[@-~]|\][^\x07\x1b]*(?:\x07|\x1b\\)|[@-Z\\-_])`)
+
+func isControl(r rune) bool {
+	return r != '\t' && (r < 0x20 || r == 0x7f || (r >= 0x80 && r <= 0x9f))
+}
+
+func sanitizeString(mode SanitizeMode, s string) string {
+	switch mode {
+	case sanitizeANSI:
+		if !strings.Contains(s, "\x1b") {
+			return s
+		}
+		return ansiSequence.ReplaceAllString(s, "")
+	case SanitizeStrip:
+		if !strings.ContainsFunc(s, isControl) {
+			return s
+		}
+		s = ansiSequence.ReplaceAllString(s, "")
+		return strings.Map(func(r rune) rune {
+			if isControl(r) {
+				return -1
+			}
+			return r
+		}, s)
+	case SanitizeEscape:
+		if !strings.ContainsFunc(s, isControl) {
+			return s
+		}
+		var b strings.Builder
+		for _, r := range s {
+			switch {
+			case r == '\n':
+				b.WriteString(`\n`)
+			case r == '\r':
+				b.WriteString(`\r`)
+			case isControl(r):
+				fmt.Fprintf(&b, `\x%02x`, r)
+			default:
+				b.WriteRune(r)
+			}
+		}
+		return b.String()
+	default:
+		return s
+	}
+}
+
+// sanitizeCore очищает сообщение и строковые поля записей (строки, байтовые
+// строки, ошибки и fmt.Stringer) перед передачей во вложенное ядро,
+// защищая от подделки записей и escape-последовательностей терминала в
+// пользовательском вводе.
+type sanitizeCore struct {
+	zapcore.Core
+	mode SanitizeMode
+}
+
+func (c *sanitizeCore) With(fields []zapcore.Field) zapcore.Core {
+	return &sanitizeCore{Core: c.Core.With(c.sanitizeFields(fields)), mode: c.mode}
+}
+
+func (c *sanitizeCore) Check(entry zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return ce.AddCore(entry, c)
+	}
+
+	return ce
+}
+
+func (c *sanitizeCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	entry.Message = sanitizeString(c.mode, entry.Message)
+
+	return c.Core.Write(entry, c.sanitizeFields(fields))
+}
+
+func (c *sanitizeCore) sanitizeFields(fields []zapcore.Field) []zapcore.Field {
+	var sanitized []zapcore.Field
+
+	for i, field := range fields {
+		field, changed := c.sanitizeField(field)
+		if !changed {
+			continue
+		}
+
+		if sanitized == nil {
+			sanitized = append([]zapcore.Field(nil), fields...)
+		}
+
+		sanitized[i] = field
+	}
+
+	if sanitized == nil {
+		return fields
+	}
+
+	return sanitized
+}
+
+// sanitizeField возвращает очищенную копию поля и признак изменения.
+func (c *sanitizeCore) sanitizeField(field zapcore.Field) (zapcore.Field, bool) {
+	switch field.Type {
+	case zapcore.StringType:
+		value := sanitizeString(c.mode, field.String)
+		if value == field.String {
+			return field, false
+		}
+		field.String = value
+	case zapcore.ByteStringType:
+		b, _ := field.Interface.([]byte)
+		value := sanitizeString(c.mode, string(b))
+		if value == string(b) {
+			return field, false
+		}
+		field.Interface = []byte(value)
+	case zapcore.ErrorType:
+		err, ok := field.Interface.(error)
+		if !ok || err == nil || !c.needsSanitize(err) {
+			return field, false
+		}
+		field.Interface = sanitizedError{err: err, mode: c.mode}
+	case zapcore.StringerType:
+		stringer, ok := field.Interface.(fmt.Stringer)
+		if !ok || stringer == nil {
+			return field, false
+		}
+		field.Interface = sanitizedStringer{stringer: stringer, mode: c.mode}
+	default:
+		return field, false
+	}
+
+	return field, true
+}
+
+// needsSanitize сообщает, что текст ошибки или её подробный вывод нужно
+// очищать. Чистая ошибка остаётся как есть, чтобы zap записал её причины
+// (errorCauses).
+func (c *sanitizeCore) needsSanitize(err error) bool {
+	text := err.Error()
+	if sanitizeString(c.mode, text) != text {
+		return true
+	}
+
+	if _, ok := err.(fmt.Formatter); ok {
+		verbose := fmt.Sprintf("%+v", err)
+		return sanitizeString(c.mode, verbose) != verbose
+	}
+
+	return false
+}
+
+// sanitizedError очищает текст ошибки и её подробный вывод %+v, который
+// zap пишет в поле errorVerbose.
+type sanitizedError struct {
+	err  error
+	mode SanitizeMode
+}
+
+func (e sanitizedError) Error() string {
+	return sanitizeString(e.mode, e.err.Error())
+}
+
+func (e sanitizedError) Unwrap() error {
+	return e.err
+}
+
+func (e sanitizedError) Format(s fmt.State, verb rune) {
+	if verb == 'v' && s.Flag('+') {
+		_, _ = io.WriteString(s, sanitizeString(e.mode, fmt.Sprintf("%+v", e.err)))
+		return
+	}
+
+	_, _ = io.WriteString(s, e.Error())
+}
+
+// sanitizedStringer очищает результат String при кодировании записи.
+type sanitizedStringer struct {
+	stringer fmt.Stringer
+	mode     SanitizeMode
+}
+
+func (s sanitizedStringer) String() string {
+	return sanitizeString(s.mode, s.stringer.String())
+}
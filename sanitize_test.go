@@ -0,0 +1,117 @@
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// TestSanitizeString проверяет удаление и экранирование управляющих символов.
+func TestSanitizeString(t *testing.T) {
+	tests := []struct {
+		name     string
+		mode     SanitizeMode
+		input    string
+		expected string
+	}{
+		{name: "Plain text", mode: SanitizeStrip, input: "plain\ttext", expected: "plain\ttext"},
+		{name: "Strip ANSI color", mode: SanitizeStrip, input: "\x1b[31mred\x1b[0m", expected: "red"},
+		{name: "Strip OSC title", mode: SanitizeStrip, input: "\x1b]0;pwned\x07text", expected: "text"},
+		{name: "Strip newline", mode: SanitizeStrip, input: "user\n2024 INFO fake", expected: "user2024 INFO fake"},
+		{name: "Escape newline", mode: SanitizeEscape, input: "user\r\nfake", expected: `user\r\nfake`},
+		{name: "Escape ANSI", mode: SanitizeEscape, input: "\x1b[31mred", expected: `\x1b[31mred`},
+		{name: "None", mode: SanitizeNone, input: "a\nb", expected: "a\nb"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, sanitizeString(tt.mode, tt.input))
+		})
+	}
+}
+
+// TestSanitizeCore проверяет очистку сообщения и строковых полей.
+func TestSanitizeCore(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	logger := zap.New(&sanitizeCore{Core: core, mode: SanitizeStrip})
+
+	logger.With(zap.String("user", "bob\x1b[2J")).Info("login\nfake entry", zap.String("agent", "curl\r"), zap.Int("code", 7))
+	logger.Debug("filtered")
+
+	entries := logs.All()
+	if assert.Len(t, entries, 1) {
+		assert.Equal(t, "loginfake entry", entries[0].Message)
+		assert.Equal(t, map[string]interface{}{"user": "bob", "agent": "curl", "code": int64(7)}, entries[0].ContextMap())
+	}
+}
+
+// TestSanitizeCoreFieldTypes проверяет очистку ошибок, fmt.Stringer и
+// байтовых строк.
+func TestSanitizeCoreFieldTypes(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	logger := zap.New(&sanitizeCore{Core: core, mode: SanitizeEscape})
+
+	cause := errors.New("clean cause")
+	logger.Info("request",
+		zap.Error(fmt.Errorf("parse: %w", errors.New("line\n\x1b[2J"))),
+		zap.Stringer("path", stringerFunc(func() string { return "/a\r\nb" })),
+		zap.ByteString("body", []byte("x\ny")),
+		zap.NamedError("clean", cause),
+	)
+
+	entries := logs.All()
+	require.Len(t, entries, 1)
+
+	fields := entries[0].ContextMap()
+	assert.Equal(t, `parse: line\n\x1b[2J`, fields["error"])
+	assert.Equal(t, `/a\r\nb`, fields["path"])
+	assert.Equal(t, `x\ny`, fields["body"])
+	assert.Equal(t, "clean cause", fields["clean"])
+	assert.Same(t, cause, entries[0].Context[3].Interface, "Clean errors should be kept as is")
+}
+
+type stringerFunc func() string
+
+func (f stringerFunc) String() string {
+	return f()
+}
+
+// TestStripFileANSI проверяет, что цвета удаляются из файла, но остаются в консоли.
+func TestStripFileANSI(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "logger_test")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	oldStdout := os.Stdout
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	os.Stdout = w
+
+	logger := NewLogger(Path(tmpDir), StripFileANSI(true))
+	require.NoError(t, logger.Init(true))
+
+	logger.Info("build \x1b[32mok\x1b[0m")
+	require.NoError(t, logger.Close())
+
+	w.Close()
+	os.Stdout = oldStdout
+
+	console, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Contains(t, string(console), "build \x1b[32mok\x1b[0m", "Console should keep colors")
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, time.Now().Format(dateLayout)+".log"))
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "build ok")
+	assert.NotContains(t, string(content), "\x1b")
+}
@@ -0,0 +1,318 @@
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// SchemaMode определяет реакцию на записи, не соответствующие
+// зарегистрированной схеме.
+type SchemaMode int
+
+const (
+	// SchemaOff отключает проверку.
+	SchemaOff SchemaMode = iota
+	// SchemaReport сообщает о нарушениях в журнал Diagnostics, а без него
+	// в stderr.
+	SchemaReport
+	// SchemaStrict вызывает панику при нарушении. Предназначен для
+	// разработки и тестов.
+	SchemaStrict
+)
+
+// SchemaValidation включает проверку записей по схемам RegisterSchema.
+func SchemaValidation(mode SchemaMode) Option {
+	return func(l *Logger) {
+		l.schemaMode = mode
+	}
+}
+
+// schemaTypes — поддерживаемые значения "type" JSON Schema.
+var schemaTypes = map[string]bool{
+	"string":  true,
+	"integer": true,
+	"number":  true,
+	"boolean": true,
+	"object":  true,
+	"array":   true,
+}
+
+type schema struct {
+	Required   []string `json:"required"`
+	Properties map[string]struct {
+		Type string `json:"type"`
+	} `json:"properties"`
+}
+
+var (
+	schemasMu sync.RWMutex
+	schemas   = map[string]*schema{}
+)
+
+// RegisterSchema регистрирует JSON Schema для записей с сообщением name
+// (для типизированных событий — имя события). Поддерживаются ключевые
+// слова "required" и "properties" с "type". Повторная регистрация имени
+// возвращает ошибку.
+func RegisterSchema(name string, data []byte) error {
+	var s schema
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("logger: invalid schema %q: %w", name, err)
+	}
+
+	for field, property := range s.Properties {
+		if !schemaTypes[property.Type] {
+			return fmt.Errorf("logger: schema %q: unsupported type %q of field %q", name, property.Type, field)
+		}
+	}
+
+	schemasMu.Lock()
+	defer schemasMu.Unlock()
+
+	if name == "" {
+		return fmt.Errorf("logger: schema name must not be empty")
+	}
+
+	if _, exist := schemas[name]; exist {
+		return fmt.Errorf("logger: schema %q is already registered", name)
+	}
+
+	schemas[name] = &s
+
+	return nil
+}
+
+func lookupSchema(name string) *schema {
+	schemasMu.RLock()
+	defer schemasMu.RUnlock()
+
+	return schemas[name]
+}
+
+// validate возвращает описания нарушений схемы.
+func (s *schema) validate(fields map[string]interface{}) []string {
+	var violations []string
+
+	for _, field := range s.Required {
+		if _, ok := fields[field]; !ok {
+			violations = append(violations, fmt.Sprintf("missing required field %q", field))
+		}
+	}
+
+	for field, property := range s.Properties {
+		value, ok := fields[field]
+		if !ok {
+			continue
+		}
+
+		if actual := schemaType(value); !matchesType(actual, property.Type) {
+			violations = append(violations, fmt.Sprintf("field %q is %s, want %s", field, actual, property.Type))
+		}
+	}
+
+	sort.Strings(violations)
+
+	return violations
+}
+
+func matchesType(actual, want string) bool {
+	return actual == want || (want == "number" && actual == "integer")
+}
+
+// schemaType возвращает тип JSON Schema значения из MapObjectEncoder.
+func schemaType(value interface{}) string {
+	switch value.(type) {
+	case string, []byte, time.Time:
+		return "string"
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, uintptr:
+		return "integer"
+	case float32, float64, complex64, complex128, time.Duration:
+		return "number"
+	case bool:
+		return "boolean"
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	case nil:
+		return "null"
+	default:
+		return "object"
+	}
+}
+
+// schemaCore ничего не пишет, а только проверяет записи. Оно добавляется
+// в Tee рядом с остальными ядрами и видит все записи, прошедшие Check.
+type schemaCore struct {
+	zapcore.LevelEnabler
+
+	mode   SchemaMode
+	diag   *zap.Logger
+	fields []zapcore.Field
+}
+
+func (c *schemaCore) With(fields []zapcore.Field) zapcore.Core {
+	return &schemaCore{
+		LevelEnabler: c.LevelEnabler,
+
+		mode:   c.mode,
+		diag:   c.diag,
+		fields: append(c.fields[:len(c.fields):len(c.fields)], fields...),
+	}
+}
+
+func (c *schemaCore) Check(entry zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.Enabled(entry.Level) || lookupSchema(entry.Message) == nil {
+		return ce
+	}
+
+	return ce.AddCore(entry, c)
+}
+
+func (c *schemaCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	s := lookupSchema(entry.Message)
+	if s == nil {
+		return nil
+	}
+
+	enc := zapcore.NewMapObjectEncoder()
+	for _, field := range c.fields {
+		field.AddTo(enc)
+	}
+	for _, field := range fields {
+		field.AddTo(enc)
+	}
+
+	violations := s.validate(enc.Fields)
+	if len(violations) == 0 {
+		return nil
+	}
+
+	if c.mode == SchemaStrict {
+		panic(fmt.Sprintf("logger: entry %q violates schema: %s", entry.Message, strings.Join(violations, "; ")))
+	}
+
+	diagLogger(c.diag).Warn("schema violation",
+		zap.String("entry", entry.Message),
+		zap.String("caller", entry.Caller.TrimmedPath()),
+		zap.Strings("violations", violations),
+	)
+
+	return nil
+}
+
+func (c *schemaCore) Sync() error {
+	return nil
+}
+
+// schemaVersionKey — поле с версией схемы записей.
+const schemaVersionKey = "schema_version"
+
+// SchemaVersion добавляет к каждой записи файла и Outputs поле
+// schema_version и переименовывает поля по правилам RegisterFieldRenames
+// для этой версии. Консольный вывод не меняется.
+func SchemaVersion(version string) Option {
+	return func(l *Logger) {
+		l.schemaVersion = version
+	}
+}
+
+var (
+	renamesMu sync.RWMutex
+	renames   = map[string]map[string]string{}
+)
+
+// RegisterFieldRenames задаёт переименование полей верхнего уровня для
+// версии схемы: ключ — имя в коде, значение — имя в выводе. Так код
+// переходит на новую схему постепенно, а потребители сразу видят новые
+// имена. Повторная регистрация версии возвращает ошибку.
+func RegisterFieldRenames(version string, fields map[string]string) error {
+	renamesMu.Lock()
+	defer renamesMu.Unlock()
+
+	if version == "" {
+		return fmt.Errorf("logger: schema version must not be empty")
+	}
+
+	if _, exist := renames[version]; exist {
+		return fmt.Errorf("logger: field renames for schema version %q are already registered", version)
+	}
+
+	copied := make(map[string]string, len(fields))
+	for from, to := range fields {
+		copied[from] = to
+	}
+
+	renames[version] = copied
+
+	return nil
+}
+
+func lookupFieldRenames(version string) map[string]string {
+	renamesMu.RLock()
+	defer renamesMu.RUnlock()
+
+	return renames[version]
+}
+
+// newVersionCore оборачивает ядро переименованием полей и добавляет поле
+// версии схемы.
+func newVersionCore(core zapcore.Core, version string) zapcore.Core {
+	if fields := lookupFieldRenames(version); len(fields) > 0 {
+		core = &renameCore{Core: core, renames: fields}
+	}
+
+	return core.With([]zapcore.Field{zap.String(schemaVersionKey, version)})
+}
+
+// renameCore переименовывает поля записей перед передачей во вложенное
+// ядро.
+type renameCore struct {
+	zapcore.Core
+	renames map[string]string
+}
+
+func (c *renameCore) With(fields []zapcore.Field) zapcore.Core {
+	return &renameCore{Core: c.Core.With(c.renameFields(fields)), renames: c.renames}
+}
+
+func (c *renameCore) Check(entry zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return ce.AddCore(entry, c)
+	}
+
+	return ce
+}
+
+func (c *renameCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	return c.Core.Write(entry, c.renameFields(fields))
+}
+
+func (c *renameCore) renameFields(fields []zapcore.Field) []zapcore.Field {
+	var renamed []zapcore.Field
+
+	for i, field := range fields {
+		to, ok := c.renames[field.Key]
+		if !ok {
+			continue
+		}
+
+		if renamed == nil {
+			renamed = append([]zapcore.Field(nil), fields...)
+		}
+
+		renamed[i].Key = to
+	}
+
+	if renamed == nil {
+		return fields
+	}
+
+	return renamed
+}
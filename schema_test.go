@@ -0,0 +1,151 @@
+package logger
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const testOrderSchema = `{
+	"required": ["order_id", "amount"],
+	"properties": {
+		"order_id": {"type": "string"},
+		"amount": {"type": "number"},
+		"paid": {"type": "boolean"}
+	}
+}`
+
+// TestRegisterSchema проверяет разбор и повторную регистрацию схем.
+func TestRegisterSchema(t *testing.T) {
+	require.NoError(t, RegisterSchema("test-schema-register", []byte(testOrderSchema)))
+	assert.Error(t, RegisterSchema("test-schema-register", []byte(testOrderSchema)))
+	assert.Error(t, RegisterSchema("test-schema-invalid", []byte(`{"required":`)))
+	assert.Error(t, RegisterSchema("test-schema-type", []byte(`{"properties":{"a":{"type":"date"}}}`)))
+	assert.Error(t, RegisterSchema("", []byte(`{}`)))
+}
+
+// TestSchemaValidate проверяет обязательные поля и типы.
+func TestSchemaValidate(t *testing.T) {
+	require.NoError(t, RegisterSchema("test-schema-validate", []byte(testOrderSchema)))
+	s := lookupSchema("test-schema-validate")
+
+	assert.Empty(t, s.validate(map[string]interface{}{"order_id": "o1", "amount": 10}))
+	assert.Empty(t, s.validate(map[string]interface{}{"order_id": "o1", "amount": 10.5, "extra": 1}))
+	assert.Equal(t, []string{
+		`field "order_id" is integer, want string`,
+		`missing required field "amount"`,
+	}, s.validate(map[string]interface{}{"order_id": 1}))
+}
+
+// TestSchemaValidationReport проверяет сообщение о нарушении в диагностике.
+func TestSchemaValidationReport(t *testing.T) {
+	require.NoError(t, RegisterSchema("test order created", []byte(testOrderSchema)))
+
+	tmpDir, err := os.MkdirTemp("", "logger_test")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	diag := &syncBuffer{}
+
+	logger := NewLogger(Path(tmpDir), SchemaValidation(SchemaReport), Diagnostics(diag))
+	require.NoError(t, logger.Init(false))
+
+	logger.baseLogger.Info("test order created", zap.String("order_id", "o1"), zap.Float64("amount", 1))
+	assert.NotContains(t, diag.String(), "schema violation")
+
+	logger.baseLogger.Info("test order created", zap.String("orderId", "o1"), zap.Float64("amount", 1))
+	require.NoError(t, logger.Close())
+
+	assert.Contains(t, diag.String(), "schema violation")
+	assert.Contains(t, diag.String(), `missing required field \"order_id\"`)
+}
+
+// TestSchemaValidationReportStderr проверяет, что без Diagnostics
+// нарушение сообщается в stderr.
+func TestSchemaValidationReportStderr(t *testing.T) {
+	require.NoError(t, RegisterSchema("test order shipped", []byte(testOrderSchema)))
+
+	oldStderr := os.Stderr
+	errR, errW, err := os.Pipe()
+	require.NoError(t, err)
+	os.Stderr = errW
+
+	logger := NewLogger(Path(t.TempDir()), SchemaValidation(SchemaReport))
+	require.NoError(t, logger.Init(false))
+
+	logger.baseLogger.Info("test order shipped", zap.Float64("amount", 1))
+	require.NoError(t, logger.Close())
+
+	errW.Close()
+	os.Stderr = oldStderr
+
+	stderr, err := io.ReadAll(errR)
+	require.NoError(t, err)
+
+	assert.Contains(t, string(stderr), "schema violation")
+}
+
+// TestSchemaValidationStrict проверяет панику в строгом режиме.
+func TestSchemaValidationStrict(t *testing.T) {
+	require.NoError(t, RegisterSchema("test order paid", []byte(testOrderSchema)))
+
+	tmpDir, err := os.MkdirTemp("", "logger_test")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	logger := NewLogger(Path(tmpDir), SchemaValidation(SchemaStrict))
+	require.NoError(t, logger.Init(false))
+	defer logger.Close()
+
+	withFields := logger.WithFields(map[string]interface{}{"order_id": "o1"})
+
+	assert.NotPanics(t, func() {
+		withFields.baseLogger.Info("test order paid", zap.Float64("amount", 1))
+	})
+	assert.Panics(t, func() {
+		withFields.baseLogger.Info("test order paid", zap.String("amount", "1"))
+	})
+}
+
+// TestSchemaVersion проверяет поле версии и переименование полей в файле.
+func TestSchemaVersion(t *testing.T) {
+	require.NoError(t, RegisterFieldRenames("test-2", map[string]string{"userId": "user_id"}))
+	assert.Error(t, RegisterFieldRenames("test-2", nil))
+
+	tmpDir, err := os.MkdirTemp("", "logger_test")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	logger := NewLogger(Path(tmpDir), Structured(true), SchemaVersion("test-2"))
+	require.NoError(t, logger.Init(false))
+
+	logger.WithFields(map[string]interface{}{"userId": 7}).Info("first")
+	logger.baseLogger.Info("second", zap.String("userId", "u1"), zap.String("other", "x"))
+	require.NoError(t, logger.Close())
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, time.Now().Format(dateLayout)+".log"))
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	require.Len(t, lines, 2)
+
+	var first, second map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &first))
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &second))
+
+	assert.Equal(t, "test-2", first["schema_version"])
+	assert.Equal(t, float64(7), first["user_id"])
+	assert.NotContains(t, first, "userId")
+
+	assert.Equal(t, "test-2", second["schema_version"])
+	assert.Equal(t, "u1", second["user_id"])
+	assert.Equal(t, "x", second["other"])
+}
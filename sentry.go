@@ -0,0 +1,245 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"runtime"
+	"strings"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// sentryClient — значение sentry_client в заголовке аутентификации.
+const sentryClient = "restfront-logger/1.0"
+
+// SentryHook отправляет записи уровня minLevel и выше ("error", "panic",
+// "fatal") событиями в Sentry: с сообщением, полями в extra, ошибкой из
+// поля "error" и стеком вызова. Остальной вывод не меняется. События
+// отправляются в фоне.
+func SentryHook(dsn string, minLevel string) Option {
+	return func(l *Logger) {
+		lvl, exist := loggerLevelMap[minLevel]
+		if !exist {
+			l.optionErrs = append(l.optionErrs, fmt.Errorf("logger: unknown Sentry level %q", minLevel))
+			return
+		}
+
+		target, err := parseSentryDSN(dsn)
+		if err != nil {
+			l.optionErrs = append(l.optionErrs, err)
+			return
+		}
+
+		target.level = lvl
+		l.sentry = target
+	}
+}
+
+// sentryTarget — адрес отправки событий, разобранный из DSN.
+type sentryTarget struct {
+	endpoint string
+	key      string
+	level    zapcore.Level
+}
+
+// parseSentryDSN разбирает DSN вида https://KEY@host/PROJECT.
+func parseSentryDSN(dsn string) (*sentryTarget, error) {
+	u, err := url.Parse(dsn)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.User == nil || u.User.Username() == "" {
+		return nil, fmt.Errorf("logger: invalid Sentry DSN")
+	}
+
+	path := strings.Trim(u.Path, "/")
+	i := strings.LastIndexByte(path, '/')
+	project := path[i+1:]
+	if project == "" {
+		return nil, fmt.Errorf("logger: Sentry DSN has no project")
+	}
+
+	endpoint := url.URL{Scheme: u.Scheme, Host: u.Host, Path: "/" + path[:i+1] + "api/" + project + "/store/"}
+
+	return &sentryTarget{endpoint: endpoint.String(), key: u.User.Username()}, nil
+}
+
+func (l *Logger) newSentryCore(target *sentryTarget) zapcore.Core {
+	client := &http.Client{Timeout: 10 * time.Second}
+	auth := fmt.Sprintf("Sentry sentry_version=7, sentry_client=%s, sentry_key=%s", sentryClient, target.key)
+
+	// События с одинаковым event_id Sentry не дублирует, поэтому пачку
+	// можно отправлять повторно целиком.
+	sink := newBatchSink("sentry", 100, batchFlushInterval, func(ctx context.Context, batch []batchEntry) (bool, error) {
+		for _, entry := range batch {
+			req, err := http.NewRequestWithContext(ctx, http.MethodPost, target.endpoint, strings.NewReader(entry.line))
+			if err != nil {
+				return false, err
+			}
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Sentry-Auth", auth)
+
+			if _, retry, err := postBatch(client, req); err != nil {
+				return retry, fmt.Errorf("logger: sentry: %w", err)
+			}
+		}
+
+		return false, nil
+	})
+	sink.setDiagnostics(l.diag)
+
+	l.closers = append(l.closers, sink)
+
+	return &sentryCore{LevelEnabler: target.level, sink: sink}
+}
+
+// sentryCore превращает записи в события Sentry.
+type sentryCore struct {
+	zapcore.LevelEnabler
+	sink   Sink
+	fields []zapcore.Field
+}
+
+func (c *sentryCore) With(fields []zapcore.Field) zapcore.Core {
+	return &sentryCore{
+		LevelEnabler: c.LevelEnabler,
+		sink:         c.sink,
+		fields:       append(c.fields[:len(c.fields):len(c.fields)], fields...),
+	}
+}
+
+func (c *sentryCore) Check(entry zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return ce.AddCore(entry, c)
+	}
+
+	return ce
+}
+
+func (c *sentryCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	// Записи в обход уровня (VerboseWhen, дампы) в Sentry не отправляются.
+	if !c.Enabled(entry.Level) {
+		return nil
+	}
+
+	enc := zapcore.NewMapObjectEncoder()
+	for _, field := range c.fields {
+		field.AddTo(enc)
+	}
+
+	var exception []map[string]interface{}
+
+	for _, field := range fields {
+		field.AddTo(enc)
+
+		if err, ok := field.Interface.(error); ok && field.Type == zapcore.ErrorType {
+			exception = []map[string]interface{}{{
+				"type":  fmt.Sprintf("%T", err),
+				"value": err.Error(),
+			}}
+		}
+	}
+
+	id := make([]byte, 16)
+	_, _ = rand.Read(id)
+
+	event := map[string]interface{}{
+		"event_id":  hex.EncodeToString(id),
+		"timestamp": entry.Time.UTC().Format(time.RFC3339Nano),
+		"level":     sentryLevel(entry.Level),
+		"platform":  "go",
+		"logger":    entry.LoggerName,
+		"message":   entry.Message,
+		"extra":     enc.Fields,
+	}
+
+	frames := sentryFrames()
+	if entry.Stack != "" {
+		enc.Fields["stacktrace"] = entry.Stack
+	}
+
+	if exception != nil {
+		exception[0]["stacktrace"] = map[string]interface{}{"frames": frames}
+		event["exception"] = map[string]interface{}{"values": exception}
+	} else {
+		event["threads"] = map[string]interface{}{
+			"values": []interface{}{map[string]interface{}{"current": true, "stacktrace": map[string]interface{}{"frames": frames}}},
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(event); err != nil {
+		return err
+	}
+
+	_, err := c.sink.Write(buf.Bytes())
+
+	return err
+}
+
+func (c *sentryCore) Sync() error {
+	return nil
+}
+
+func sentryLevel(level zapcore.Level) string {
+	switch {
+	case level <= zapcore.DebugLevel:
+		return "debug"
+	case level == zapcore.InfoLevel:
+		return "info"
+	case level == zapcore.WarnLevel:
+		return "warning"
+	case level == zapcore.ErrorLevel:
+		return "error"
+	default:
+		return "fatal"
+	}
+}
+
+// sentryFrames возвращает стек вызова без кадров zap и логгера в
+// порядке, принятом в Sentry: вызывающий код последним.
+func sentryFrames() []map[string]interface{} {
+	pcs := make([]uintptr, 64)
+	n := runtime.Callers(2, pcs)
+
+	var frames []map[string]interface{}
+
+	it := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := it.Next()
+		if !internalFrame(frame.Function) {
+			frames = append(frames, map[string]interface{}{
+				"function": frame.Function,
+				"abs_path": frame.File,
+				"lineno":   frame.Line,
+			})
+		}
+		if !more {
+			break
+		}
+	}
+
+	for i, j := 0, len(frames)-1; i < j; i, j = i+1, j-1 {
+		frames[i], frames[j] = frames[j], frames[i]
+	}
+
+	return frames
+}
+
+func internalFrame(function string) bool {
+	if strings.HasPrefix(function, "go.uber.org/zap") {
+		return true
+	}
+
+	name, found := strings.CutPrefix(function, "github.com/restfront/logger.")
+	if !found {
+		return false
+	}
+
+	// Тесты пакета относятся к вызывающему коду.
+	return !strings.HasPrefix(name, "Test")
+}
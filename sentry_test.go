@@ -0,0 +1,88 @@
+package logger
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestParseSentryDSN проверяет адрес отправки событий.
+func TestParseSentryDSN(t *testing.T) {
+	target, err := parseSentryDSN("https://abc@o1.ingest.sentry.io/42")
+	require.NoError(t, err)
+	assert.Equal(t, "https://o1.ingest.sentry.io/api/42/store/", target.endpoint)
+	assert.Equal(t, "abc", target.key)
+
+	target, err = parseSentryDSN("http://abc@sentry.local/prefix/7")
+	require.NoError(t, err)
+	assert.Equal(t, "http://sentry.local/prefix/api/7/store/", target.endpoint)
+
+	_, err = parseSentryDSN("https://sentry.local/7")
+	assert.Error(t, err)
+
+	_, err = parseSentryDSN("https://abc@sentry.local/")
+	assert.Error(t, err)
+}
+
+// TestSentryHook проверяет отправку ошибок событиями Sentry.
+func TestSentryHook(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		events []map[string]interface{}
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/42/store/", r.URL.Path)
+		assert.Contains(t, r.Header.Get("X-Sentry-Auth"), "sentry_key=abc")
+
+		var event map[string]interface{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&event))
+
+		mu.Lock()
+		events = append(events, event)
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	dsn := strings.Replace(server.URL, "://", "://abc@", 1) + "/42"
+
+	logger := NewLogger(Path(t.TempDir()), AppName("app"), SentryHook(dsn, "error"))
+	require.NoError(t, logger.Init(false))
+
+	logger.Warn("not sent")
+	logger.Named("db").WithFields(map[string]interface{}{"user": "u1"}).ErrorW("query failed", Err(errors.New("timeout")))
+	require.NoError(t, logger.Close())
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	require.Len(t, events, 1)
+	event := events[0]
+	assert.Equal(t, "error", event["level"])
+	assert.Equal(t, "db", event["logger"])
+	assert.Equal(t, "query failed", event["message"])
+	assert.Equal(t, map[string]interface{}{"user": "u1", "error": "timeout"}, event["extra"])
+	assert.Len(t, event["event_id"], 32)
+
+	exception := event["exception"].(map[string]interface{})["values"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "*errors.errorString", exception["type"])
+	assert.Equal(t, "timeout", exception["value"])
+
+	frames := exception["stacktrace"].(map[string]interface{})["frames"].([]interface{})
+	require.NotEmpty(t, frames)
+	last := frames[len(frames)-1].(map[string]interface{})
+	assert.Equal(t, "github.com/restfront/logger.TestSentryHook", last["function"])
+}
+
+// TestSentryHookInvalid проверяет ошибки настройки.
+func TestSentryHookInvalid(t *testing.T) {
+	_, err := Build(Path(t.TempDir()), SentryHook("https://abc@sentry.local/1", "critical"))
+	assert.ErrorContains(t, err, `unknown Sentry level "critical"`)
+}
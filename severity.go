@@ -0,0 +1,118 @@
+package logger
+
+import (
+	"fmt"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// OTelSeverity добавляет к записям файла и Outputs поле severity_number с
+// числовым уровнем OpenTelemetry (SeverityNumber).
+func OTelSeverity(enable bool) Option {
+	return func(l *Logger) {
+		l.otelSeverity = enable
+	}
+}
+
+// otelSeverityNumbers сопоставляет уровни zap значениям SeverityNumber
+// модели логов OpenTelemetry.
+var otelSeverityNumbers = map[zapcore.Level]int{
+	zapcore.DebugLevel:  5,
+	zapcore.InfoLevel:   9,
+	zapcore.WarnLevel:   13,
+	zapcore.ErrorLevel:  17,
+	zapcore.DPanicLevel: 18,
+	zapcore.PanicLevel:  19,
+	zapcore.FatalLevel:  21,
+}
+
+func otelSeverityFields(level zapcore.Level) []zapcore.Field {
+	return []zapcore.Field{zap.Int("severity_number", otelSeverityNumbers[level])}
+}
+
+// levelFieldsCore дописывает к каждой записи поля, вычисленные по её
+// уровню.
+type levelFieldsCore struct {
+	zapcore.Core
+	fields func(zapcore.Level) []zapcore.Field
+}
+
+func (c *levelFieldsCore) With(fields []zapcore.Field) zapcore.Core {
+	return &levelFieldsCore{Core: c.Core.With(fields), fields: c.fields}
+}
+
+func (c *levelFieldsCore) Check(entry zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return ce.AddCore(entry, c)
+	}
+
+	return ce
+}
+
+func (c *levelFieldsCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	extra := c.fields(entry.Level)
+
+	all := make([]zapcore.Field, 0, len(extra)+len(fields))
+	all = append(all, extra...)
+	all = append(all, fields...)
+
+	return c.Core.Write(entry, all)
+}
+
+// SyslogFacility — код источника syslog (RFC 5424).
+type SyslogFacility int
+
+const (
+	FacilityKern   SyslogFacility = 0
+	FacilityUser   SyslogFacility = 1
+	FacilityDaemon SyslogFacility = 3
+	FacilityAuth   SyslogFacility = 4
+	FacilityLocal0 SyslogFacility = 16
+	FacilityLocal1 SyslogFacility = 17
+	FacilityLocal2 SyslogFacility = 18
+	FacilityLocal3 SyslogFacility = 19
+	FacilityLocal4 SyslogFacility = 20
+	FacilityLocal5 SyslogFacility = 21
+	FacilityLocal6 SyslogFacility = 22
+	FacilityLocal7 SyslogFacility = 23
+)
+
+// SyslogFields добавляет к записям файла и Outputs объект syslog с полями
+// severity (по уровню записи) и facility, чтобы сборщики могли
+// пересылать записи в syslog без таблиц соответствия.
+func SyslogFields(facility SyslogFacility) Option {
+	return func(l *Logger) {
+		l.syslogFields = true
+		l.syslogFacility = facility
+	}
+}
+
+// syslogSeverities сопоставляет уровни zap уровням важности syslog.
+var syslogSeverities = map[zapcore.Level]int{
+	zapcore.DebugLevel:  7,
+	zapcore.InfoLevel:   6,
+	zapcore.WarnLevel:   4,
+	zapcore.ErrorLevel:  3,
+	zapcore.DPanicLevel: 2,
+	zapcore.PanicLevel:  2,
+	zapcore.FatalLevel:  2,
+}
+
+func (f SyslogFacility) validate() error {
+	if f < 0 || f > 23 {
+		return fmt.Errorf("logger: invalid syslog facility %d", f)
+	}
+
+	return nil
+}
+
+func syslogFields(facility SyslogFacility) func(zapcore.Level) []zapcore.Field {
+	return func(level zapcore.Level) []zapcore.Field {
+		return []zapcore.Field{zap.Object("syslog", zapcore.ObjectMarshalerFunc(func(enc zapcore.ObjectEncoder) error {
+			enc.AddInt("severity", syslogSeverities[level])
+			enc.AddInt("facility", int(facility))
+			return nil
+		}))}
+	}
+}
@@ -0,0 +1,86 @@
+package logger
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readJSONLines(t *testing.T, path string) []map[string]interface{} {
+	t.Helper()
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	var entries []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(string(content)), "\n") {
+		var entry map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(line), &entry))
+		entries = append(entries, entry)
+	}
+
+	return entries
+}
+
+// TestOTelSeverity проверяет числовой уровень OpenTelemetry в файле.
+func TestOTelSeverity(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "logger_test")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	logger := NewLogger(Path(tmpDir), Level("debug"), Structured(true), OTelSeverity(true))
+	require.NoError(t, logger.Init(false))
+
+	logger.Debug("debug")
+	logger.Info("info")
+	logger.Warn("warn")
+	logger.Error("error")
+	require.NoError(t, logger.Close())
+
+	entries := readJSONLines(t, filepath.Join(tmpDir, time.Now().Format(dateLayout)+".log"))
+	require.Len(t, entries, 4)
+
+	for i, want := range []float64{5, 9, 13, 17} {
+		assert.Equal(t, want, entries[i]["severity_number"])
+		assert.Contains(t, entries[i], "level")
+	}
+}
+
+// TestSyslogFields проверяет поля syslog в файле.
+func TestSyslogFields(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "logger_test")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	logger := NewLogger(Path(tmpDir), Level("debug"), Structured(true), SyslogFields(FacilityLocal3))
+	require.NoError(t, logger.Init(false))
+
+	logger.Debug("debug")
+	logger.Info("info")
+	logger.Warn("warn")
+	logger.Error("error")
+	require.NoError(t, logger.Close())
+
+	entries := readJSONLines(t, filepath.Join(tmpDir, time.Now().Format(dateLayout)+".log"))
+	require.Len(t, entries, 4)
+
+	for i, want := range []float64{7, 6, 4, 3} {
+		assert.Equal(t, map[string]interface{}{"severity": want, "facility": float64(19)}, entries[i]["syslog"])
+	}
+}
+
+// TestSyslogFieldsInvalidFacility проверяет ошибку Init при неверном коде источника.
+func TestSyslogFieldsInvalidFacility(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "logger_test")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	logger := NewLogger(Path(tmpDir), SyslogFields(24))
+	assert.Error(t, logger.Init(false))
+}
@@ -0,0 +1,607 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"sync"
+	"syscall"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Sink — назначение закодированных записей. Sink закрывается вместе с
+// логгером.
+type Sink interface {
+	zapcore.WriteSyncer
+	io.Closer
+}
+
+// SinkFactory создаёт Sink по URL назначения.
+type SinkFactory func(u *url.URL) (Sink, error)
+
+var (
+	sinksMu sync.RWMutex
+	sinks   = map[string]SinkFactory{
+		"tcp": newNetSink,
+		"udp": newNetSink,
+
+		"gelf": newGELFSink,
+
+		"loki+http":  newLokiSink,
+		"loki+https": newLokiSink,
+
+		"elasticsearch+http":  newElasticsearchSink,
+		"elasticsearch+https": newElasticsearchSink,
+
+		"otlp+http":  newOTLPSink,
+		"otlp+https": newOTLPSink,
+	}
+)
+
+// Схемы, которые логгер обрабатывает сам, так как они зависят от его
+// настроек или общих ресурсов процесса.
+var builtinSchemes = map[string]bool{
+	"stdout": true,
+	"stderr": true,
+	"file":   true,
+}
+
+// RegisterSink регистрирует фабрику назначений для схемы URL, после чего
+// схему можно использовать в опции Outputs. Схемы, не найденные здесь,
+// открываются через zap.Open.
+func RegisterSink(scheme string, factory SinkFactory) error {
+	sinksMu.Lock()
+	defer sinksMu.Unlock()
+
+	if scheme == "" {
+		return fmt.Errorf("logger: sink scheme must not be empty")
+	}
+
+	if _, exist := sinks[scheme]; exist || builtinSchemes[scheme] {
+		return fmt.Errorf("logger: sink for scheme %q is already registered", scheme)
+	}
+
+	sinks[scheme] = factory
+
+	return nil
+}
+
+// parseSinkURL разбирает URL назначения. Строки "stdout" и "stderr" и
+// пути без схемы (в том числе пути Windows с буквой диска) допускаются.
+func parseSinkURL(raw string) (*url.URL, error) {
+	u, err := url.Parse(raw)
+	if err != nil || len(u.Scheme) == 1 {
+		return &url.URL{Scheme: "file", Path: raw}, nil
+	}
+
+	if u.Scheme == "" {
+		switch u.Path {
+		case "stdout", "stderr":
+			u.Scheme, u.Path = u.Path, ""
+		default:
+			u.Scheme = "file"
+		}
+	}
+
+	return u, nil
+}
+
+// outputConfig — параметры назначения из строки запроса URL.
+type outputConfig struct {
+	level  string
+	format string
+}
+
+func parseOutputConfig(u *url.URL) (outputConfig, error) {
+	query := u.Query()
+
+	cfg := outputConfig{
+		level:  query.Get("level"),
+		format: query.Get("format"),
+	}
+
+	if _, exist := loggerLevelMap[cfg.level]; cfg.level != "" && !exist {
+		return cfg, fmt.Errorf("logger: unknown level %q in output %q", cfg.level, u.Redacted())
+	}
+
+	if cfg.format != "" {
+		if _, err := lookupEncoder(cfg.format); err != nil {
+			return cfg, err
+		}
+	}
+
+	return cfg, nil
+}
+
+func validateSinkURL(raw string) error {
+	u, err := parseSinkURL(raw)
+	if err != nil {
+		return err
+	}
+
+	if _, err := parseOutputConfig(u); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// outputTarget — открытое назначение с его форматом и уровнем. own
+// отмечает собственный уровень из URL, который заменяет уровень логгера.
+type outputTarget struct {
+	sink   Sink
+	format string
+	level  zapcore.LevelEnabler
+	own    bool
+}
+
+// recordParser реализуют назначения, разбирающие закодированные записи по
+// именам служебных полей логгера.
+type recordParser interface {
+	setRecordKeys(cfg zapcore.EncoderConfig, traceIDKey, spanIDKey string)
+}
+
+// openOutput открывает назначение с уровнем и форматом из URL либо
+// файлового вывода. Закрытие назначения остаётся за вызывающим.
+func (l *Logger) openOutput(raw string, encoderCfg zapcore.EncoderConfig, lvl zapcore.LevelEnabler) (outputTarget, error) {
+	u, err := parseSinkURL(raw)
+	if err != nil {
+		return outputTarget{}, err
+	}
+
+	cfg, err := parseOutputConfig(u)
+	if err != nil {
+		return outputTarget{}, err
+	}
+
+	format := cfg.format
+	if format == "" {
+		format = l.fileFormat()
+	}
+
+	if _, err := lookupEncoder(format); err != nil {
+		return outputTarget{}, err
+	}
+
+	if cfg.level != "" {
+		lvl = loggerLevelMap[cfg.level]
+	}
+
+	sink, err := l.openSink(u)
+	if err != nil {
+		return outputTarget{}, err
+	}
+
+	if d, ok := sink.(diagnosable); ok {
+		d.setDiagnostics(l.diag)
+	}
+
+	if p, ok := sink.(recordParser); ok {
+		traceIDKey, spanIDKey := l.traceKeys()
+		p.setRecordKeys(encoderCfg, traceIDKey, spanIDKey)
+	}
+
+	if rotator, ok := sink.(*fileRotator); ok {
+		lvl = rotatorLevel(lvl, rotator)
+	}
+
+	return outputTarget{sink: sink, format: format, level: lvl, own: cfg.level != ""}, nil
+}
+
+// newOutputCore открывает назначение и создаёт для него ядро.
+func (l *Logger) newOutputCore(raw string, encoderCfg zapcore.EncoderConfig, lvl zapcore.LevelEnabler) (zapcore.Core, error) {
+	o, err := l.openOutput(raw, encoderCfg, lvl)
+	if err != nil {
+		return nil, err
+	}
+
+	l.closers = append(l.closers, o.sink)
+
+	newEncoder, _ := l.encoderFactory(o.format)
+
+	core := zapcore.NewCore(newEncoder(encoderCfg), o.sink, o.level)
+	if o.own {
+		return ownLevelCore{Core: core}, nil
+	}
+
+	return core, nil
+}
+
+func (l *Logger) openSink(u *url.URL) (Sink, error) {
+	switch u.Scheme {
+	case "stdout":
+		return stdSink{consoleSyncer{os.Stdout}}, nil
+	case "stderr":
+		return stdSink{consoleSyncer{os.Stderr}}, nil
+	case "file":
+		return l.newFileRotator(u.Host + u.Path), nil
+	}
+
+	sinksMu.RLock()
+	factory, exist := sinks[u.Scheme]
+	sinksMu.RUnlock()
+
+	if exist {
+		return factory(u)
+	}
+
+	return openZapSink(u)
+}
+
+// openZapSink открывает назначение через zap.Open, что позволяет
+// использовать приёмники, зарегистрированные через zap.RegisterSink.
+// Параметры level и format этого пакета в zap не передаются.
+func openZapSink(u *url.URL) (Sink, error) {
+	zapURL := *u
+	query := zapURL.Query()
+	query.Del("level")
+	query.Del("format")
+	zapURL.RawQuery = query.Encode()
+
+	writer, closeFn, err := zap.Open(zapURL.String())
+	if err != nil {
+		return nil, fmt.Errorf("logger: cannot open output %q: %w", u.Redacted(), err)
+	}
+
+	return zapSink{WriteSyncer: writer, close: closeFn}, nil
+}
+
+type zapSink struct {
+	zapcore.WriteSyncer
+	close func()
+}
+
+func (s zapSink) Close() error {
+	s.close()
+
+	return nil
+}
+
+type stdSink struct {
+	consoleSyncer
+}
+
+func (stdSink) Close() error {
+	return nil
+}
+
+const (
+	netDialTimeout = 5 * time.Second
+	// netWriteTimeout ограничивает одну попытку записи в соединение, чтобы
+	// сборщик, переставший читать, не задерживал закрытие.
+	netWriteTimeout = time.Second
+	// netRedialInterval — пауза между попытками подключения и повторной
+	// отправкой после таймаута записи.
+	netRedialInterval = time.Second
+	// netBufferSize — предел записей, ожидающих отправки; при
+	// переполнении отбрасываются самые старые.
+	netBufferSize = 1 << 20
+)
+
+// netSink пишет записи в TCP или UDP соединение. Подключение и отправка
+// идут в фоновой горутине, поэтому недоступный или медленный сборщик не
+// задерживает вызовы логгера. Записи ждут отправки в ограниченном буфере
+// и отправляются после переподключения.
+type netSink struct {
+	network string
+	addr    string
+	diag    *zap.Logger
+	redial  time.Duration
+	wake    chan struct{}
+	done    chan struct{}
+	ctx     context.Context
+	cancel  context.CancelFunc
+
+	mu       sync.Mutex
+	conn     net.Conn
+	failed   bool
+	nextDial time.Time
+	dialing  bool
+	closed   bool
+	pending  [][]byte
+	buffered int
+	dropped  int
+	// sent — отправленная часть первой записи: TCP может принять запись
+	// частично, и остаток дописывается в то же соединение.
+	sent    int
+	sending bool
+	syncs   []chan error
+}
+
+func newNetSink(u *url.URL) (Sink, error) {
+	if u.Host == "" {
+		return nil, fmt.Errorf("logger: %s sink requires host:port", u.Scheme)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	s := &netSink{
+		network: u.Scheme,
+		addr:    u.Host,
+		redial:  netRedialInterval,
+		wake:    make(chan struct{}, 1),
+		done:    make(chan struct{}),
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+
+	goLabeled("net-sink", s.run)
+
+	return s, nil
+}
+
+func (s *netSink) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	s.enqueue(p)
+	s.mu.Unlock()
+
+	s.signal()
+
+	return len(p), nil
+}
+
+// enqueue копирует запись в буфер, вытесняя самые старые при
+// переполнении. Частично отправленная запись не вытесняется. Вызывается
+// под mu.
+func (s *netSink) enqueue(p []byte) {
+	s.pending = append(s.pending, append([]byte(nil), p...))
+	s.buffered += len(p)
+
+	for s.buffered > netBufferSize && len(s.pending) > 1 {
+		i := 0
+		if s.sending || s.sent > 0 {
+			i = 1
+		}
+
+		s.buffered -= len(s.pending[i])
+		s.pending = append(s.pending[:i], s.pending[i+1:]...)
+		s.dropped++
+	}
+}
+
+// signal будит горутину отправки.
+func (s *netSink) signal() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// run подключается и отправляет накопленные записи, пока назначение не
+// закрыто.
+func (s *netSink) run() {
+	defer close(s.done)
+	defer s.notifySynced(nil)
+
+	for {
+		s.mu.Lock()
+		conn, closed := s.conn, s.closed
+		dial := conn == nil && !closed && !time.Now().Before(s.nextDial)
+		s.dialing = dial
+		s.mu.Unlock()
+
+		if dial {
+			conn = s.dial()
+		}
+
+		var err error
+		if conn != nil {
+			err = s.flush(conn)
+		}
+
+		s.notifySynced(err)
+
+		s.mu.Lock()
+		closed = s.closed
+		retry := len(s.pending) > 0
+		s.mu.Unlock()
+
+		if closed {
+			return
+		}
+
+		var timer <-chan time.Time
+		if retry {
+			timer = time.After(s.redial)
+		}
+
+		select {
+		case <-s.wake:
+		case <-timer:
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
+
+// dial подключается к сборщику; при неудаче следующая попытка будет не
+// раньше чем через redial.
+func (s *netSink) dial() net.Conn {
+	dialer := net.Dialer{Timeout: netDialTimeout}
+	conn, err := dialer.DialContext(s.ctx, s.network, s.addr)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.dialing = false
+
+	if err != nil {
+		// Пауза отсчитывается от завершения попытки: иначе долгий таймаут
+		// подключения съедал бы её целиком.
+		s.nextDial = time.Now().Add(s.redial)
+		if !s.failed {
+			diagLogger(s.diag).Warn("sink connect failed", zap.String("addr", s.network+"://"+s.addr), zap.Error(err))
+			s.failed = true
+		}
+		return nil
+	}
+
+	if s.failed {
+		diagLogger(s.diag).Info("sink reconnected",
+			zap.String("addr", s.network+"://"+s.addr),
+			zap.Int("buffered", len(s.pending)),
+			zap.Int("dropped", s.dropped),
+		)
+	}
+
+	s.conn = conn
+	s.failed = false
+	s.dropped = 0
+
+	return conn
+}
+
+// flush отправляет накопленные записи в conn без удержания mu, чтобы
+// медленный сборщик не блокировал Write. Запись, которую сеть не примет
+// никогда (например, датаграмма больше допустимого размера), отбрасывается.
+// После таймаута соединение сохраняется, и остаток записи отправляется
+// при следующей попытке; при других ошибках соединение закрывается, а
+// запись после переподключения отправляется целиком.
+func (s *netSink) flush(conn net.Conn) error {
+	for {
+		s.mu.Lock()
+		if len(s.pending) == 0 {
+			s.mu.Unlock()
+			return nil
+		}
+		record := s.pending[0][s.sent:]
+		s.sending = true
+		s.mu.Unlock()
+
+		_ = conn.SetWriteDeadline(time.Now().Add(netWriteTimeout))
+		n, err := conn.Write(record)
+
+		s.mu.Lock()
+		s.sending = false
+		s.sent += n
+
+		var netErr net.Error
+
+		switch {
+		case err == nil:
+			s.popRecord()
+		case permanentWriteError(err):
+			diagLogger(s.diag).Warn("sink record dropped", zap.String("addr", s.network+"://"+s.addr), zap.Error(err))
+			s.popRecord()
+			s.dropped++
+		case errors.As(err, &netErr) && netErr.Timeout():
+			s.mu.Unlock()
+			return err
+		default:
+			diagLogger(s.diag).Warn("sink write failed", zap.String("addr", s.network+"://"+s.addr), zap.Error(err))
+			_ = conn.Close()
+			if s.conn == conn {
+				s.conn = nil
+			}
+			s.sent = 0
+			s.failed = true
+			s.mu.Unlock()
+			return err
+		}
+		s.mu.Unlock()
+	}
+}
+
+// popRecord убирает из буфера первую запись. Вызывается под mu.
+func (s *netSink) popRecord() {
+	s.buffered -= len(s.pending[0])
+	s.pending[0] = nil
+	s.pending = s.pending[1:]
+	s.sent = 0
+
+	if len(s.pending) == 0 {
+		s.pending = nil
+	}
+}
+
+// permanentWriteError сообщает, что повторная отправка записи не поможет.
+func permanentWriteError(err error) bool {
+	return errors.Is(err, syscall.EMSGSIZE)
+}
+
+// notifySynced передаёт результат отправки ожидающим вызовам Sync.
+func (s *netSink) notifySynced(err error) {
+	s.mu.Lock()
+	syncs := s.syncs
+	s.syncs = nil
+	s.mu.Unlock()
+
+	for _, synced := range syncs {
+		synced <- err
+	}
+}
+
+func (s *netSink) setDiagnostics(diag *zap.Logger) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.diag = diag
+}
+
+// Sync дожидается попытки отправить накопленные записи. Без соединения
+// Sync не ждёт: записи будут отправлены после подключения.
+func (s *netSink) Sync() error {
+	s.mu.Lock()
+	if len(s.pending) == 0 || s.conn == nil || s.closed {
+		s.mu.Unlock()
+		s.signal()
+		return nil
+	}
+
+	synced := make(chan error, 1)
+	s.syncs = append(s.syncs, synced)
+	s.mu.Unlock()
+
+	s.signal()
+
+	return <-synced
+}
+
+func (s *netSink) Close() error {
+	return s.closeContext(context.Background())
+}
+
+// closeContext дожидается идущего подключения и отправки накопленных
+// записей не дольше ctx и закрывает соединение.
+func (s *netSink) closeContext(ctx context.Context) error {
+	s.mu.Lock()
+	s.closed = true
+	s.mu.Unlock()
+
+	s.signal()
+
+	select {
+	case <-s.done:
+	case <-ctx.Done():
+		s.cancel()
+
+		// Закрытие соединения прерывает зависшую запись.
+		s.mu.Lock()
+		if s.conn != nil {
+			_ = s.conn.Close()
+		}
+		s.mu.Unlock()
+
+		<-s.done
+	}
+	s.cancel()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.conn == nil {
+		return nil
+	}
+
+	err := s.conn.Close()
+	s.conn = nil
+
+	return err
+}
@@ -0,0 +1,355 @@
+package logger
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type memorySink struct {
+	buf    bytes.Buffer
+	closed bool
+	mu     sync.Mutex
+}
+
+func (s *memorySink) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.buf.Write(p)
+}
+
+func (s *memorySink) Sync() error {
+	return nil
+}
+
+func (s *memorySink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.closed = true
+
+	return nil
+}
+
+func (s *memorySink) String() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.buf.String()
+}
+
+// TestParseSinkURL проверяет разбор URL назначений.
+func TestParseSinkURL(t *testing.T) {
+	tests := []struct {
+		raw    string
+		scheme string
+		path   string
+		host   string
+	}{
+		{raw: "stdout", scheme: "stdout"},
+		{raw: "stderr", scheme: "stderr"},
+		{raw: "stderr?level=warn", scheme: "stderr"},
+		{raw: "file:///var/log/app", scheme: "file", path: "/var/log/app"},
+		{raw: "/var/log/app", scheme: "file", path: "/var/log/app"},
+		{raw: `C:\logs\app`, scheme: "file", path: `C:\logs\app`},
+		{raw: "tcp://collector:5000", scheme: "tcp", host: "collector:5000"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			u, err := parseSinkURL(tt.raw)
+			require.NoError(t, err)
+			assert.Equal(t, tt.scheme, u.Scheme)
+			assert.Equal(t, tt.path, u.Path)
+			assert.Equal(t, tt.host, u.Host)
+		})
+	}
+}
+
+// TestRegisterSink проверяет регистрацию схемы и запись через Outputs.
+func TestRegisterSink(t *testing.T) {
+	sink := &memorySink{}
+	err := RegisterSink("test-memory", func(u *url.URL) (Sink, error) {
+		return sink, nil
+	})
+	require.NoError(t, err)
+
+	assert.Error(t, RegisterSink("file", nil), "Builtin schemes should not be overridden")
+
+	tmpDir, err := os.MkdirTemp("", "logger_test")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	logger := NewLogger(Path(tmpDir), Outputs("test-memory://"))
+	require.NoError(t, logger.Init(false))
+
+	logger.Info("Test log message")
+	require.NoError(t, logger.Close())
+
+	assert.Contains(t, sink.String(), "Test log message")
+	assert.True(t, sink.closed, "Sink should be closed with logger")
+
+	err = NewLogger(Path(tmpDir), Outputs("unknown://host")).Init(false)
+	assert.ErrorContains(t, err, "cannot open output")
+}
+
+// TestNetSink проверяет отправку записей по TCP.
+func TestNetSink(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	received := make(chan string, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		line, _ := bufio.NewReader(conn).ReadString('\n')
+		received <- line
+	}()
+
+	sink, err := newNetSink(&url.URL{Scheme: "tcp", Host: listener.Addr().String()})
+	require.NoError(t, err)
+	defer sink.Close()
+
+	_, err = sink.Write([]byte("test log\n"))
+	require.NoError(t, err)
+
+	select {
+	case line := <-received:
+		assert.Equal(t, "test log\n", line)
+	case <-time.After(time.Second):
+		t.Fatal("Entry should be received")
+	}
+}
+
+// TestNetSinkBuffersUntilReconnect проверяет, что записи, сделанные без
+// соединения, отправляются после появления сборщика.
+func TestNetSinkBuffersUntilReconnect(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := listener.Addr().String()
+	require.NoError(t, listener.Close())
+
+	sink, err := newNetSink(&url.URL{Scheme: "tcp", Host: addr})
+	require.NoError(t, err)
+	defer sink.Close()
+
+	_, err = sink.Write([]byte("first\n"))
+	require.NoError(t, err)
+
+	// Запись не ждёт подключения, а до истечения паузы повторное
+	// подключение не выполняется.
+	start := time.Now()
+	_, err = sink.Write([]byte("second\n"))
+	require.NoError(t, err)
+	assert.Less(t, time.Since(start), netRedialInterval)
+
+	listener, err = net.Listen("tcp", addr)
+	require.NoError(t, err)
+	defer listener.Close()
+
+	received := make(chan []string, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		reader := bufio.NewReader(conn)
+		var lines []string
+		for len(lines) < 3 {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				break
+			}
+			lines = append(lines, line)
+		}
+		received <- lines
+	}()
+
+	s := sink.(*netSink)
+	assert.Eventually(t, func() bool {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		return !s.dialing && (s.conn != nil || !s.nextDial.IsZero())
+	}, netDialTimeout, 10*time.Millisecond)
+	s.mu.Lock()
+	s.nextDial = time.Time{}
+	s.mu.Unlock()
+
+	_, err = sink.Write([]byte("third\n"))
+	require.NoError(t, err)
+
+	select {
+	case lines := <-received:
+		assert.Equal(t, []string{"first\n", "second\n", "third\n"}, lines)
+	case <-time.After(time.Second):
+		t.Fatal("Entries should be received")
+	}
+}
+
+// TestNetworkSink проверяет назначение, добавленное опцией NetworkSink.
+func TestNetworkSink(t *testing.T) {
+	logger := NewLogger(NetworkSink("udp", "127.0.0.1:5000"))
+	assert.Equal(t, []string{"udp://127.0.0.1:5000"}, logger.outputs)
+
+	_, err := Build(Path(t.TempDir()), NetworkSink("sctp", "127.0.0.1:5000"))
+	assert.ErrorContains(t, err, `unsupported network "sctp"`)
+}
+
+// TestOutputsLevelAndFormat проверяет уровень и формат назначения из URL.
+func TestOutputsLevelAndFormat(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "logger_test")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	outputDir, err := os.MkdirTemp("", "logger_test")
+	require.NoError(t, err)
+	defer os.RemoveAll(outputDir)
+
+	logger := NewLogger(Path(tmpDir), Outputs("file://"+outputDir+"?format=json&level=warn"))
+	require.NoError(t, logger.Init(false))
+
+	logger.Info("info message")
+	logger.Warn("warn message")
+	require.NoError(t, logger.Close())
+
+	content, err := os.ReadFile(filepath.Join(outputDir, time.Now().Format(dateLayout)+".log"))
+	require.NoError(t, err)
+
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal(content, &entry), "Output should contain a single JSON entry")
+	assert.Equal(t, "warn message", entry["message"])
+
+	content, err = os.ReadFile(filepath.Join(tmpDir, time.Now().Format(dateLayout)+".log"))
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "info message", "Main file should keep its own level")
+
+	err = NewLogger(Path(tmpDir), Outputs("stderr?level=verbose")).Init(false)
+	assert.ErrorContains(t, err, "unknown level")
+}
+
+// TestZapSinkOutput проверяет использование приёмника, зарегистрированного в zap.
+func TestZapSinkOutput(t *testing.T) {
+	sink := &memorySink{}
+	err := zap.RegisterSink("test-zap-memory", func(u *url.URL) (zap.Sink, error) {
+		assert.Empty(t, u.Query().Get("level"), "Package parameters should not be passed to zap")
+		return sink, nil
+	})
+	require.NoError(t, err)
+
+	tmpDir, err := os.MkdirTemp("", "logger_test")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	logger := NewLogger(Path(tmpDir), Outputs("test-zap-memory://?level=warn"))
+	require.NoError(t, logger.Init(false))
+
+	logger.Info("info message")
+	logger.Warn("warn message")
+	require.NoError(t, logger.Close())
+
+	assert.NotContains(t, sink.String(), "info message")
+	assert.Contains(t, sink.String(), "warn message")
+	assert.True(t, sink.closed, "Zap sink should be closed with logger")
+}
+
+// TestNetSinkWriteDoesNotWaitForDial проверяет, что подключение к
+// сборщику, не отвечающему на SYN, не задерживает запись.
+func TestNetSinkWriteDoesNotWaitForDial(t *testing.T) {
+	// 192.0.2.0/24 (TEST-NET-1) не маршрутизируется.
+	sink, err := newNetSink(&url.URL{Scheme: "tcp", Host: "192.0.2.1:5000"})
+	require.NoError(t, err)
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		_, err = sink.Write([]byte("line\n"))
+		require.NoError(t, err)
+	}
+	assert.Less(t, time.Since(start), 100*time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start = time.Now()
+	require.NoError(t, sink.(*netSink).closeContext(ctx))
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+// TestNetSinkWriteDoesNotWaitForCollector проверяет, что сборщик,
+// переставший читать, не задерживает запись.
+func TestNetSinkWriteDoesNotWaitForCollector(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	accepted := make(chan net.Conn, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err == nil {
+			accepted <- conn
+		}
+	}()
+
+	sink, err := newNetSink(&url.URL{Scheme: "tcp", Host: listener.Addr().String()})
+	require.NoError(t, err)
+
+	record := []byte(strings.Repeat("x", 64<<10) + "\n")
+
+	start := time.Now()
+	for i := 0; i < 64; i++ {
+		_, err = sink.Write(record)
+		require.NoError(t, err)
+	}
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+
+	conn := <-accepted
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	require.NoError(t, sink.(*netSink).closeContext(ctx))
+}
+
+// TestNetSinkDropsOversizedDatagram проверяет, что датаграмма, которую
+// сеть не примет, отбрасывается и не задерживает следующие записи.
+func TestNetSinkDropsOversizedDatagram(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer conn.Close()
+
+	sink, err := newNetSink(&url.URL{Scheme: "udp", Host: conn.LocalAddr().String()})
+	require.NoError(t, err)
+	defer sink.Close()
+
+	_, err = sink.Write(make([]byte, 70000))
+	require.NoError(t, err)
+	_, err = sink.Write([]byte("small\n"))
+	require.NoError(t, err)
+
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(time.Second)))
+	buf := make([]byte, 1024)
+	n, _, err := conn.ReadFrom(buf)
+	require.NoError(t, err)
+	assert.Equal(t, "small\n", string(buf[:n]))
+}
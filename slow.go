@@ -0,0 +1,54 @@
+package logger
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// TrackDeadline засекает начало операции и возвращает функцию, которую
+// вызывают по её завершении:
+//
+//	defer log.TrackDeadline(ctx, "load orders", 0.8)()
+//
+// Если у ctx есть дедлайн и операция израсходовала не меньше доли
+// threshold отведённого времени, пишется предупреждение с затраченным
+// временем и бюджетом. Без дедлайна функция ничего не делает.
+func (l *Logger) TrackDeadline(ctx context.Context, operation string, threshold float64) func() {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return func() {}
+	}
+
+	start := l.now()
+	budget := deadline.Sub(start)
+
+	return func() {
+		elapsed := l.now().Sub(start)
+		if budget > 0 && float64(elapsed) < threshold*float64(budget) {
+			return
+		}
+
+		used := 1.0
+		if budget > 0 {
+			used = float64(elapsed) / float64(budget)
+		}
+
+		l.baseLogger.Warn("slow operation",
+			zap.String("operation", operation),
+			zap.Duration("elapsed", elapsed),
+			zap.Duration("budget", budget),
+			zap.Float64("budget_used", used),
+			zap.Bool("deadline_exceeded", ctx.Err() != nil),
+		)
+	}
+}
+
+func (l *Logger) now() time.Time {
+	if l.clock != nil {
+		return l.clock.Now()
+	}
+
+	return time.Now()
+}
@@ -0,0 +1,53 @@
+package logger
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// TestTrackDeadline проверяет предупреждение при исчерпании бюджета.
+func TestTrackDeadline(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2024, 5, 28, 12, 0, 0, 0, time.Local)}
+	core, logs := observer.New(zap.DebugLevel)
+
+	logger := NewLogger(WithClock(clock))
+	logger.baseLogger = zap.New(core)
+
+	ctx, cancel := context.WithDeadline(context.Background(), clock.Now().Add(10*time.Second))
+	defer cancel()
+
+	done := logger.TrackDeadline(ctx, "fast", 0.8)
+	clock.Add(2 * time.Second)
+	done()
+
+	assert.Zero(t, logs.Len())
+
+	done = logger.TrackDeadline(ctx, "slow", 0.8)
+	clock.Add(7 * time.Second)
+	done()
+
+	entries := logs.All()
+	if assert.Len(t, entries, 1) {
+		fields := entries[0].ContextMap()
+		assert.Equal(t, "slow", fields["operation"])
+		assert.Equal(t, 7*time.Second, fields["elapsed"])
+		assert.Equal(t, 8*time.Second, fields["budget"])
+	}
+}
+
+// TestTrackDeadlineWithoutDeadline проверяет, что без дедлайна ничего не пишется.
+func TestTrackDeadlineWithoutDeadline(t *testing.T) {
+	core, logs := observer.New(zap.DebugLevel)
+
+	logger := NewLogger()
+	logger.baseLogger = zap.New(core)
+
+	logger.TrackDeadline(context.Background(), "op", 0.5)()
+
+	assert.Zero(t, logs.Len())
+}
@@ -0,0 +1,80 @@
+package logger
+
+import (
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// templateKey — поле с исходным шаблоном сообщения, по которому удобно
+// группировать записи.
+const templateKey = "template"
+
+// Msg пишет на уровне info сообщение по шаблону с именованными
+// подстановками:
+//
+//	log.Msg("user {user_id} purchased {sku}", zap.Int("user_id", 7), zap.String("sku", "A-1"))
+//
+// Подстановки заполняются значениями полей записи и полей WithFields,
+// сами поля и шаблон тоже попадают в запись. Подстановки без поля
+// остаются как есть; {{ и }} выводят фигурные скобки.
+func (l *Logger) Msg(template string, fields ...zap.Field) {
+	ce := l.baseLogger.Check(zapcore.InfoLevel, template)
+	if ce == nil {
+		return
+	}
+
+	ce.Message = renderTemplate(template, l.fields, fields)
+	ce.Write(append(fields, zap.String(templateKey, template))...)
+}
+
+// renderTemplate подставляет в шаблон значения полей; поля записи
+// перекрывают поля логгера.
+func renderTemplate(template string, groups ...[]zap.Field) string {
+	if !strings.ContainsAny(template, "{}") {
+		return template
+	}
+
+	enc := zapcore.NewMapObjectEncoder()
+	for _, fields := range groups {
+		for _, field := range fields {
+			field.AddTo(enc)
+		}
+	}
+
+	var b strings.Builder
+
+	for i := 0; i < len(template); i++ {
+		c := template[i]
+
+		if (c == '{' || c == '}') && i+1 < len(template) && template[i+1] == c {
+			b.WriteByte(c)
+			i++
+			continue
+		}
+
+		if c != '{' {
+			b.WriteByte(c)
+			continue
+		}
+
+		end := strings.IndexByte(template[i:], '}')
+		if end < 0 {
+			b.WriteString(template[i:])
+			break
+		}
+
+		name := template[i+1 : i+end]
+		if value, ok := enc.Fields[name]; ok {
+			fmt.Fprint(&b, value)
+		} else {
+			b.WriteString(template[i : i+end+1])
+		}
+
+		i += end
+	}
+
+	return b.String()
+}
@@ -0,0 +1,52 @@
+package logger
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// TestRenderTemplate проверяет подстановки и экранирование скобок.
+func TestRenderTemplate(t *testing.T) {
+	fields := []zap.Field{zap.Int("user_id", 7), zap.String("sku", "A-1")}
+
+	tests := []struct {
+		template string
+		want     string
+	}{
+		{"plain message", "plain message"},
+		{"user {user_id} purchased {sku}", "user 7 purchased A-1"},
+		{"user {unknown}", "user {unknown}"},
+		{"{{user_id}} is {user_id}", "{user_id} is 7"},
+		{"unclosed {user_id", "unclosed {user_id"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, renderTemplate(tt.template, fields), tt.template)
+	}
+
+	assert.Equal(t, "user 8", renderTemplate("user {user_id}", fields, []zap.Field{zap.Int("user_id", 8)}))
+}
+
+// TestMsg проверяет сообщение, поля и шаблон записи.
+func TestMsg(t *testing.T) {
+	core, logs := observer.New(zap.DebugLevel)
+
+	logger := NewLogger()
+	logger.baseLogger = zap.New(core)
+
+	child := logger.WithFields(map[string]interface{}{"user_id": 7})
+	child.Msg("user {user_id} purchased {sku}", zap.String("sku", "A-1"))
+
+	entries := logs.All()
+	if assert.Len(t, entries, 1) {
+		assert.Equal(t, "user 7 purchased A-1", entries[0].Message)
+		assert.Equal(t, map[string]interface{}{
+			"user_id":  int64(7),
+			"sku":      "A-1",
+			"template": "user {user_id} purchased {sku}",
+		}, entries[0].ContextMap())
+	}
+}
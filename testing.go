@@ -0,0 +1,57 @@
+package logger
+
+import (
+	"fmt"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest"
+)
+
+// FailTestAt заставляет логгер из ForTesting помечать тест упавшим
+// (t.Errorf), если тестируемый код пишет запись уровня level или выше.
+// Вне ForTesting опция ни на что не влияет. Неизвестный уровень
+// игнорируется (ForTesting и Build сообщают об ошибке).
+func FailTestAt(level string) Option {
+	return func(l *Logger) {
+		if _, exist := loggerLevelMap[level]; !exist {
+			l.optionErrs = append(l.optionErrs, fmt.Errorf("logger: unknown fail test level %q", level))
+			return
+		}
+		l.failTestAt = level
+	}
+}
+
+// ForTesting возвращает логгер, пишущий через t.Log: записи привязаны к
+// тесту, безопасны для параллельных тестов и выводятся только при падении
+// теста или с флагом -v. По умолчанию уровень debug, его можно изменить
+// опцией Level. Ошибки опций помечают тест упавшим.
+func ForTesting(t testing.TB, options ...Option) *Logger {
+	t.Helper()
+
+	l := NewLogger(append([]Option{Level("debug")}, options...)...)
+	for _, err := range l.optionErrs {
+		t.Errorf("%v", err)
+	}
+
+	zapOptions := l.callerOptions()
+
+	if l.failTestAt != "" {
+		failLevel := loggerLevelMap[l.failTestAt]
+		zapOptions = append(zapOptions, zap.Hooks(func(entry zapcore.Entry) error {
+			if entry.Level >= failLevel {
+				t.Errorf("unexpected %s log: %s", entry.Level, entry.Message)
+			}
+			return nil
+		}))
+	}
+
+	l.baseLogger = zaptest.NewLogger(t,
+		zaptest.Level(l.getLoggerLevel()),
+		zaptest.WrapOptions(zapOptions...),
+	)
+	l.sugarLogger = l.baseLogger.Sugar()
+
+	return l
+}
@@ -0,0 +1,67 @@
+package logger
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// recordingTB перехватывает вывод t.Log.
+type recordingTB struct {
+	testing.TB
+	logs   []string
+	errors []string
+}
+
+func (r *recordingTB) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func (r *recordingTB) Logf(format string, args ...interface{}) {
+	r.logs = append(r.logs, fmt.Sprintf(format, args...))
+}
+
+func (r *recordingTB) Helper() {}
+
+// TestForTesting проверяет вывод записей через t.Log.
+func TestForTesting(t *testing.T) {
+	tb := &recordingTB{TB: t}
+
+	logger := ForTesting(tb, Level("info"))
+	logger.Debug("debug message")
+	logger.WithFields(map[string]interface{}{"key": "value"}).Info("info message")
+
+	if assert.Len(t, tb.logs, 1) {
+		assert.Contains(t, tb.logs[0], "info message")
+		assert.Contains(t, tb.logs[0], `"key": "value"`)
+	}
+}
+
+// TestForTestingFailTestAt проверяет падение теста при записи уровня error.
+func TestForTestingFailTestAt(t *testing.T) {
+	tb := &recordingTB{TB: t}
+
+	logger := ForTesting(tb, FailTestAt("error"))
+	logger.Warn("warn message")
+	assert.Empty(t, tb.errors)
+
+	logger.Error("error message")
+	if assert.Len(t, tb.errors, 1) {
+		assert.Contains(t, tb.errors[0], "error message")
+	}
+}
+
+// TestForTestingFailTestAtUnknownLevel проверяет, что неизвестный уровень
+// помечает тест упавшим, а не подменяется молча.
+func TestForTestingFailTestAtUnknownLevel(t *testing.T) {
+	tb := &recordingTB{TB: t}
+
+	logger := ForTesting(tb, FailTestAt("fatl"))
+	if assert.Len(t, tb.errors, 1) {
+		assert.Contains(t, tb.errors[0], `unknown fail test level "fatl"`)
+	}
+
+	logger.Error("error message")
+	assert.Len(t, tb.errors, 1)
+}
@@ -0,0 +1,53 @@
+package logger
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultTraceIDKey = "trace_id"
+	defaultSpanIDKey  = "span_id"
+)
+
+// TraceFields задаёт имена полей идентификаторов трассировки и спана,
+// которые методы *Ctx берут из спана OpenTelemetry в контексте. По
+// умолчанию — "trace_id" и "span_id"; пустые имена отключают поля.
+func TraceFields(traceIDKey, spanIDKey string) Option {
+	return func(l *Logger) {
+		l.traceIDKey = &traceIDKey
+		l.spanIDKey = &spanIDKey
+	}
+}
+
+// traceKeys возвращает имена полей идентификаторов трассировки и спана
+// с учётом TraceFields.
+func (l *Logger) traceKeys() (traceIDKey, spanIDKey string) {
+	if l.traceIDKey != nil {
+		return *l.traceIDKey, *l.spanIDKey
+	}
+
+	return defaultTraceIDKey, defaultSpanIDKey
+}
+
+// traceFields возвращает поля идентификаторов активного спана ctx.
+func (l *Logger) traceFields(ctx context.Context) []zap.Field {
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.IsValid() {
+		return nil
+	}
+
+	traceIDKey, spanIDKey := l.traceKeys()
+
+	var fields []zap.Field
+	if traceIDKey != "" {
+		fields = append(fields, zap.String(traceIDKey, spanContext.TraceID().String()))
+	}
+	if spanIDKey != "" {
+		fields = append(fields, zap.String(spanIDKey, spanContext.SpanID().String()))
+	}
+
+	return fields
+}
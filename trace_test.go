@@ -0,0 +1,61 @@
+package logger
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func spanContext(t *testing.T) context.Context {
+	t.Helper()
+
+	traceID, err := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	require.NoError(t, err)
+	spanID, err := trace.SpanIDFromHex("00f067aa0ba902b7")
+	require.NoError(t, err)
+
+	sc := trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID, SpanID: spanID, TraceFlags: trace.FlagsSampled})
+
+	return trace.ContextWithSpanContext(context.Background(), sc)
+}
+
+func TestTraceFields(t *testing.T) {
+	tests := []struct {
+		name    string
+		options []Option
+		fields  map[string]interface{}
+	}{
+		{
+			name:   "default",
+			fields: map[string]interface{}{"trace_id": "4bf92f3577b34da6a3ce929d0e0e4736", "span_id": "00f067aa0ba902b7"},
+		},
+		{
+			name:    "custom names",
+			options: []Option{TraceFields("traceId", "")},
+			fields:  map[string]interface{}{"traceId": "4bf92f3577b34da6a3ce929d0e0e4736"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			core, logs := observer.New(zap.DebugLevel)
+
+			logger := NewLogger(tt.options...)
+			logger.baseLogger = zap.New(core)
+			logger.sugarLogger = logger.baseLogger.Sugar()
+
+			logger.InfoCtx(spanContext(t), "traced")
+			logger.InfoCtx(context.Background(), "untraced")
+
+			entries := logs.All()
+			require.Len(t, entries, 2)
+			assert.Equal(t, tt.fields, entries[0].ContextMap())
+			assert.Empty(t, entries[1].ContextMap())
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package logger
+
+import (
+	"go.uber.org/zap/zapcore"
+)
+
+// VerboseFunc решает, выводить ли запись, которую отсеял бы уровень
+// логгера. fields содержит поля записи вместе с полями WithFields,
+// например идентификатор пользователя или арендатора.
+type VerboseFunc func(entry zapcore.Entry, fields map[string]interface{}) bool
+
+// VerboseWhen включает точечную детализацию: записи ниже текущего уровня
+// выводятся, если fn вернёт true. fn может обращаться к сервису
+// флагов, но вызывается для каждой такой записи, поэтому должна быть
+// быстрой.
+func VerboseWhen(fn VerboseFunc) Option {
+	return func(l *Logger) {
+		l.verbose = fn
+	}
+}
+
+// verboseCore передаёт записи ниже уровня логгера в VerboseFunc.
+// Одобренные записи получают выводы с общим уровнем, если запись проходит
+// их собственные ограничения (см. gateCore); выводы с собственным уровнем
+// получают записи как обычно.
+type verboseCore struct {
+	zapcore.Core
+	fn     VerboseFunc
+	gate   *levelGate
+	fields []zapcore.Field
+}
+
+func (c *verboseCore) Enabled(zapcore.Level) bool {
+	return true
+}
+
+func (c *verboseCore) With(fields []zapcore.Field) zapcore.Core {
+	return &verboseCore{
+		Core:   c.Core.With(fields),
+		fn:     c.fn,
+		gate:   c.gate,
+		fields: append(c.fields[:len(c.fields):len(c.fields)], fields...),
+	}
+}
+
+func (c *verboseCore) Check(entry zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	ce = c.Core.Check(entry, ce)
+	if c.gate.allows(entry, false) {
+		return ce
+	}
+
+	return ce.AddCore(entry, verboseWrite{c})
+}
+
+// verboseWrite выводит запись ниже уровня логгера, если её одобрит
+// VerboseFunc. Write самого verboseCore передаёт записи дальше без
+// проверки: так проходят записи, уже выбранные выше, например WithDebugRing.
+type verboseWrite struct {
+	*verboseCore
+}
+
+func (w verboseWrite) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	enc := zapcore.NewMapObjectEncoder()
+	for _, field := range w.fields {
+		field.AddTo(enc)
+	}
+	for _, field := range fields {
+		field.AddTo(enc)
+	}
+
+	if !w.fn(entry, enc.Fields) {
+		return nil
+	}
+
+	return w.Core.Write(entry, fields)
+}
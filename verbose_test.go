@@ -0,0 +1,60 @@
+package logger
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// TestVerboseWhen проверяет вывод debug только для выбранного арендатора.
+func TestVerboseWhen(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+
+	verbose := func(entry zapcore.Entry, fields map[string]interface{}) bool {
+		return fields["tenant"] == "acme"
+	}
+
+	logger := NewLogger()
+	logger.baseLogger = zap.New(&verboseCore{Core: core, fn: verbose, gate: newLevelGate(zap.InfoLevel, nil)})
+
+	acme := logger.WithFields(map[string]interface{}{"tenant": "acme"})
+	other := logger.WithFields(map[string]interface{}{"tenant": "other"})
+
+	acme.baseLogger.Debug("acme debug")
+	other.baseLogger.Debug("other debug")
+	logger.baseLogger.Debug("call field debug", zap.String("tenant", "acme"))
+	other.baseLogger.Info("other info")
+
+	var messages []string
+	for _, entry := range logs.All() {
+		messages = append(messages, entry.Message)
+	}
+
+	assert.Equal(t, []string{"acme debug", "call field debug", "other info"}, messages)
+}
+
+// TestVerboseWhenOutputLevel проверяет, что одобренные записи не попадают
+// в назначения с собственным уровнем.
+func TestVerboseWhenOutputLevel(t *testing.T) {
+	dir, outputDir := t.TempDir(), t.TempDir()
+
+	verbose := func(entry zapcore.Entry, fields map[string]interface{}) bool {
+		return fields["tenant"] == "acme"
+	}
+
+	logger := NewLogger(Path(dir), Structured(true), AppName("app"), Level("info"),
+		Outputs("file://"+outputDir+"?format=json&level=error"), VerboseWhen(verbose))
+	require.NoError(t, logger.Init(false))
+
+	logger.WithFields(map[string]interface{}{"tenant": "acme"}).Debug("acme debug")
+	logger.WithFields(map[string]interface{}{"tenant": "other"}).Debug("other debug")
+	logger.Error("root error")
+	require.NoError(t, logger.Close())
+
+	assert.Equal(t, []interface{}{"acme debug", "root error"}, logMessages(t, dir))
+	assert.Equal(t, []interface{}{"root error"}, logMessages(t, outputDir))
+}
@@ -0,0 +1,42 @@
+package logger
+
+import (
+	"io"
+	"strings"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Writer возвращает io.Writer, записывающий каждую непустую строку как
+// запись уровня level без caller. Через него в логгер направляется вывод
+// библиотек, принимающих io.Writer. Неизвестный уровень заменяется на
+// info.
+func (l *Logger) Writer(level string) io.Writer {
+	lvl, exist := loggerLevelMap[level]
+	if !exist {
+		lvl = zapcore.InfoLevel
+	}
+
+	return &lineWriter{logger: l.baseLogger.WithOptions(zap.WithCaller(false)), level: lvl}
+}
+
+type lineWriter struct {
+	logger *zap.Logger
+	level  zapcore.Level
+}
+
+func (w *lineWriter) Write(p []byte) (int, error) {
+	for _, line := range strings.Split(string(p), "\n") {
+		line = strings.TrimRight(line, "\r")
+		if line == "" {
+			continue
+		}
+
+		if ce := w.logger.Check(w.level, line); ce != nil {
+			ce.Write()
+		}
+	}
+
+	return len(p), nil
+}
@@ -0,0 +1,31 @@
+package logger
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestWriter проверяет запись строк через io.Writer.
+func TestWriter(t *testing.T) {
+	tmpDir := t.TempDir()
+	logger := NewLogger(Path(tmpDir), Structured(true))
+	require.NoError(t, logger.Init(false))
+
+	_, err := fmt.Fprint(logger.Writer("warn"), "first\r\n\nsecond\n")
+	require.NoError(t, err)
+	_, err = fmt.Fprintln(logger.Writer("debug"), "hidden")
+	require.NoError(t, err)
+	require.NoError(t, logger.Close())
+
+	lines := readJSONLines(t, filepath.Join(tmpDir, time.Now().Format(dateLayout)+".log"))
+	require.Len(t, lines, 2)
+	assert.Equal(t, "first", lines[0]["message"])
+	assert.Equal(t, "warn", lines[0]["level"])
+	assert.Equal(t, "second", lines[1]["message"])
+	assert.NotContains(t, lines[0], "caller")
+}